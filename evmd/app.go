@@ -2,13 +2,16 @@ package evmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"os"
+	"path/filepath"
 	"sort"
 
 	corevm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/spf13/cast"
 
 	// Force-load the tracer engines to trigger registration due to Go-Ethereum v1.10.15 changes
@@ -40,6 +43,7 @@ import (
 	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
 	"github.com/cosmos/evm/x/vm"
 	evmkeeper "github.com/cosmos/evm/x/vm/keeper"
+	"github.com/cosmos/evm/x/vm/tracers/live"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 	"github.com/cosmos/gogoproto/proto"
 	ibctransfer "github.com/cosmos/ibc-go/v10/modules/apps/transfer"
@@ -222,6 +226,11 @@ type EVMD struct {
 
 	// module configurator
 	configurator module.Configurator
+
+	// tracerOutput is the file the EVM tracer writes to, if any
+	tracerOutput *os.File
+	// preimageDB is the database of the SHA3 preimages seen by the EVM, if any
+	preimageDB dbm.DB
 }
 
 // NewExampleApp returns a reference to an initialized EVMD.
@@ -507,6 +516,57 @@ func NewExampleApp(
 		tracer,
 	)
 
+	// Set up the logger configuration of the EVM tracer
+	tracerConfig := evmtypes.TracerConfig{
+		EnableMemory:     cast.ToBool(appOpts.Get(srvflags.EVMTracerEnableMemory)),
+		DisableStack:     cast.ToBool(appOpts.Get(srvflags.EVMTracerDisableStack)),
+		DisableStorage:   cast.ToBool(appOpts.Get(srvflags.EVMTracerDisableStorage)),
+		EnableReturnData: cast.ToBool(appOpts.Get(srvflags.EVMTracerEnableReturnData)),
+		Limit:            cast.ToInt(appOpts.Get(srvflags.EVMTracerLimit)),
+	}
+	if output := cast.ToString(appOpts.Get(srvflags.EVMTracerOutput)); output != "" {
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			panic(fmt.Errorf("failed to open tracer output %s: %w", output, err))
+		}
+		app.tracerOutput, tracerConfig.Output = file, file
+	}
+	app.EVMKeeper.SetTracerConfig(tracerConfig)
+
+	// Set up the contexts of the streaming queries, which aren't intercepted by the gRPC server
+	app.EVMKeeper.SetQueryContextCreator(app.CreateQueryContext)
+
+	// Set up the limits of the JS tracers run by the trace queries
+	app.EVMKeeper.SetJSTracerLimits(
+		cast.ToDuration(appOpts.Get(srvflags.JSONRPCEVMTimeout)),
+		cast.ToUint64(appOpts.Get(srvflags.JSONRPCJSTracerStepLimit)),
+	)
+
+	// Set up the number of transactions traced concurrently by the block traces
+	app.EVMKeeper.SetTraceBlockConcurrency(cast.ToInt(appOpts.Get(srvflags.JSONRPCTraceBlockConcurrency)))
+
+	// Set up the node-local database of the SHA3 preimages seen by the EVM
+	if cast.ToBool(appOpts.Get(srvflags.EVMEnablePreimageRecording)) {
+		preimageDB, err := dbm.NewDB("evmpreimages", server.GetAppDBBackend(appOpts), filepath.Join(homePath, "data"))
+		if err != nil {
+			panic(fmt.Errorf("failed to open preimage database: %w", err))
+		}
+		app.preimageDB = preimageDB
+		app.EVMKeeper.SetPreimageStore(preimageDB)
+	}
+
+	// Set up the live tracer invoked for every block executed by the node
+	if liveTracer := cast.ToString(appOpts.Get(srvflags.EVMLiveTracer)); liveTracer != "" {
+		liveTracerConfig := cast.ToString(appOpts.Get(srvflags.EVMLiveTracerConfig))
+		// log the live tracer errors like the EVM keeper
+		live.SetLogger(logger.With("module", evmtypes.ModuleName))
+		hooks, err := tracers.LiveDirectory.New(liveTracer, json.RawMessage(liveTracerConfig))
+		if err != nil {
+			panic(fmt.Errorf("failed to create live tracer %s: %w", liveTracer, err))
+		}
+		app.EVMKeeper.SetLiveTracer(hooks)
+	}
+
 	app.Erc20Keeper = erc20keeper.NewKeeper(
 		keys[erc20types.StoreKey],
 		appCodec,
@@ -883,6 +943,22 @@ func (app *EVMD) PreBlocker(ctx sdk.Context, _ *abci.RequestFinalizeBlock) (*sdk
 	return app.ModuleManager.PreBlock(ctx)
 }
 
+// Close closes the live tracer, the tracer output and the preimage database of
+// the EVM keeper, if any, and the underlying BaseApp.
+func (app *EVMD) Close() error {
+	if hooks := app.EVMKeeper.LiveTracer(); hooks != nil && hooks.OnClose != nil {
+		hooks.OnClose()
+	}
+	err := app.BaseApp.Close()
+	if app.tracerOutput != nil {
+		err = errors.Join(err, app.tracerOutput.Close())
+	}
+	if app.preimageDB != nil {
+		err = errors.Join(err, app.preimageDB.Close())
+	}
+	return err
+}
+
 // LoadHeight loads a particular height
 func (app *EVMD) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
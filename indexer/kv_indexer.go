@@ -4,33 +4,42 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmttypes "github.com/cometbft/cometbft/types"
 
 	dbm "github.com/cosmos/cosmos-db"
-	rpctypes "github.com/cosmos/evm/rpc/types"
 	cosmosevmtypes "github.com/cosmos/evm/types"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
 )
 
 const (
-	KeyPrefixTxHash  = 1
-	KeyPrefixTxIndex = 2
+	KeyPrefixTxHash           = 1
+	KeyPrefixTxIndex          = 2
+	KeyPrefixAddressTx        = 3
+	KeyPrefixSenderNonce      = 4
+	KeyPrefixContractCreation = 5
 
 	// TxIndexKeyLength is the length of tx-index key
 	TxIndexKeyLength = 1 + 8 + 8
+	// AddressTxKeyLength is the length of address-tx key
+	AddressTxKeyLength = 1 + common.AddressLength + 8 + 8
 )
 
-var _ cosmosevmtypes.EVMTxIndexer = &KVIndexer{}
+var (
+	_ cosmosevmtypes.EVMAddressIndexer  = &KVIndexer{}
+	_ cosmosevmtypes.EVMLogIndexer      = &KVIndexer{}
+	_ cosmosevmtypes.EVMPrunableIndexer = &KVIndexer{}
+)
 
 // KVIndexer implements a eth tx indexer on a KV db.
 type KVIndexer struct {
@@ -45,82 +54,49 @@ func NewKVIndexer(db dbm.DB, logger log.Logger, clientCtx client.Context) *KVInd
 }
 
 // IndexBlock index all the eth txs in a block through the following steps:
-// - Iterates over all of the Txs in Block
-// - Parses eth Tx infos from cosmos-sdk events for every TxResult
-// - Iterates over all the messages of the Tx
-// - Builds and stores a indexer.TxResult based on parsed events for every message
+// - Parses the eth txs of the block, see parseBlockEthTxs
+// - Stores the indexer.TxResult of every eth tx
+// - Indexes the eth tx by address, and its logs by address and topics
 func (kv *KVIndexer) IndexBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult) error {
-	height := block.Height
-
 	batch := kv.db.NewBatch()
 	defer batch.Close()
 
-	// record index of valid eth tx during the iteration
-	var ethTxIndex int32
-	for txIndex, tx := range block.Txs {
-		result := txResults[txIndex]
-		if !rpctypes.TxSucessOrExpectedFailure(result) {
-			continue
-		}
-
-		tx, err := kv.clientCtx.TxConfig.TxDecoder()(tx)
-		if err != nil {
-			kv.logger.Error("Fail to decode tx", "err", err, "block", height, "txIndex", txIndex)
-			continue
-		}
+	if err := kv.indexBlock(batch, block, txResults); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d", block.Height)
+	}
+	if err := batch.Write(); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d, write batch", block.Height)
+	}
+	return nil
+}
 
-		if !isEthTx(tx) {
-			continue
+// indexBlock writes the entries of the block to the batch. The address and log
+// keys of the block are also saved in its block keys entry, so that they're
+// deleted by height.
+func (kv *KVIndexer) indexBlock(batch dbm.Batch, block *cmttypes.Block, txResults []*abci.ExecTxResult) error {
+	keysBatch := &blockKeysBatch{Batch: batch}
+	for _, ethTx := range parseBlockEthTxs(kv.clientCtx, kv.logger, block, txResults) {
+		if err := saveTxResult(kv.clientCtx.Codec, batch, ethTx.hash, &ethTx.txResult); err != nil {
+			return err
 		}
-
-		txs, err := rpctypes.ParseTxResult(result, tx)
-		if err != nil {
-			kv.logger.Error("Fail to parse event", "err", err, "block", height, "txIndex", txIndex)
-			continue
+		if err := kv.saveAddressIndexes(keysBatch, ethTx.msg, ethTx.hash, &ethTx.txResult); err != nil {
+			return err
 		}
-
-		var cumulativeGasUsed uint64
-		for msgIndex, msg := range tx.GetMsgs() {
-			ethMsg := msg.(*evmtypes.MsgEthereumTx)
-			txHash := common.HexToHash(ethMsg.Hash)
-
-			txResult := cosmosevmtypes.TxResult{
-				Height:     height,
-				TxIndex:    uint32(txIndex),  //#nosec G115 -- int overflow is not a concern here
-				MsgIndex:   uint32(msgIndex), //#nosec G115 -- int overflow is not a concern here
-				EthTxIndex: ethTxIndex,
-			}
-			if result.Code != abci.CodeTypeOK {
-				// exceeds block gas limit scenario, set gas used to gas limit because that's what's charged by ante handler.
-				// some old versions don't emit any events, so workaround here directly.
-				txResult.GasUsed = ethMsg.GetGas()
-				txResult.Failed = true
-			} else {
-				parsedTx := txs.GetTxByMsgIndex(msgIndex)
-				if parsedTx == nil {
-					kv.logger.Error("msg index not found in events", "msgIndex", msgIndex)
-					continue
-				}
-				if parsedTx.EthTxIndex >= 0 && parsedTx.EthTxIndex != ethTxIndex {
-					kv.logger.Error("eth tx index don't match", "expect", ethTxIndex, "found", parsedTx.EthTxIndex)
-				}
-				txResult.GasUsed = parsedTx.GasUsed
-				txResult.Failed = parsedTx.Failed
-			}
-
-			cumulativeGasUsed += txResult.GasUsed
-			txResult.CumulativeGasUsed = cumulativeGasUsed
-			ethTxIndex++
-
-			if err := saveTxResult(kv.clientCtx.Codec, batch, txHash, &txResult); err != nil {
-				return errorsmod.Wrapf(err, "IndexBlock %d", height)
-			}
+		if err := kv.saveLogIndexes(keysBatch, ethTx.result, ethTx.msgIndex, ethTx.hash, &ethTx.txResult); err != nil {
+			return err
 		}
 	}
-	if err := batch.Write(); err != nil {
-		return errorsmod.Wrapf(err, "IndexBlock %d, write batch", block.Height)
+	if len(keysBatch.keys) > 0 {
+		if err := batch.Set(BlockKeysKey(block.Height), keysBatch.encode()); err != nil {
+			return errorsmod.Wrap(err, "set block-keys key")
+		}
 	}
-	return nil
+	return kv.saveLogRange(batch, block.Height)
+}
+
+// Close closes the db of the indexer
+func (kv *KVIndexer) Close() error {
+	return kv.db.Close()
 }
 
 // LastIndexedBlock returns the latest indexed block number, returns -1 if db is empty
@@ -161,6 +137,77 @@ func (kv *KVIndexer) GetByBlockAndIndex(blockNumber int64, txIndex int32) (*cosm
 	return kv.GetByTxHash(common.BytesToHash(bz))
 }
 
+// GetByAddress finds the hashes of the eth txs sent from or to the address, or
+// deploying a contract at it, see cosmosevmtypes.EVMAddressIndexer.
+func (kv *KVIndexer) GetByAddress(address common.Address, fromBlock int64, reverse bool, pageSize int) ([]common.Hash, bool, error) {
+	prefix := append([]byte{KeyPrefixAddressTx}, address.Bytes()...)
+	start, end := prefix, storetypes.PrefixEndBytes(prefix)
+
+	var (
+		it  dbm.Iterator
+		err error
+	)
+	if reverse {
+		if fromBlock >= 0 {
+			end = AddressTxKey(address, fromBlock+1, 0)
+		}
+		it, err = kv.db.ReverseIterator(start, end)
+	} else {
+		if fromBlock >= 0 {
+			start = AddressTxKey(address, fromBlock, 0)
+		}
+		it, err = kv.db.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, false, errorsmod.Wrapf(err, "GetByAddress %s", address.Hex())
+	}
+	defer it.Close()
+
+	var (
+		hashes     []common.Hash
+		lastHeight int64 = -1
+	)
+	for ; it.Valid(); it.Next() {
+		height, err := parseBlockNumberFromAddressKey(it.Key())
+		if err != nil {
+			return nil, false, errorsmod.Wrapf(err, "GetByAddress %s", address.Hex())
+		}
+		// never split the txs of a block between pages
+		if len(hashes) >= pageSize && height != lastHeight {
+			return hashes, true, nil
+		}
+		hashes = append(hashes, common.BytesToHash(it.Value()))
+		lastHeight = height
+	}
+	return hashes, false, nil
+}
+
+// GetBySenderAndNonce finds the eth tx hash by sender and nonce
+func (kv *KVIndexer) GetBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error) {
+	bz, err := kv.db.Get(SenderNonceKey(sender, nonce))
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetBySenderAndNonce %s %d", sender.Hex(), nonce)
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	hash := common.BytesToHash(bz)
+	return &hash, nil
+}
+
+// GetContractCreationTx finds the hash of the eth tx deploying the contract
+func (kv *KVIndexer) GetContractCreationTx(contract common.Address) (*common.Hash, error) {
+	bz, err := kv.db.Get(ContractCreationKey(contract))
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetContractCreationTx %s", contract.Hex())
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	hash := common.BytesToHash(bz)
+	return &hash, nil
+}
+
 // TxHashKey returns the key for db entry: `tx hash -> tx result struct`
 func TxHashKey(hash common.Hash) []byte {
 	return append([]byte{KeyPrefixTxHash}, hash.Bytes()...)
@@ -173,6 +220,25 @@ func TxIndexKey(blockNumber int64, txIndex int32) []byte {
 	return append(append([]byte{KeyPrefixTxIndex}, bz1...), bz2...)
 }
 
+// AddressTxKey returns the key for db entry: `(address, block number, tx index) -> tx hash`
+func AddressTxKey(address common.Address, blockNumber int64, txIndex int32) []byte {
+	bz1 := sdk.Uint64ToBigEndian(uint64(blockNumber)) //nolint:gosec // G115 // block number won't exceed uint64
+	bz2 := sdk.Uint64ToBigEndian(uint64(txIndex))     //nolint:gosec // G115 // index won't exceed uint64
+	key := append([]byte{KeyPrefixAddressTx}, address.Bytes()...)
+	return append(append(key, bz1...), bz2...)
+}
+
+// SenderNonceKey returns the key for db entry: `(sender, nonce) -> tx hash`
+func SenderNonceKey(sender common.Address, nonce uint64) []byte {
+	key := append([]byte{KeyPrefixSenderNonce}, sender.Bytes()...)
+	return append(key, sdk.Uint64ToBigEndian(nonce)...)
+}
+
+// ContractCreationKey returns the key for db entry: `contract address -> tx hash`
+func ContractCreationKey(contract common.Address) []byte {
+	return append([]byte{KeyPrefixContractCreation}, contract.Bytes()...)
+}
+
 // LoadLastBlock returns the latest indexed block number, returns -1 if db is empty
 func LoadLastBlock(db dbm.DB) (int64, error) {
 	it, err := db.ReverseIterator([]byte{KeyPrefixTxIndex}, []byte{KeyPrefixTxIndex + 1})
@@ -199,19 +265,6 @@ func LoadFirstBlock(db dbm.DB) (int64, error) {
 	return parseBlockNumberFromKey(it.Key())
 }
 
-// isEthTx check if the tx is an eth tx
-func isEthTx(tx sdk.Tx) bool {
-	extTx, ok := tx.(authante.HasExtensionOptionsTx)
-	if !ok {
-		return false
-	}
-	opts := extTx.GetExtensionOptions()
-	if len(opts) != 1 || opts[0].GetTypeUrl() != "/cosmos.evm.vm.v1.ExtensionOptionsEthereumTx" {
-		return false
-	}
-	return true
-}
-
 // saveTxResult index the txResult into the kv db batch
 func saveTxResult(codec codec.Codec, batch dbm.Batch, txHash common.Hash, txResult *cosmosevmtypes.TxResult) error {
 	bz := codec.MustMarshal(txResult)
@@ -224,6 +277,39 @@ func saveTxResult(codec codec.Codec, batch dbm.Batch, txHash common.Hash, txResu
 	return nil
 }
 
+// saveAddressIndexes index the eth tx by sender, recipient, sender nonce and
+// deployed contract into the kv db batch. The tx results don't hold the nested
+// calls, so the internal transfers and contract creations aren't indexed.
+func (kv *KVIndexer) saveAddressIndexes(batch dbm.Batch, ethMsg *evmtypes.MsgEthereumTx, txHash common.Hash, txResult *cosmosevmtypes.TxResult) error {
+	tx := ethMsg.AsTransaction()
+	from, err := ethMsgSender(ethMsg)
+	if err != nil {
+		kv.logger.Error("Fail to recover tx sender", "err", err, "hash", txHash.Hex())
+		return nil
+	}
+
+	addresses := []common.Address{from}
+	if to := tx.To(); to != nil {
+		addresses = append(addresses, *to)
+	} else if !txResult.Failed {
+		contract := crypto.CreateAddress(from, tx.Nonce())
+		if err := batch.Set(ContractCreationKey(contract), txHash.Bytes()); err != nil {
+			return errorsmod.Wrap(err, "set contract-creation key")
+		}
+		addresses = append(addresses, contract)
+	}
+
+	for _, address := range addresses {
+		if err := batch.Set(AddressTxKey(address, txResult.Height, txResult.EthTxIndex), txHash.Bytes()); err != nil {
+			return errorsmod.Wrap(err, "set address-tx key")
+		}
+	}
+	if err := batch.Set(SenderNonceKey(from, tx.Nonce()), txHash.Bytes()); err != nil {
+		return errorsmod.Wrap(err, "set sender-nonce key")
+	}
+	return nil
+}
+
 func parseBlockNumberFromKey(key []byte) (int64, error) {
 	if len(key) != TxIndexKeyLength {
 		return 0, fmt.Errorf("wrong tx index key length, expect: %d, got: %d", TxIndexKeyLength, len(key))
@@ -231,3 +317,11 @@ func parseBlockNumberFromKey(key []byte) (int64, error) {
 
 	return int64(sdk.BigEndianToUint64(key[1:9])), nil //#nosec G115 -- int overflow is not a concern here, block number is unlikely to exceed 9,223,372,036,854,775,807
 }
+
+func parseBlockNumberFromAddressKey(key []byte) (int64, error) {
+	if len(key) != AddressTxKeyLength {
+		return 0, fmt.Errorf("wrong address tx key length, expect: %d, got: %d", AddressTxKeyLength, len(key))
+	}
+
+	return int64(sdk.BigEndianToUint64(key[1+common.AddressLength : 1+common.AddressLength+8])), nil //#nosec G115 -- int overflow is not a concern here
+}
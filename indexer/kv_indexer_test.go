@@ -1,11 +1,13 @@
 package indexer_test
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -17,6 +19,7 @@ import (
 	"github.com/cosmos/evm/testutil/constants"
 	"github.com/cosmos/evm/testutil/integration/os/network"
 	utiltx "github.com/cosmos/evm/testutil/tx"
+	cosmosevmtypes "github.com/cosmos/evm/types"
 	"github.com/cosmos/evm/x/vm/types"
 
 	"cosmossdk.io/log"
@@ -190,3 +193,396 @@ func TestKVIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestKVIndexerAddressIndexes(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	db := dbm.NewMemDB()
+	idxer := indexer.NewKVIndexer(db, log.NewNopLogger(), clientCtx)
+
+	// index a transfer, a contract deployment and another transfer in blocks 1 to 3
+	to := common.BigToAddress(big.NewInt(1))
+	contract := crypto.CreateAddress(from, 1)
+	recipients := []*common.Address{&to, nil, &to}
+	hashes := make([]common.Hash, len(recipients))
+	for i, recipient := range recipients {
+		tx := types.NewTx(&types.EvmTxArgs{
+			Nonce:    uint64(i),
+			To:       recipient,
+			Amount:   big.NewInt(1000),
+			GasLimit: 100000,
+		})
+		tx.From = from.Hex()
+		require.NoError(t, tx.Sign(ethSigner, signer))
+		hashes[i] = tx.AsTransaction().Hash()
+
+		tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+		require.NoError(t, err)
+		txBz, err := clientCtx.TxConfig.TxEncoder()(tmTx)
+		require.NoError(t, err)
+
+		block := &cmttypes.Block{Header: cmttypes.Header{Height: int64(i + 1)}, Data: cmttypes.Data{Txs: []cmttypes.Tx{txBz}}}
+		err = idxer.IndexBlock(block, []*abci.ExecTxResult{
+			{
+				Code: 0,
+				Events: []abci.Event{
+					{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+						{Key: "ethereumTxHash", Value: hashes[i].Hex()},
+						{Key: "txIndex", Value: "0"},
+						{Key: "txGasUsed", Value: "21000"},
+					}},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name       string
+		address    common.Address
+		fromBlock  int64
+		reverse    bool
+		pageSize   int
+		expHashes  []common.Hash
+		expHasMore bool
+	}{
+		{"sender, latest first", from, -1, true, 10, []common.Hash{hashes[2], hashes[1], hashes[0]}, false},
+		{"sender, latest first, paginated", from, -1, true, 2, []common.Hash{hashes[2], hashes[1]}, true},
+		{"sender, before block 2", from, 1, true, 10, []common.Hash{hashes[0]}, false},
+		{"sender, from block 2", from, 2, false, 1, []common.Hash{hashes[1]}, true},
+		{"recipient, oldest first", to, -1, false, 10, []common.Hash{hashes[0], hashes[2]}, false},
+		{"deployed contract", contract, -1, false, 10, []common.Hash{hashes[1]}, false},
+		{"unknown address", common.BigToAddress(big.NewInt(2)), -1, true, 10, nil, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, hasMore, err := idxer.GetByAddress(tc.address, tc.fromBlock, tc.reverse, tc.pageSize)
+			require.NoError(t, err)
+			require.Equal(t, tc.expHashes, res)
+			require.Equal(t, tc.expHasMore, hasMore)
+		})
+	}
+
+	hash, err := idxer.GetBySenderAndNonce(from, 1)
+	require.NoError(t, err)
+	require.Equal(t, &hashes[1], hash)
+	hash, err = idxer.GetBySenderAndNonce(from, 3)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+
+	hash, err = idxer.GetContractCreationTx(contract)
+	require.NoError(t, err)
+	require.Equal(t, &hashes[1], hash)
+	hash, err = idxer.GetContractCreationTx(to)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+
+	// only the top-level addresses are indexed: were the recipient a factory,
+	// the contracts it deploys wouldn't be found
+	for _, created := range []common.Address{
+		crypto.CreateAddress(to, 1),
+		crypto.CreateAddress2(to, common.Hash{}, crypto.Keccak256(nil)),
+	} {
+		hash, err = idxer.GetContractCreationTx(created)
+		require.NoError(t, err)
+		require.Nil(t, hash)
+		res, _, err := idxer.GetByAddress(created, -1, true, 10)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	}
+
+	// reindexing block 3 without its tx replaces its entries
+	require.NoError(t, idxer.ReindexBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 3}}, nil))
+	_, err = idxer.GetByTxHash(hashes[2])
+	require.Error(t, err)
+	res, _, err := idxer.GetByAddress(to, -1, true, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[0]}, res)
+	hash, err = idxer.GetBySenderAndNonce(from, 2)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+	last, err := idxer.LastIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), last)
+
+	// pruning all the blocks deletes their address keys
+	require.NoError(t, idxer.PruneBlocks(4))
+	it, err := db.Iterator([]byte{indexer.KeyPrefixAddressTx}, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	require.False(t, it.Valid())
+}
+
+func TestKVIndexerLogIndexes(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	db := dbm.NewMemDB()
+	idxer := indexer.NewKVIndexer(db, log.NewNopLogger(), clientCtx)
+
+	first, last, err := idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), first)
+	require.Equal(t, int64(-1), last)
+
+	addrA := common.BigToAddress(big.NewInt(1))
+	addrB := common.BigToAddress(big.NewInt(2))
+	topic1 := common.BigToHash(big.NewInt(1))
+	topic2 := common.BigToHash(big.NewInt(2))
+	topic3 := common.BigToHash(big.NewInt(3))
+
+	// the logs emitted by the tx of the blocks 1, 2, 3 and 5
+	blockLogs := map[int64][]*types.Log{
+		1: {{Address: addrA.Hex(), Topics: []string{topic1.Hex(), topic2.Hex()}, Index: 0}},
+		2: {
+			{Address: addrB.Hex(), Topics: []string{topic1.Hex()}, Index: 0},
+			{Address: addrA.Hex(), Topics: []string{topic3.Hex()}, Index: 1},
+		},
+		3: {{Address: addrA.Hex(), Topics: []string{topic1.Hex(), topic2.Hex()}, Index: 0}},
+		5: {{Address: addrB.Hex(), Topics: []string{topic3.Hex()}, Index: 0}},
+	}
+	indexBlock := func(height int64) {
+		tx := types.NewTx(&types.EvmTxArgs{
+			Nonce:    uint64(height), //nolint:gosec // G115
+			To:       &addrA,
+			Amount:   big.NewInt(1000),
+			GasLimit: 100000,
+		})
+		tx.From = from.Hex()
+		require.NoError(t, tx.Sign(ethSigner, signer))
+		txHash := tx.AsTransaction().Hash()
+
+		tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+		require.NoError(t, err)
+		txBz, err := clientCtx.TxConfig.TxEncoder()(tmTx)
+		require.NoError(t, err)
+
+		logAttrs := make([]abci.EventAttribute, len(blockLogs[height]))
+		for i, txLog := range blockLogs[height] {
+			txLog.BlockNumber = uint64(height) //nolint:gosec // G115
+			txLog.TxHash = txHash.Hex()
+			bz, err := json.Marshal(txLog)
+			require.NoError(t, err)
+			logAttrs[i] = abci.EventAttribute{Key: types.AttributeKeyTxLog, Value: string(bz)}
+		}
+
+		block := &cmttypes.Block{Header: cmttypes.Header{Height: height}, Data: cmttypes.Data{Txs: []cmttypes.Tx{txBz}}}
+		err = idxer.IndexBlock(block, []*abci.ExecTxResult{
+			{
+				Code: 0,
+				Events: []abci.Event{
+					{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+						{Key: "ethereumTxHash", Value: txHash.Hex()},
+						{Key: "txIndex", Value: "0"},
+						{Key: "txGasUsed", Value: "21000"},
+					}},
+					{Type: types.EventTypeTxLog, Attributes: logAttrs},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+	for height := int64(1); height <= 3; height++ {
+		indexBlock(height)
+	}
+
+	first, last, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first)
+	require.Equal(t, int64(3), last)
+
+	testCases := []struct {
+		name         string
+		addresses    []common.Address
+		topics       [][]common.Hash
+		fromBlock    int64
+		toBlock      int64
+		expLocations []cosmosevmtypes.LogLocation
+		expErr       bool
+	}{
+		{"address", []common.Address{addrA}, nil, 1, 3, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}, {Height: 2, LogIndex: 1}, {Height: 3, LogIndex: 0}}, false},
+		{"first topic in range", nil, [][]common.Hash{{topic1}}, 2, 3, []cosmosevmtypes.LogLocation{{Height: 2, LogIndex: 0}, {Height: 3, LogIndex: 0}}, false},
+		{"address and topics", []common.Address{addrA}, [][]common.Hash{{topic1}, {topic2}}, 1, 3, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}, {Height: 3, LogIndex: 0}}, false},
+		{"wildcard topic", nil, [][]common.Hash{{}, {topic2}}, 1, 3, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}, {Height: 3, LogIndex: 0}}, false},
+		{"alternatives", []common.Address{addrA, addrB}, [][]common.Hash{{topic3, topic1}}, 1, 3, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}, {Height: 2, LogIndex: 0}, {Height: 2, LogIndex: 1}, {Height: 3, LogIndex: 0}}, false},
+		{"topic in wrong position", nil, [][]common.Hash{{topic2}}, 1, 3, []cosmosevmtypes.LogLocation{}, false},
+		{"no criteria", nil, [][]common.Hash{{}}, 1, 3, nil, true},
+		{"too many topics", nil, make([][]common.Hash, 5), 1, 3, nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := idxer.GetLogLocations(tc.addresses, tc.topics, tc.fromBlock, tc.toBlock)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expLocations, res)
+		})
+	}
+
+	// block 4 isn't indexed, so the range restarts from block 5
+	indexBlock(5)
+	first, last, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), first)
+	require.Equal(t, int64(5), last)
+
+	// block 4 joins both ranges
+	indexBlock(4)
+	first, last, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first)
+	require.Equal(t, int64(5), last)
+	res, err := idxer.GetLogLocations([]common.Address{addrB}, nil, first, last)
+	require.NoError(t, err)
+	require.Equal(t, []cosmosevmtypes.LogLocation{{Height: 2, LogIndex: 0}, {Height: 5, LogIndex: 0}}, res)
+
+	// deleting blocks 2 and 3 splits the range, until they are indexed again
+	require.NoError(t, idxer.DeleteBlocks(2, 3))
+	first, last, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 5}, []int64{first, last})
+	res, err = idxer.GetLogLocations([]common.Address{addrA}, nil, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}}, res)
+	_, err = idxer.GetByBlockAndIndex(2, 0)
+	require.Error(t, err)
+
+	indexBlock(2)
+	indexBlock(3)
+	first, last, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 5}, []int64{first, last})
+	res, err = idxer.GetLogLocations([]common.Address{addrA}, nil, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, []cosmosevmtypes.LogLocation{{Height: 1, LogIndex: 0}, {Height: 2, LogIndex: 1}, {Height: 3, LogIndex: 0}}, res)
+}
+
+func TestKVIndexerPruneBlocks(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	db := dbm.NewMemDB()
+	idxer := indexer.NewKVIndexer(db, log.NewNopLogger(), clientCtx)
+
+	// a contract deployment and two contract calls emitting a log in blocks 1
+	// to 3
+	contract := crypto.CreateAddress(from, 0)
+	topic := common.BigToHash(big.NewInt(1))
+	hashes := make([]common.Hash, 3)
+	for i := range hashes {
+		to := &contract
+		if i == 0 {
+			to = nil
+		}
+		tx := types.NewTx(&types.EvmTxArgs{
+			Nonce:    uint64(i), //nolint:gosec // G115
+			To:       to,
+			Amount:   big.NewInt(1000),
+			GasLimit: 100000,
+		})
+		tx.From = from.Hex()
+		require.NoError(t, tx.Sign(ethSigner, signer))
+		hashes[i] = tx.AsTransaction().Hash()
+
+		tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+		require.NoError(t, err)
+		txBz, err := clientCtx.TxConfig.TxEncoder()(tmTx)
+		require.NoError(t, err)
+
+		events := []abci.Event{
+			{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+				{Key: "ethereumTxHash", Value: hashes[i].Hex()},
+				{Key: "txIndex", Value: "0"},
+				{Key: "txGasUsed", Value: "21000"},
+			}},
+		}
+		if i > 0 {
+			txLog, err := json.Marshal(&types.Log{Address: contract.Hex(), Topics: []string{topic.Hex()}, Index: 0})
+			require.NoError(t, err)
+			events = append(events, abci.Event{Type: types.EventTypeTxLog, Attributes: []abci.EventAttribute{
+				{Key: types.AttributeKeyTxLog, Value: string(txLog)},
+			}})
+		}
+
+		block := &cmttypes.Block{Header: cmttypes.Header{Height: int64(i + 1)}, Data: cmttypes.Data{Txs: []cmttypes.Tx{txBz}}}
+		require.NoError(t, idxer.IndexBlock(block, []*abci.ExecTxResult{{Code: 0, Events: events}}))
+	}
+
+	require.NoError(t, idxer.PruneBlocks(3))
+
+	first, err := idxer.FirstIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), first)
+	for i, hash := range hashes[:2] {
+		_, err = idxer.GetByTxHash(hash)
+		require.Error(t, err)
+		_, err = idxer.GetByBlockAndIndex(int64(i+1), 0)
+		require.Error(t, err)
+	}
+	res, err := idxer.GetByTxHash(hashes[2])
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.Height)
+
+	found, hasMore, err := idxer.GetByAddress(from, -1, true, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[2]}, found)
+	require.False(t, hasMore)
+	found, _, err = idxer.GetByAddress(contract, -1, true, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[2]}, found)
+
+	hash, err := idxer.GetBySenderAndNonce(from, 0)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+	hash, err = idxer.GetBySenderAndNonce(from, 2)
+	require.NoError(t, err)
+	require.Equal(t, &hashes[2], hash)
+	hash, err = idxer.GetContractCreationTx(contract)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+
+	logFirst, logLast, err := idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), logFirst)
+	require.Equal(t, int64(3), logLast)
+	locations, err := idxer.GetLogLocations([]common.Address{contract}, [][]common.Hash{{topic}}, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, []cosmosevmtypes.LogLocation{{Height: 3, LogIndex: 0}}, locations)
+
+	// pruning past the last block empties the indexer
+	require.NoError(t, idxer.PruneBlocks(4))
+	first, err = idxer.FirstIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), first)
+	logFirst, _, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), logFirst)
+	it, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	require.False(t, it.Valid())
+}
@@ -0,0 +1,280 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	KeyPrefixLogAddress = 6
+	KeyPrefixLogTopic   = 7
+	KeyPrefixLogRange   = 8
+
+	// maxLogTopics is the maximum number of topics of a log
+	maxLogTopics = 4
+)
+
+// LogIndexRange returns the latest range of consecutive blocks whose logs are
+// indexed, -1 and -1 if none.
+func (kv *KVIndexer) LogIndexRange() (int64, int64, error) {
+	ranges, err := loadLogRanges(kv.db)
+	if err != nil {
+		return 0, 0, errorsmod.Wrap(err, "LogIndexRange")
+	}
+	if len(ranges) == 0 {
+		return -1, -1, nil
+	}
+	latest := ranges[len(ranges)-1]
+	return latest.first, latest.last, nil
+}
+
+// GetLogLocations finds the locations of the eth logs matching the addresses
+// and topics, see cosmosevmtypes.EVMLogIndexer.
+func (kv *KVIndexer) GetLogLocations(
+	addresses []common.Address,
+	topics [][]common.Hash,
+	fromBlock, toBlock int64,
+) ([]cosmosevmtypes.LogLocation, error) {
+	if len(topics) > maxLogTopics {
+		return nil, fmt.Errorf("too many topics, got %d: limit %d", len(topics), maxLogTopics)
+	}
+
+	// the prefixes of each criterion, matched if any of them matches
+	var criteria [][][]byte
+	if len(addresses) > 0 {
+		prefixes := make([][]byte, len(addresses))
+		for i, address := range addresses {
+			prefixes[i] = logAddressPrefix(address)
+		}
+		criteria = append(criteria, prefixes)
+	}
+	for position, topicList := range topics {
+		if len(topicList) == 0 {
+			// wildcard
+			continue
+		}
+		prefixes := make([][]byte, len(topicList))
+		for i, topic := range topicList {
+			prefixes[i] = logTopicPrefix(position, topic)
+		}
+		criteria = append(criteria, prefixes)
+	}
+	if len(criteria) == 0 {
+		return nil, fmt.Errorf("GetLogLocations requires at least one address or topic")
+	}
+
+	var matches map[cosmosevmtypes.LogLocation]struct{}
+	for _, prefixes := range criteria {
+		found := make(map[cosmosevmtypes.LogLocation]struct{})
+		for _, prefix := range prefixes {
+			if err := kv.scanLogLocations(prefix, fromBlock, toBlock, func(loc cosmosevmtypes.LogLocation) {
+				// the logs have to match all the criteria
+				if _, ok := matches[loc]; matches == nil || ok {
+					found[loc] = struct{}{}
+				}
+			}); err != nil {
+				return nil, errorsmod.Wrap(err, "GetLogLocations")
+			}
+		}
+		matches = found
+		if len(matches) == 0 {
+			break
+		}
+	}
+
+	locations := make([]cosmosevmtypes.LogLocation, 0, len(matches))
+	for loc := range matches {
+		locations = append(locations, loc)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Height != locations[j].Height {
+			return locations[i].Height < locations[j].Height
+		}
+		return locations[i].LogIndex < locations[j].LogIndex
+	})
+	return locations, nil
+}
+
+// scanLogLocations calls fn with the location of each log indexed under the
+// prefix from fromBlock to toBlock, included.
+func (kv *KVIndexer) scanLogLocations(prefix []byte, fromBlock, toBlock int64, fn func(cosmosevmtypes.LogLocation)) error {
+	start := append(append([]byte{}, prefix...), sdk.Uint64ToBigEndian(uint64(fromBlock))...) //nolint:gosec // G115 // block number won't exceed uint64
+	end := append(append([]byte{}, prefix...), sdk.Uint64ToBigEndian(uint64(toBlock+1))...)   //nolint:gosec // G115 // block number won't exceed uint64
+	it, err := kv.db.Iterator(start, end)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+8 {
+			return fmt.Errorf("wrong log key length, expect: %d, got: %d", len(prefix)+8+8, len(key))
+		}
+		fn(cosmosevmtypes.LogLocation{
+			Height:   int64(sdk.BigEndianToUint64(key[len(prefix) : len(prefix)+8])), //#nosec G115 -- int overflow is not a concern here
+			LogIndex: uint(sdk.BigEndianToUint64(key[len(prefix)+8:])),
+		})
+	}
+	return it.Error()
+}
+
+// saveLogIndexes index the logs of the eth tx by address and topics into the kv
+// db batch
+func (kv *KVIndexer) saveLogIndexes(batch dbm.Batch, result *abci.ExecTxResult, msgIndex int, txHash common.Hash, txResult *cosmosevmtypes.TxResult) error {
+	if txResult.Failed {
+		// reverted, no logs
+		return nil
+	}
+
+	logs, err := txLogsFromEvents(result.Events, msgIndex)
+	if err != nil {
+		kv.logger.Error("Fail to parse tx logs", "err", err, "hash", txHash.Hex())
+		return nil
+	}
+
+	for _, txLog := range evmtypes.LogsToEthereum(logs) {
+		if err := batch.Set(LogAddressKey(txLog.Address, txResult.Height, txLog.Index), txHash.Bytes()); err != nil {
+			return errorsmod.Wrap(err, "set log-address key")
+		}
+		for position, topic := range txLog.Topics {
+			if position >= maxLogTopics {
+				break
+			}
+			if err := batch.Set(LogTopicKey(position, topic, txResult.Height, txLog.Index), txHash.Bytes()); err != nil {
+				return errorsmod.Wrap(err, "set log-topic key")
+			}
+		}
+	}
+	return nil
+}
+
+// saveLogRange adds the block to the ranges of consecutive blocks whose logs
+// are indexed into the kv db batch, merging the ranges it's adjacent to. A block
+// that isn't adjacent to any range starts a new one, as the blocks in between
+// aren't indexed.
+func (kv *KVIndexer) saveLogRange(batch dbm.Batch, height int64) error {
+	ranges, err := loadLogRanges(kv.db)
+	if err != nil {
+		return err
+	}
+
+	merged := logRange{first: height, last: height}
+	for _, r := range ranges {
+		if r.last < height-1 || r.first > height+1 {
+			continue
+		}
+		if r.first <= height && height <= r.last {
+			// already indexed
+			return nil
+		}
+		merged.first = min(merged.first, r.first)
+		merged.last = max(merged.last, r.last)
+		if err := batch.Delete(LogRangeKey(r.last)); err != nil {
+			return errorsmod.Wrap(err, "delete log-range key")
+		}
+	}
+
+	if err := batch.Set(LogRangeKey(merged.last), sdk.Uint64ToBigEndian(uint64(merged.first))); err != nil { //nolint:gosec // G115 // block number won't exceed uint64
+		return errorsmod.Wrap(err, "set log-range key")
+	}
+	return nil
+}
+
+// LogAddressKey returns the key for db entry: `(address, block number, log index) -> tx hash`
+func LogAddressKey(address common.Address, blockNumber int64, logIndex uint) []byte {
+	key := append(logAddressPrefix(address), sdk.Uint64ToBigEndian(uint64(blockNumber))...) //nolint:gosec // G115 // block number won't exceed uint64
+	return append(key, sdk.Uint64ToBigEndian(uint64(logIndex))...)
+}
+
+// LogTopicKey returns the key for db entry: `(topic position, topic, block number, log index) -> tx hash`
+func LogTopicKey(position int, topic common.Hash, blockNumber int64, logIndex uint) []byte {
+	key := append(logTopicPrefix(position, topic), sdk.Uint64ToBigEndian(uint64(blockNumber))...) //nolint:gosec // G115 // block number won't exceed uint64
+	return append(key, sdk.Uint64ToBigEndian(uint64(logIndex))...)
+}
+
+func logAddressPrefix(address common.Address) []byte {
+	return append([]byte{KeyPrefixLogAddress}, address.Bytes()...)
+}
+
+func logTopicPrefix(position int, topic common.Hash) []byte {
+	return append([]byte{KeyPrefixLogTopic, byte(position)}, topic.Bytes()...) //#nosec G115 -- position is lower than maxLogTopics
+}
+
+// LogRangeKey returns the key for db entry: `(last block) -> first block` of a
+// range of consecutive blocks whose logs are indexed
+func LogRangeKey(last int64) []byte {
+	return append([]byte{KeyPrefixLogRange}, sdk.Uint64ToBigEndian(uint64(last))...) //nolint:gosec // G115 // block number won't exceed uint64
+}
+
+// logRange is a range of consecutive blocks whose logs are indexed, first and
+// last included
+type logRange struct {
+	first, last int64
+}
+
+// loadLogRanges returns the ranges of consecutive blocks whose logs are
+// indexed, sorted by block
+func loadLogRanges(db dbm.DB) ([]logRange, error) {
+	it, err := db.Iterator([]byte{KeyPrefixLogRange}, []byte{KeyPrefixLogRange + 1})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var ranges []logRange
+	for ; it.Valid(); it.Next() {
+		if len(it.Key()) != 1+8 || len(it.Value()) != 8 {
+			return nil, fmt.Errorf("wrong log range length, expect: 9 and 8, got: %d and %d", len(it.Key()), len(it.Value()))
+		}
+		ranges = append(ranges, logRange{
+			first: int64(sdk.BigEndianToUint64(it.Value())),   //#nosec G115 -- int overflow is not a concern here
+			last:  int64(sdk.BigEndianToUint64(it.Key()[1:])), //#nosec G115 -- int overflow is not a concern here
+		})
+	}
+	return ranges, it.Error()
+}
+
+// txLogsFromEvents parses the logs of the eth tx of the given msg index from
+// the tx events
+func txLogsFromEvents(events []abci.Event, msgIndex int) ([]*evmtypes.Log, error) {
+	for _, event := range events {
+		if event.Type != evmtypes.EventTypeTxLog {
+			continue
+		}
+
+		if msgIndex > 0 {
+			// not the eth tx we want
+			msgIndex--
+			continue
+		}
+
+		logs := make([]*evmtypes.Log, 0, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			if attr.Key != evmtypes.AttributeKeyTxLog {
+				continue
+			}
+
+			var txLog evmtypes.Log
+			if err := json.Unmarshal([]byte(attr.Value), &txLog); err != nil {
+				return nil, err
+			}
+			logs = append(logs, &txLog)
+		}
+		return logs, nil
+	}
+	return nil, fmt.Errorf("eth tx logs not found for message index %d", msgIndex)
+}
@@ -0,0 +1,244 @@
+package indexer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	errorsmod "cosmossdk.io/errors"
+)
+
+const (
+	// KeyPrefixBlockKeys is the prefix of the keys holding the address and log
+	// keys written for each block, so that they're deleted by height
+	KeyPrefixBlockKeys = 9
+
+	// pruneBatchSize is the number of keys scanned between the writes of the
+	// pruning, as the iterators of some dbs can't be written to
+	pruneBatchSize = 10000
+)
+
+// PruneBlocks deletes the eth txs indexed in the blocks lower than height,
+// along with their address and log indexes
+func (kv *KVIndexer) PruneBlocks(height int64) error {
+	return errorsmod.Wrap(kv.deleteBlocks(0, height-1), "PruneBlocks")
+}
+
+// DeleteBlocks deletes the eth txs indexed in the blocks from fromBlock to
+// toBlock, included, along with their address and log indexes, so that the
+// blocks can be indexed again
+func (kv *KVIndexer) DeleteBlocks(fromBlock, toBlock int64) error {
+	return errorsmod.Wrap(kv.deleteBlocks(fromBlock, toBlock), "DeleteBlocks")
+}
+
+// ReindexBlock indexes the block again, deleting the entries it was indexed
+// with in the same batch, so that the block is never left unindexed.
+func (kv *KVIndexer) ReindexBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult) error {
+	height := block.Height
+
+	batch := kv.db.NewBatch()
+	defer batch.Close()
+
+	for _, scan := range []struct {
+		start, end []byte
+		keys       func(key, value []byte) ([][]byte, error)
+	}{
+		{TxIndexKey(height, 0), TxIndexKey(height+1, 0), txIndexKeys},
+		{BlockKeysKey(height), BlockKeysKey(height + 1), blockKeys},
+	} {
+		for start := scan.start; start != nil; {
+			var (
+				keys [][]byte
+				err  error
+			)
+			start, keys, err = kv.scanPrunedKeys(start, scan.end, scan.keys)
+			if err != nil {
+				return errorsmod.Wrapf(err, "ReindexBlock %d", height)
+			}
+			for _, key := range keys {
+				if err := batch.Delete(key); err != nil {
+					return errorsmod.Wrapf(err, "ReindexBlock %d", height)
+				}
+			}
+		}
+	}
+
+	if err := kv.indexBlock(batch, block, txResults); err != nil {
+		return errorsmod.Wrapf(err, "ReindexBlock %d", height)
+	}
+	if err := batch.Write(); err != nil {
+		return errorsmod.Wrapf(err, "ReindexBlock %d, write batch", height)
+	}
+	return nil
+}
+
+// deleteBlocks deletes the eth txs indexed in the blocks from fromBlock to
+// toBlock, included, along with their address and log indexes
+func (kv *KVIndexer) deleteBlocks(fromBlock, toBlock int64) error {
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	// the tx hashes are deleted along with the tx-index keys, and the address
+	// and log keys along with the block keys, which are all sorted by block
+	if err := kv.pruneKeys(TxIndexKey(fromBlock, 0), TxIndexKey(toBlock+1, 0), txIndexKeys); err != nil {
+		return errorsmod.Wrap(err, "tx indexes")
+	}
+	if err := kv.pruneKeys(BlockKeysKey(fromBlock), BlockKeysKey(toBlock+1), blockKeys); err != nil {
+		return errorsmod.Wrap(err, "block keys")
+	}
+
+	// the log ranges are cut around the blocks
+	ranges, err := loadLogRanges(kv.db)
+	if err != nil {
+		return errorsmod.Wrap(err, "log ranges")
+	}
+	batch := kv.db.NewBatch()
+	defer batch.Close()
+	for _, r := range ranges {
+		if r.last < fromBlock || r.first > toBlock {
+			continue
+		}
+		if err := batch.Delete(LogRangeKey(r.last)); err != nil {
+			return errorsmod.Wrap(err, "log ranges")
+		}
+		if r.first < fromBlock {
+			if err := batch.Set(LogRangeKey(fromBlock-1), sdk.Uint64ToBigEndian(uint64(r.first))); err != nil { //nolint:gosec // G115 // block number won't exceed uint64
+				return errorsmod.Wrap(err, "log ranges")
+			}
+		}
+		if r.last > toBlock {
+			if err := batch.Set(LogRangeKey(r.last), sdk.Uint64ToBigEndian(uint64(toBlock+1))); err != nil { //nolint:gosec // G115 // block number won't exceed uint64
+				return errorsmod.Wrap(err, "log ranges")
+			}
+		}
+	}
+	return errorsmod.Wrap(batch.Write(), "log ranges")
+}
+
+// txIndexKeys returns the tx-index key along with the tx-hash key of its tx
+func txIndexKeys(key, value []byte) ([][]byte, error) {
+	return [][]byte{key, TxHashKey(common.BytesToHash(value))}, nil
+}
+
+// blockKeys returns the block keys key along with the address and log keys it
+// holds
+func blockKeys(key, value []byte) ([][]byte, error) {
+	keys, err := decodeBlockKeys(value)
+	if err != nil {
+		return nil, err
+	}
+	return append(keys, key), nil
+}
+
+// BlockKeysKey returns the key for db entry: `block number -> address and log keys`
+func BlockKeysKey(blockNumber int64) []byte {
+	return append([]byte{KeyPrefixBlockKeys}, sdk.Uint64ToBigEndian(uint64(blockNumber))...) //nolint:gosec // G115 // block number won't exceed uint64
+}
+
+// blockKeysBatch records the keys written through it, so that the address and
+// log keys of a block are saved along with the block
+type blockKeysBatch struct {
+	dbm.Batch
+	keys [][]byte
+}
+
+// Set writes the key to the underlying batch and records it
+func (b *blockKeysBatch) Set(key, value []byte) error {
+	if err := b.Batch.Set(key, value); err != nil {
+		return err
+	}
+	b.keys = append(b.keys, key)
+	return nil
+}
+
+// encode returns the recorded keys, each one prefixed by its length
+func (b *blockKeysBatch) encode() []byte {
+	var bz []byte
+	for _, key := range b.keys {
+		bz = append(bz, byte(len(key))) //#nosec G115 -- the keys are shorter than 256 bytes
+		bz = append(bz, key...)
+	}
+	return bz
+}
+
+// decodeBlockKeys decodes the keys encoded by blockKeysBatch
+func decodeBlockKeys(bz []byte) ([][]byte, error) {
+	var keys [][]byte
+	for len(bz) > 0 {
+		n := int(bz[0])
+		if len(bz) < 1+n {
+			return nil, fmt.Errorf("invalid block keys, %d bytes left for a %d bytes key", len(bz)-1, n)
+		}
+		keys = append(keys, bz[1:1+n])
+		bz = bz[1+n:]
+	}
+	return keys, nil
+}
+
+// pruneKeys deletes the keys returned by prune for the entries from start to
+// end, excluded
+func (kv *KVIndexer) pruneKeys(start, end []byte, prune func(key, value []byte) ([][]byte, error)) error {
+	for start != nil {
+		var (
+			keys [][]byte
+			err  error
+		)
+		start, keys, err = kv.scanPrunedKeys(start, end, prune)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		batch := kv.db.NewBatch()
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		err = batch.Write()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanPrunedKeys returns the keys to delete for the next pruneBatchSize
+// entries from start, along with the key to resume the scan from, nil once
+// end is reached
+func (kv *KVIndexer) scanPrunedKeys(start, end []byte, prune func(key, value []byte) ([][]byte, error)) ([]byte, [][]byte, error) {
+	it, err := kv.db.Iterator(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	var pruned [][]byte
+	for n := 0; it.Valid(); it.Next() {
+		if n == pruneBatchSize {
+			return bytes.Clone(it.Key()), pruned, nil
+		}
+		keys, err := prune(it.Key(), it.Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range keys {
+			pruned = append(pruned, bytes.Clone(key))
+		}
+		n++
+	}
+	return nil, pruned, it.Error()
+}
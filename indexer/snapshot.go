@@ -0,0 +1,387 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	cmtdbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtcfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/light"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	snapshot "cosmossdk.io/store/snapshots/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// SnapshotName is the name of the evm indexer state-sync snapshot extension
+	SnapshotName = "evm_indexer"
+	// SnapshotFormat1 encodes each exported block, from the snapshot height
+	// down, as a block payload followed by a finalize block response payload.
+	// The blocks holding indexed eth txs are exported with all their txs and
+	// tx results, the other ones with their header only, so that the restored
+	// blocks are verified against the hash chain of their headers.
+	SnapshotFormat1 = 1
+
+	// SnapshotFormat is the format of the evm indexer snapshots
+	SnapshotFormat = SnapshotFormat1
+)
+
+var _ snapshot.ExtensionSnapshotter = &Snapshotter{}
+
+// ethTxResponseTypeURL is the type url of the eth tx responses in the data of
+// the tx results
+var ethTxResponseTypeURL = sdk.MsgTypeURL(&evmtypes.MsgEthereumTxResponse{})
+
+// SnapshotClient is the CometBFT client the blocks exported by the Snapshotter
+// are loaded from
+type SnapshotClient interface {
+	rpcclient.SignClient
+	rpcclient.StatusClient
+}
+
+// HeaderVerifier returns the headers verified against the chain, which the
+// blocks restored from the snapshots are checked against
+type HeaderVerifier interface {
+	VerifiedHeader(ctx context.Context, height int64) (*cmttypes.Header, error)
+}
+
+// Snapshotter is a state-sync snapshot extension exporting the blocks of the
+// eth txs indexed in a recent window, so that the nodes restoring the
+// snapshot index them again into their own evm indexer.
+type Snapshotter struct {
+	idxer  cosmosevmtypes.EVMTxIndexer
+	client SnapshotClient
+	// verifier checks the restored blocks
+	verifier HeaderVerifier
+	// window is the number of blocks exported, up to the snapshot height
+	window int64
+	logger log.Logger
+}
+
+// NewSnapshotter returns a new Snapshotter exporting the eth txs indexed by
+// idxer in the last window blocks, loaded from client, and restoring the
+// blocks verified by verifier.
+func NewSnapshotter(
+	idxer cosmosevmtypes.EVMTxIndexer,
+	client SnapshotClient,
+	verifier HeaderVerifier,
+	window int64,
+	logger log.Logger,
+) *Snapshotter {
+	return &Snapshotter{
+		idxer:    idxer,
+		client:   client,
+		verifier: verifier,
+		window:   window,
+		logger:   logger,
+	}
+}
+
+// SnapshotName implements snapshot.ExtensionSnapshotter
+func (s *Snapshotter) SnapshotName() string {
+	return SnapshotName
+}
+
+// SnapshotFormat implements snapshot.ExtensionSnapshotter
+func (s *Snapshotter) SnapshotFormat() uint32 {
+	return SnapshotFormat
+}
+
+// SupportedFormats implements snapshot.ExtensionSnapshotter
+func (s *Snapshotter) SupportedFormats() []uint32 {
+	return []uint32{SnapshotFormat1}
+}
+
+// SnapshotExtension implements snapshot.ExtensionSnapshotter by writing the
+// blocks of the window from the snapshot height down to the first block
+// holding indexed eth txs.
+func (s *Snapshotter) SnapshotExtension(height uint64, payloadWriter snapshot.ExtensionPayloadWriter) error {
+	if s.window <= 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	status, err := s.client.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	last := int64(height) //#nosec G115 -- int overflow is not a concern here
+	// the blocks pruned from the block store can't be exported
+	first := max(last-s.window+1, status.SyncInfo.EarliestBlockHeight, 1)
+	indexed := make(map[int64]bool)
+	lowest := last + 1
+	for h := first; h <= last; h++ {
+		if len(s.indexedTxIndexes(h)) > 0 {
+			indexed[h] = true
+			lowest = min(lowest, h)
+		}
+	}
+
+	for h := last; h >= lowest; h-- {
+		pbBlock := &cmtproto.Block{}
+		results := &abci.ResponseFinalizeBlock{}
+		if indexed[h] {
+			block, err := s.client.Block(ctx, &h)
+			if err != nil {
+				return err
+			}
+			blockResults, err := s.client.BlockResults(ctx, &h)
+			if err != nil {
+				return err
+			}
+			if len(blockResults.TxsResults) != len(block.Block.Txs) {
+				return fmt.Errorf("block %d has %d txs but %d tx results", h, len(block.Block.Txs), len(blockResults.TxsResults))
+			}
+			pbBlock.Header = *block.Block.Header.ToProto()
+			pbBlock.Data.Txs = block.Block.Txs.ToSliceOfBytes()
+			results.TxResults = blockResults.TxsResults
+		} else {
+			header, err := s.client.Header(ctx, &h)
+			if err != nil {
+				return err
+			}
+			pbBlock.Header = *header.Header.ToProto()
+		}
+
+		for _, msg := range []interface{ Marshal() ([]byte, error) }{pbBlock, results} {
+			bz, err := msg.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := payloadWriter(bz); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreExtension implements snapshot.ExtensionSnapshotter by indexing the
+// exported blocks. Their headers are checked against the hash chain ending in
+// the verified header following the snapshot height, and their txs and tx
+// results against the hashes of these headers. The events of the tx results
+// aren't covered by the headers, so they're re-derived from the verified
+// results rather than restored from the snapshot.
+func (s *Snapshotter) RestoreExtension(height uint64, format uint32, payloadReader snapshot.ExtensionPayloadReader) error {
+	if format != SnapshotFormat1 {
+		return errorsmod.Wrapf(snapshot.ErrUnknownFormat, "format %v", format)
+	}
+
+	// next is the header of the block following the restored one
+	var next *cmttypes.Header
+	for {
+		bz, err := payloadReader()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var pbBlock cmtproto.Block
+		if err := pbBlock.Unmarshal(bz); err != nil {
+			return fmt.Errorf("invalid evm indexer snapshot block: %w", err)
+		}
+
+		bz, err = payloadReader()
+		if err != nil {
+			return fmt.Errorf("missing evm indexer snapshot results of block %d: %w", pbBlock.Header.Height, err)
+		}
+		var results abci.ResponseFinalizeBlock
+		if err := results.Unmarshal(bz); err != nil {
+			return fmt.Errorf("invalid evm indexer snapshot results of block %d: %w", pbBlock.Header.Height, err)
+		}
+
+		if next == nil {
+			nextHeight := int64(height) + 1 //#nosec G115 -- int overflow is not a concern here
+			if next, err = s.verifier.VerifiedHeader(context.Background(), nextHeight); err != nil {
+				return fmt.Errorf("failed to verify the header of block %d: %w", nextHeight, err)
+			}
+		}
+
+		header, err := cmttypes.HeaderFromProto(&pbBlock.Header)
+		if err != nil {
+			return err
+		}
+		if header.Height != next.Height-1 || !bytes.Equal(header.Hash(), next.LastBlockID.Hash) {
+			return fmt.Errorf("evm indexer snapshot block %d doesn't match the hash chain at block %d", header.Height, next.Height-1)
+		}
+		block := &cmttypes.Block{
+			Header: header,
+			Data:   cmttypes.Data{Txs: cmttypes.ToTxs(pbBlock.Data.Txs)},
+			// the block hash is the hash of the header, as long as there's a
+			// last commit
+			LastCommit: &cmttypes.Commit{},
+		}
+		// the blocks exported with their header only hold no indexed eth tx
+		if len(block.Txs) > 0 {
+			if err := s.indexVerifiedBlock(block, results.TxResults, next); err != nil {
+				return err
+			}
+		}
+		next = &block.Header
+	}
+}
+
+// indexVerifiedBlock indexes a block restored from a snapshot, once its txs
+// and tx results match the hashes of its header and of the next block one
+func (s *Snapshotter) indexVerifiedBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult, next *cmttypes.Header) error {
+	if !bytes.Equal(block.Txs.Hash(), block.DataHash) {
+		return fmt.Errorf("evm indexer snapshot txs of block %d don't match its header", block.Height)
+	}
+	if len(txResults) != len(block.Txs) {
+		return fmt.Errorf("block %d has %d txs but %d tx results", block.Height, len(block.Txs), len(txResults))
+	}
+	if !bytes.Equal(cmttypes.NewResults(txResults).Hash(), next.LastResultsHash) {
+		return fmt.Errorf("evm indexer snapshot tx results of block %d don't match the header of block %d", block.Height, next.Height)
+	}
+
+	verified := make([]*abci.ExecTxResult, len(txResults))
+	for i, result := range txResults {
+		events, err := ethTxEvents(result)
+		if err != nil {
+			return fmt.Errorf("invalid evm indexer snapshot tx result %d of block %d: %w", i, block.Height, err)
+		}
+		verified[i] = &abci.ExecTxResult{
+			Code:      result.Code,
+			Data:      result.Data,
+			Log:       result.Log,
+			Info:      result.Info,
+			GasWanted: result.GasWanted,
+			GasUsed:   result.GasUsed,
+			Events:    events,
+			Codespace: result.Codespace,
+		}
+	}
+	return s.idxer.IndexBlock(block, verified)
+}
+
+// ethTxEvents derives the eth tx events of a tx result restored from a
+// snapshot from the eth tx responses of its data, which unlike the events is
+// covered by the results hash. The other events, such as the bank transfers
+// indexed by the SQL indexer, can't be verified so they're dropped.
+func ethTxEvents(result *abci.ExecTxResult) ([]abci.Event, error) {
+	if result.Code != abci.CodeTypeOK {
+		// the indexers don't read the events of the failed txs
+		return nil, nil
+	}
+
+	var txMsgData sdk.TxMsgData
+	if err := proto.Unmarshal(result.Data, &txMsgData); err != nil {
+		return nil, err
+	}
+	var events []abci.Event
+	for msgIndex, msgResponse := range txMsgData.MsgResponses {
+		if msgResponse.TypeUrl != ethTxResponseTypeURL {
+			continue
+		}
+		var res evmtypes.MsgEthereumTxResponse
+		if err := proto.Unmarshal(msgResponse.Value, &res); err != nil {
+			return nil, err
+		}
+
+		msgIndexAttr := abci.EventAttribute{Key: msgIndexAttributeKey, Value: strconv.Itoa(msgIndex)}
+		txAttrs := []abci.EventAttribute{
+			{Key: evmtypes.AttributeKeyEthereumTxHash, Value: res.Hash},
+			{Key: evmtypes.AttributeKeyTxGasUsed, Value: strconv.FormatUint(res.GasUsed, 10)},
+			msgIndexAttr,
+		}
+		if res.Failed() {
+			txAttrs = append(txAttrs, abci.EventAttribute{Key: evmtypes.AttributeKeyEthereumTxFailed, Value: res.VmError})
+		}
+		logAttrs := make([]abci.EventAttribute, 0, len(res.Logs)+1)
+		for _, txLog := range res.Logs {
+			value, err := json.Marshal(txLog)
+			if err != nil {
+				return nil, err
+			}
+			logAttrs = append(logAttrs, abci.EventAttribute{Key: evmtypes.AttributeKeyTxLog, Value: string(value)})
+		}
+		logAttrs = append(logAttrs, msgIndexAttr)
+
+		events = append(events,
+			abci.Event{Type: evmtypes.EventTypeEthereumTx, Attributes: txAttrs},
+			abci.Event{Type: evmtypes.EventTypeTxLog, Attributes: logAttrs},
+		)
+	}
+	return events, nil
+}
+
+// indexedTxIndexes returns the indexes in the block of the cosmos txs
+// wrapping the eth txs indexed at the given height
+func (s *Snapshotter) indexedTxIndexes(height int64) map[uint32]struct{} {
+	txIndexes := make(map[uint32]struct{})
+	for ethTxIndex := int32(0); ; ethTxIndex++ {
+		// the indexers fail to find the txs which aren't indexed
+		res, err := s.idxer.GetByBlockAndIndex(height, ethTxIndex)
+		if err != nil || res == nil {
+			return txIndexes
+		}
+		txIndexes[res.TxIndex] = struct{}{}
+	}
+}
+
+// LightClientVerifier is a HeaderVerifier checking the headers with a light
+// client set up from the state-sync config, like the one verifying the
+// restored app state
+type LightClientVerifier struct {
+	chainID string
+	cfg     cmtcfg.StateSyncConfig
+	client  *light.Client
+}
+
+var _ HeaderVerifier = &LightClientVerifier{}
+
+// NewLightClientVerifier returns a new LightClientVerifier, whose light client
+// connects to the state-sync rpc servers on the first verification
+func NewLightClientVerifier(chainID string, cfg cmtcfg.StateSyncConfig) *LightClientVerifier {
+	return &LightClientVerifier{
+		chainID: chainID,
+		cfg:     cfg,
+	}
+}
+
+// VerifiedHeader implements HeaderVerifier
+func (v *LightClientVerifier) VerifiedHeader(ctx context.Context, height int64) (*cmttypes.Header, error) {
+	if v.client == nil {
+		if len(v.cfg.RPCServers) == 0 {
+			return nil, errors.New("no state-sync rpc servers to verify the headers")
+		}
+		trustOptions := light.TrustOptions{
+			Period: v.cfg.TrustPeriod,
+			Height: v.cfg.TrustHeight,
+			Hash:   v.cfg.TrustHashBytes(),
+		}
+		client, err := light.NewHTTPClient(
+			ctx, v.chainID, trustOptions, v.cfg.RPCServers[0], v.cfg.RPCServers[1:], lightdb.New(cmtdbm.NewMemDB(), ""),
+		)
+		if err != nil {
+			return nil, err
+		}
+		v.client = client
+	}
+
+	lightBlock, err := v.client.VerifyLightBlockAtHeight(ctx, height, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return lightBlock.Header, nil
+}
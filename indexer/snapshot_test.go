@@ -0,0 +1,244 @@
+package indexer_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/testutil/constants"
+	"github.com/cosmos/evm/testutil/integration/os/network"
+	utiltx "github.com/cosmos/evm/testutil/tx"
+	"github.com/cosmos/evm/x/vm/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// snapshotClient serves the blocks exported by the snapshotter
+type snapshotClient struct {
+	indexer.SnapshotClient
+
+	blocks    map[int64]*cmttypes.Block
+	txResults map[int64][]*abci.ExecTxResult
+	earliest  int64
+}
+
+func (c *snapshotClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{EarliestBlockHeight: c.earliest}}, nil
+}
+
+func (c *snapshotClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return &coretypes.ResultBlock{Block: c.blocks[*height]}, nil
+}
+
+func (c *snapshotClient) Header(_ context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	return &coretypes.ResultHeader{Header: &c.blocks[*height].Header}, nil
+}
+
+func (c *snapshotClient) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{Height: *height, TxsResults: c.txResults[*height]}, nil
+}
+
+// headerVerifier verifies the headers of the blocks served by the snapshot
+// client
+type headerVerifier struct {
+	src *snapshotClient
+}
+
+func (v headerVerifier) VerifiedHeader(_ context.Context, height int64) (*cmttypes.Header, error) {
+	block, ok := v.src.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	return &block.Header, nil
+}
+
+func TestSnapshotter(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	// blocks 1 to 5, an eth tx following a non eth tx in the odd ones
+	to := common.BigToAddress(big.NewInt(1))
+	src := &snapshotClient{
+		blocks:    make(map[int64]*cmttypes.Block),
+		txResults: make(map[int64][]*abci.ExecTxResult),
+		earliest:  1,
+	}
+	idxer := indexer.NewKVIndexer(dbm.NewMemDB(), log.NewNopLogger(), clientCtx)
+	var hashes []common.Hash
+	for height := int64(1); height <= 5; height++ {
+		txs := []cmttypes.Tx{[]byte("not an eth tx")}
+		txResults := []*abci.ExecTxResult{{Code: 0, GasUsed: 50000}}
+		if height%2 == 1 {
+			tx := types.NewTx(&types.EvmTxArgs{
+				Nonce:    uint64(len(hashes)),
+				To:       &to,
+				Amount:   big.NewInt(1000),
+				GasLimit: 21000,
+			})
+			tx.From = from.Hex()
+			require.NoError(t, tx.Sign(ethSigner, signer))
+			hash := tx.AsTransaction().Hash()
+			hashes = append(hashes, hash)
+
+			tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+			require.NoError(t, err)
+			txBz, err := clientCtx.TxConfig.TxEncoder()(tmTx)
+			require.NoError(t, err)
+			txs = append(txs, txBz)
+			msgResponse, err := codectypes.NewAnyWithValue(&types.MsgEthereumTxResponse{Hash: hash.Hex(), GasUsed: 21000})
+			require.NoError(t, err)
+			data, err := proto.Marshal(&sdk.TxMsgData{MsgResponses: []*codectypes.Any{msgResponse}})
+			require.NoError(t, err)
+			txResults = append(txResults, &abci.ExecTxResult{
+				Code:    0,
+				Data:    data,
+				GasUsed: 21000,
+				Events: []abci.Event{
+					{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+						{Key: "ethereumTxHash", Value: hash.Hex()},
+						{Key: "txIndex", Value: "0"},
+						{Key: "txGasUsed", Value: "21000"},
+					}},
+				},
+			})
+		}
+
+		block := cmttypes.MakeBlock(height, txs, &cmttypes.Commit{}, nil)
+		block.ChainID = "test"
+		block.ProposerAddress = make([]byte, 20)
+		if prev, ok := src.blocks[height-1]; ok {
+			block.LastBlockID = cmttypes.BlockID{Hash: prev.Hash()}
+			block.LastResultsHash = cmttypes.NewResults(src.txResults[height-1]).Hash()
+		}
+		src.blocks[height] = block
+		src.txResults[height] = txResults
+		require.NoError(t, idxer.IndexBlock(block, txResults))
+	}
+
+	// a window of the last 3 blocks exports the header of block 4 and the eth
+	// tx of block 3
+	var payloads [][]byte
+	verifier := headerVerifier{src: src}
+	snapshotter := indexer.NewSnapshotter(idxer, src, verifier, 3, log.NewNopLogger())
+	require.NoError(t, snapshotter.SnapshotExtension(4, func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	}))
+	require.Len(t, payloads, 4)
+
+	restore := func(snapshotter *indexer.Snapshotter) error {
+		i := 0
+		return snapshotter.RestoreExtension(4, indexer.SnapshotFormat, func() ([]byte, error) {
+			if i == len(payloads) {
+				return nil, io.EOF
+			}
+			i++
+			return payloads[i-1], nil
+		})
+	}
+
+	restored := indexer.NewKVIndexer(dbm.NewMemDB(), log.NewNopLogger(), clientCtx)
+	require.NoError(t, restore(indexer.NewSnapshotter(restored, nil, verifier, 0, log.NewNopLogger())))
+	_, err = restored.GetByTxHash(hashes[0])
+	require.Error(t, err)
+	expRes, err := idxer.GetByTxHash(hashes[1])
+	require.NoError(t, err)
+	res, err := restored.GetByTxHash(hashes[1])
+	require.NoError(t, err)
+	require.Equal(t, expRes, res)
+	require.Empty(t, indexer.VerifyBlock(restored, clientCtx, log.NewNopLogger(), src.blocks[3], src.txResults[3]))
+
+	// the restored blocks keep their hash
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "evmindexer.sqlite"))
+	require.NoError(t, err)
+	defer db.Close()
+	sqlIdxer, err := indexer.NewSQLIndexer(db, log.NewNopLogger(), clientCtx)
+	require.NoError(t, err)
+	require.NoError(t, restore(indexer.NewSnapshotter(sqlIdxer, nil, verifier, 0, log.NewNopLogger())))
+	var blockHash string
+	require.NoError(t, db.QueryRow("SELECT hash FROM blocks WHERE height = $1", 3).Scan(&blockHash))
+	require.Equal(t, common.BytesToHash(src.blocks[3].Hash()).Hex(), blockHash)
+
+	err = indexer.NewSnapshotter(restored, nil, verifier, 0, log.NewNopLogger()).RestoreExtension(4, 2, nil)
+	require.Error(t, err)
+
+	// the blocks not matching the verified headers aren't restored
+	unverified := indexer.NewKVIndexer(dbm.NewMemDB(), log.NewNopLogger(), clientCtx)
+	src.blocks[5].LastBlockID = cmttypes.BlockID{Hash: make([]byte, 32)}
+	require.ErrorContains(t, restore(indexer.NewSnapshotter(unverified, nil, verifier, 0, log.NewNopLogger())), "hash chain")
+	src.blocks[5].LastBlockID = cmttypes.BlockID{Hash: src.blocks[4].Hash()}
+
+	// the events of the tx results aren't restored but derived from their
+	// verified data
+	tampered := &abci.ResponseFinalizeBlock{}
+	require.NoError(t, tampered.Unmarshal(payloads[3]))
+	tampered.TxResults[1].Events = []abci.Event{
+		{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+			{Key: "ethereumTxHash", Value: hashes[0].Hex()},
+			{Key: "txIndex", Value: "0"},
+			{Key: "txGasUsed", Value: "1"},
+			{Key: "ethereumTxFailed", Value: "execution reverted"},
+		}},
+		{Type: types.EventTypeTxLog, Attributes: []abci.EventAttribute{
+			{Key: "txLog", Value: fmt.Sprintf(`{"address":"%s","topics":[],"data":null}`, to.Hex())},
+		}},
+	}
+	validPayload := payloads[3]
+	payloads[3], err = tampered.Marshal()
+	require.NoError(t, err)
+	untrusted := indexer.NewKVIndexer(dbm.NewMemDB(), log.NewNopLogger(), clientCtx)
+	require.NoError(t, restore(indexer.NewSnapshotter(untrusted, nil, verifier, 0, log.NewNopLogger())))
+	res, err = untrusted.GetByTxHash(hashes[1])
+	require.NoError(t, err)
+	require.Equal(t, expRes, res)
+	_, err = untrusted.GetByTxHash(hashes[0])
+	require.Error(t, err)
+	logs, err := untrusted.GetLogLocations([]common.Address{to}, nil, 3, 3)
+	require.NoError(t, err)
+	require.Empty(t, logs)
+
+	tampered = &abci.ResponseFinalizeBlock{}
+	require.NoError(t, tampered.Unmarshal(validPayload))
+	tampered.TxResults[1].GasUsed = 1
+	payloads[3], err = tampered.Marshal()
+	require.NoError(t, err)
+	require.ErrorContains(t, restore(indexer.NewSnapshotter(unverified, nil, verifier, 0, log.NewNopLogger())), "tx results of block 3")
+	_, err = unverified.GetByTxHash(hashes[1])
+	require.Error(t, err)
+
+	// the blocks missing from the block store aren't exported
+	payloads = nil
+	src.earliest = 4
+	require.NoError(t, snapshotter.SnapshotExtension(4, func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	}))
+	require.Empty(t, payloads)
+}
@@ -0,0 +1,662 @@
+package indexer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// sqlSchema creates the tables of the SQL indexer. The statements are valid
+// for both SQLite and Postgres. The hashes and addresses are stored as
+// lowercase 0x-prefixed hex strings, and the amounts as decimal strings.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		height BIGINT PRIMARY KEY,
+		hash TEXT NOT NULL,
+		block_time BIGINT NOT NULL,
+		eth_tx_count INTEGER NOT NULL,
+		gas_used BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS txs (
+		hash TEXT PRIMARY KEY,
+		height BIGINT NOT NULL,
+		eth_tx_index INTEGER NOT NULL,
+		tx_type INTEGER NOT NULL,
+		sender TEXT NOT NULL,
+		recipient TEXT,
+		nonce BIGINT NOT NULL,
+		value TEXT NOT NULL,
+		gas_limit BIGINT NOT NULL,
+		gas_price TEXT NOT NULL,
+		gas_tip_cap TEXT NOT NULL,
+		gas_fee_cap TEXT NOT NULL,
+		input TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS txs_block ON txs (height, eth_tx_index)`,
+	`CREATE INDEX IF NOT EXISTS txs_sender ON txs (sender, nonce)`,
+	`CREATE INDEX IF NOT EXISTS txs_recipient ON txs (recipient, height)`,
+	`CREATE TABLE IF NOT EXISTS receipts (
+		tx_hash TEXT PRIMARY KEY,
+		height BIGINT NOT NULL,
+		tx_index INTEGER NOT NULL,
+		msg_index INTEGER NOT NULL,
+		eth_tx_index INTEGER NOT NULL,
+		failed BOOLEAN NOT NULL,
+		gas_used BIGINT NOT NULL,
+		cumulative_gas_used BIGINT NOT NULL,
+		contract_address TEXT
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS receipts_block ON receipts (height, eth_tx_index)`,
+	`CREATE INDEX IF NOT EXISTS receipts_contract ON receipts (contract_address)`,
+	`CREATE TABLE IF NOT EXISTS logs (
+		height BIGINT NOT NULL,
+		log_index INTEGER NOT NULL,
+		tx_hash TEXT NOT NULL,
+		address TEXT NOT NULL,
+		topic0 TEXT,
+		topic1 TEXT,
+		topic2 TEXT,
+		topic3 TEXT,
+		data TEXT NOT NULL,
+		PRIMARY KEY (height, log_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS logs_address ON logs (address, height)`,
+	`CREATE INDEX IF NOT EXISTS logs_topic0 ON logs (topic0, height)`,
+	`CREATE INDEX IF NOT EXISTS logs_topic1 ON logs (topic1, height)`,
+	`CREATE INDEX IF NOT EXISTS logs_topic2 ON logs (topic2, height)`,
+	`CREATE INDEX IF NOT EXISTS logs_topic3 ON logs (topic3, height)`,
+	`CREATE TABLE IF NOT EXISTS internal_transfers (
+		tx_hash TEXT NOT NULL,
+		transfer_index INTEGER NOT NULL,
+		height BIGINT NOT NULL,
+		sender TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		denom TEXT NOT NULL,
+		amount TEXT NOT NULL,
+		PRIMARY KEY (tx_hash, transfer_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS internal_transfers_block ON internal_transfers (height)`,
+	`CREATE INDEX IF NOT EXISTS internal_transfers_sender ON internal_transfers (sender, height)`,
+	`CREATE INDEX IF NOT EXISTS internal_transfers_recipient ON internal_transfers (recipient, height)`,
+	// the ranges of consecutive indexed blocks, maintained along with the
+	// blocks so that the log index range isn't computed from the blocks table
+	`CREATE TABLE IF NOT EXISTS log_ranges (
+		last_height BIGINT PRIMARY KEY,
+		first_height BIGINT NOT NULL
+	)`,
+}
+
+// msgIndexAttributeKey is the attribute appended by the baseapp to the events
+// of each message of a tx
+const msgIndexAttributeKey = "msg_index"
+
+// sqlTables are the tables of the SQL indexer holding the rows of a block
+var sqlTables = []string{"blocks", "txs", "receipts", "logs", "internal_transfers"}
+
+var (
+	_ cosmosevmtypes.EVMAddressIndexer  = &SQLIndexer{}
+	_ cosmosevmtypes.EVMLogIndexer      = &SQLIndexer{}
+	_ cosmosevmtypes.EVMPrunableIndexer = &SQLIndexer{}
+)
+
+// SQLIndexer implements a eth tx indexer on a SQL db, SQLite or Postgres. The
+// blocks, txs, receipts, logs and internal transfers are stored in normalized
+// tables, so that the db can also be queried directly.
+type SQLIndexer struct {
+	db        *sql.DB
+	logger    log.Logger
+	clientCtx client.Context
+}
+
+// NewSQLIndexer creates the SQLIndexer, creating the tables of the db if they
+// don't exist yet
+func NewSQLIndexer(db *sql.DB, logger log.Logger, clientCtx client.Context) (*SQLIndexer, error) {
+	for _, stmt := range sqlSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, errorsmod.Wrap(err, "create SQL indexer schema")
+		}
+	}
+	return &SQLIndexer{db, logger, clientCtx}, nil
+}
+
+// IndexBlock index the block and all its eth txs, along with their receipts,
+// logs and internal transfers, in a single db transaction. Indexing a block
+// again replaces its rows.
+func (s *SQLIndexer) IndexBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult) error {
+	height := block.Height
+
+	dbTx, err := s.db.Begin()
+	if err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d, begin db tx", height)
+	}
+	defer dbTx.Rollback() //nolint:errcheck // no-op once committed
+
+	for _, table := range sqlTables {
+		if _, err := dbTx.Exec("DELETE FROM "+table+" WHERE height = $1", height); err != nil {
+			return errorsmod.Wrapf(err, "IndexBlock %d, delete %s", height, table)
+		}
+	}
+
+	ethTxs := parseBlockEthTxs(s.clientCtx, s.logger, block, txResults)
+	var gasUsed uint64
+	for _, ethTx := range ethTxs {
+		if err := s.saveEthTx(dbTx, &ethTx); err != nil {
+			return errorsmod.Wrapf(err, "IndexBlock %d", height)
+		}
+		gasUsed += ethTx.txResult.GasUsed
+	}
+
+	if _, err := dbTx.Exec(
+		"INSERT INTO blocks (height, hash, block_time, eth_tx_count, gas_used) VALUES ($1, $2, $3, $4, $5)",
+		height, common.BytesToHash(block.Hash()).Hex(), block.Time.Unix(), len(ethTxs), gasUsed,
+	); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d, insert block", height)
+	}
+	if err := saveSQLLogRange(dbTx, height); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d, save log range", height)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlock %d, commit db tx", height)
+	}
+	return nil
+}
+
+// ReindexBlock indexes the block again, which replaces its rows in the same db
+// transaction
+func (s *SQLIndexer) ReindexBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult) error {
+	return s.IndexBlock(block, txResults)
+}
+
+// PruneBlocks deletes the rows of the blocks lower than height
+func (s *SQLIndexer) PruneBlocks(height int64) error {
+	return errorsmod.Wrap(s.deleteBlocks(0, height-1), "PruneBlocks")
+}
+
+// DeleteBlocks deletes the rows of the blocks from fromBlock to toBlock,
+// included, so that the blocks can be indexed again
+func (s *SQLIndexer) DeleteBlocks(fromBlock, toBlock int64) error {
+	return errorsmod.Wrap(s.deleteBlocks(fromBlock, toBlock), "DeleteBlocks")
+}
+
+// deleteBlocks deletes the rows of the blocks from fromBlock to toBlock,
+// included, in a single db transaction
+func (s *SQLIndexer) deleteBlocks(fromBlock, toBlock int64) error {
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	dbTx, err := s.db.Begin()
+	if err != nil {
+		return errorsmod.Wrap(err, "begin db tx")
+	}
+	defer dbTx.Rollback() //nolint:errcheck // no-op once committed
+
+	for _, table := range sqlTables {
+		if _, err := dbTx.Exec("DELETE FROM "+table+" WHERE height >= $1 AND height <= $2", fromBlock, toBlock); err != nil {
+			return errorsmod.Wrapf(err, "delete %s", table)
+		}
+	}
+
+	// the log ranges are cut around the blocks
+	ranges, err := querySQLLogRanges(dbTx, fromBlock, toBlock)
+	if err != nil {
+		return errorsmod.Wrap(err, "query log ranges")
+	}
+	for _, r := range ranges {
+		if _, err := dbTx.Exec("DELETE FROM log_ranges WHERE last_height = $1", r.last); err != nil {
+			return errorsmod.Wrap(err, "delete log range")
+		}
+		if r.first < fromBlock {
+			if _, err := dbTx.Exec("INSERT INTO log_ranges (last_height, first_height) VALUES ($1, $2)", fromBlock-1, r.first); err != nil {
+				return errorsmod.Wrap(err, "insert log range")
+			}
+		}
+		if r.last > toBlock {
+			if _, err := dbTx.Exec("INSERT INTO log_ranges (last_height, first_height) VALUES ($1, $2)", r.last, toBlock+1); err != nil {
+				return errorsmod.Wrap(err, "insert log range")
+			}
+		}
+	}
+	return errorsmod.Wrap(dbTx.Commit(), "commit db tx")
+}
+
+// saveSQLLogRange adds the block to the ranges of consecutive indexed blocks in
+// the db transaction, merging the ranges it's adjacent to, like the log ranges
+// of the KVIndexer.
+func saveSQLLogRange(dbTx *sql.Tx, height int64) error {
+	ranges, err := querySQLLogRanges(dbTx, height-1, height+1)
+	if err != nil {
+		return err
+	}
+
+	merged := logRange{first: height, last: height}
+	for _, r := range ranges {
+		if r.first <= height && height <= r.last {
+			// already indexed
+			return nil
+		}
+		merged.first = min(merged.first, r.first)
+		merged.last = max(merged.last, r.last)
+		if _, err := dbTx.Exec("DELETE FROM log_ranges WHERE last_height = $1", r.last); err != nil {
+			return err
+		}
+	}
+	_, err = dbTx.Exec("INSERT INTO log_ranges (last_height, first_height) VALUES ($1, $2)", merged.last, merged.first)
+	return err
+}
+
+// querySQLLogRanges returns the ranges of consecutive indexed blocks
+// overlapping the blocks from fromBlock to toBlock, included
+func querySQLLogRanges(dbTx *sql.Tx, fromBlock, toBlock int64) ([]logRange, error) {
+	rows, err := dbTx.Query(
+		"SELECT first_height, last_height FROM log_ranges WHERE last_height >= $1 AND first_height <= $2 ORDER BY last_height",
+		fromBlock, toBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranges []logRange
+	for rows.Next() {
+		var r logRange
+		if err := rows.Scan(&r.first, &r.last); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, rows.Err()
+}
+
+// saveEthTx inserts the rows of the eth tx into the db transaction
+func (s *SQLIndexer) saveEthTx(dbTx *sql.Tx, ethTx *blockEthTx) error {
+	tx := ethTx.msg.AsTransaction()
+	txResult := &ethTx.txResult
+	txHash := ethTx.hash.Hex()
+
+	var sender string
+	from, err := ethMsgSender(ethTx.msg)
+	if err != nil {
+		s.logger.Error("Fail to recover tx sender", "err", err, "hash", txHash)
+	} else {
+		sender = sqlAddress(from)
+	}
+
+	var recipient, contract sql.NullString
+	if to := tx.To(); to != nil {
+		recipient = sql.NullString{String: sqlAddress(*to), Valid: true}
+	} else if sender != "" && !txResult.Failed {
+		contract = sql.NullString{String: sqlAddress(crypto.CreateAddress(from, tx.Nonce())), Valid: true}
+	}
+
+	if _, err := dbTx.Exec(
+		`INSERT INTO txs (hash, height, eth_tx_index, tx_type, sender, recipient, nonce, value, gas_limit, gas_price, gas_tip_cap, gas_fee_cap, input)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		txHash, txResult.Height, txResult.EthTxIndex, tx.Type(), sender, recipient, tx.Nonce(), tx.Value().String(),
+		tx.Gas(), tx.GasPrice().String(), tx.GasTipCap().String(), tx.GasFeeCap().String(), hexutil.Encode(tx.Data()),
+	); err != nil {
+		return errorsmod.Wrap(err, "insert tx")
+	}
+	if _, err := dbTx.Exec(
+		`INSERT INTO receipts (tx_hash, height, tx_index, msg_index, eth_tx_index, failed, gas_used, cumulative_gas_used, contract_address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		txHash, txResult.Height, txResult.TxIndex, txResult.MsgIndex, txResult.EthTxIndex, txResult.Failed,
+		txResult.GasUsed, txResult.CumulativeGasUsed, contract,
+	); err != nil {
+		return errorsmod.Wrap(err, "insert receipt")
+	}
+
+	if txResult.Failed {
+		// reverted, no logs nor transfers
+		return nil
+	}
+
+	logs, err := txLogsFromEvents(ethTx.result.Events, ethTx.msgIndex)
+	if err != nil {
+		s.logger.Error("Fail to parse tx logs", "err", err, "hash", txHash)
+	}
+	for _, txLog := range evmtypes.LogsToEthereum(logs) {
+		var topics [maxLogTopics]sql.NullString
+		for i := 0; i < len(txLog.Topics) && i < maxLogTopics; i++ {
+			topics[i] = sql.NullString{String: txLog.Topics[i].Hex(), Valid: true}
+		}
+		if _, err := dbTx.Exec(
+			`INSERT INTO logs (height, log_index, tx_hash, address, topic0, topic1, topic2, topic3, data)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			txResult.Height, txLog.Index, txHash, sqlAddress(txLog.Address),
+			topics[0], topics[1], topics[2], topics[3], hexutil.Encode(txLog.Data),
+		); err != nil {
+			return errorsmod.Wrap(err, "insert log")
+		}
+	}
+
+	var transferIndex int
+	for _, event := range ethTx.result.Events {
+		if event.Type != banktypes.EventTypeTransfer || !isMsgEvent(event, ethTx.msgIndex) {
+			continue
+		}
+		transferSender, transferRecipient, coins, ok := parseTransferEvent(event)
+		if !ok {
+			s.logger.Error("Fail to parse transfer event", "hash", txHash)
+			continue
+		}
+		for _, coin := range coins {
+			if _, err := dbTx.Exec(
+				`INSERT INTO internal_transfers (tx_hash, transfer_index, height, sender, recipient, denom, amount)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				txHash, transferIndex, txResult.Height, transferSender, transferRecipient, coin.Denom, coin.Amount.String(),
+			); err != nil {
+				return errorsmod.Wrap(err, "insert internal transfer")
+			}
+			transferIndex++
+		}
+	}
+	return nil
+}
+
+// Close closes the db of the indexer
+func (s *SQLIndexer) Close() error {
+	return s.db.Close()
+}
+
+// LastIndexedBlock returns the latest indexed block number, returns -1 if db is empty
+func (s *SQLIndexer) LastIndexedBlock() (int64, error) {
+	var height sql.NullInt64
+	if err := s.db.QueryRow("SELECT MAX(height) FROM blocks").Scan(&height); err != nil {
+		return 0, errorsmod.Wrap(err, "LastIndexedBlock")
+	}
+	if !height.Valid {
+		return -1, nil
+	}
+	return height.Int64, nil
+}
+
+// FirstIndexedBlock returns the first indexed block number, returns -1 if db is empty
+func (s *SQLIndexer) FirstIndexedBlock() (int64, error) {
+	var height sql.NullInt64
+	if err := s.db.QueryRow("SELECT MIN(height) FROM blocks").Scan(&height); err != nil {
+		return 0, errorsmod.Wrap(err, "FirstIndexedBlock")
+	}
+	if !height.Valid {
+		return -1, nil
+	}
+	return height.Int64, nil
+}
+
+// GetByTxHash finds eth tx by eth tx hash
+func (s *SQLIndexer) GetByTxHash(hash common.Hash) (*cosmosevmtypes.TxResult, error) {
+	res, err := s.getTxResult("tx_hash = $1", hash.Hex())
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetByTxHash %s", hash.Hex())
+	}
+	if res == nil {
+		return nil, fmt.Errorf("tx not found, hash: %s", hash.Hex())
+	}
+	return res, nil
+}
+
+// GetByBlockAndIndex finds eth tx by block number and eth tx index
+func (s *SQLIndexer) GetByBlockAndIndex(blockNumber int64, txIndex int32) (*cosmosevmtypes.TxResult, error) {
+	res, err := s.getTxResult("height = $1 AND eth_tx_index = $2", blockNumber, txIndex)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetByBlockAndIndex %d %d", blockNumber, txIndex)
+	}
+	if res == nil {
+		return nil, fmt.Errorf("tx not found, block: %d, eth-index: %d", blockNumber, txIndex)
+	}
+	return res, nil
+}
+
+// getTxResult returns the result of the eth tx whose receipt matches the
+// condition, nil if not found
+func (s *SQLIndexer) getTxResult(cond string, args ...any) (*cosmosevmtypes.TxResult, error) {
+	var res cosmosevmtypes.TxResult
+	err := s.db.QueryRow(
+		"SELECT height, tx_index, msg_index, eth_tx_index, failed, gas_used, cumulative_gas_used FROM receipts WHERE "+cond,
+		args...,
+	).Scan(&res.Height, &res.TxIndex, &res.MsgIndex, &res.EthTxIndex, &res.Failed, &res.GasUsed, &res.CumulativeGasUsed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetByAddress finds the hashes of the eth txs sent from or to the address, or
+// deploying a contract at it, see cosmosevmtypes.EVMAddressIndexer.
+func (s *SQLIndexer) GetByAddress(address common.Address, fromBlock int64, reverse bool, pageSize int) ([]common.Hash, bool, error) {
+	query := `SELECT t.height, t.hash FROM txs t JOIN receipts r ON r.tx_hash = t.hash
+		WHERE (t.sender = $1 OR t.recipient = $1 OR r.contract_address = $1)`
+	args := []any{sqlAddress(address)}
+	if fromBlock >= 0 {
+		args = append(args, fromBlock)
+		if reverse {
+			query += " AND t.height <= $2"
+		} else {
+			query += " AND t.height >= $2"
+		}
+	}
+	if reverse {
+		query += " ORDER BY t.height DESC, t.eth_tx_index DESC"
+	} else {
+		query += " ORDER BY t.height, t.eth_tx_index"
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, false, errorsmod.Wrapf(err, "GetByAddress %s", address.Hex())
+	}
+	defer rows.Close()
+
+	var (
+		hashes     []common.Hash
+		lastHeight int64 = -1
+	)
+	for rows.Next() {
+		var (
+			height int64
+			hash   string
+		)
+		if err := rows.Scan(&height, &hash); err != nil {
+			return nil, false, errorsmod.Wrapf(err, "GetByAddress %s", address.Hex())
+		}
+		// never split the txs of a block between pages
+		if len(hashes) >= pageSize && height != lastHeight {
+			return hashes, true, nil
+		}
+		hashes = append(hashes, common.HexToHash(hash))
+		lastHeight = height
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, errorsmod.Wrapf(err, "GetByAddress %s", address.Hex())
+	}
+	return hashes, false, nil
+}
+
+// GetBySenderAndNonce finds the eth tx hash by sender and nonce
+func (s *SQLIndexer) GetBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error) {
+	hash, err := s.getHash("SELECT hash FROM txs WHERE sender = $1 AND nonce = $2 ORDER BY height DESC LIMIT 1", sqlAddress(sender), nonce)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetBySenderAndNonce %s %d", sender.Hex(), nonce)
+	}
+	return hash, nil
+}
+
+// GetContractCreationTx finds the hash of the eth tx deploying the contract
+func (s *SQLIndexer) GetContractCreationTx(contract common.Address) (*common.Hash, error) {
+	hash, err := s.getHash("SELECT tx_hash FROM receipts WHERE contract_address = $1 ORDER BY height DESC LIMIT 1", sqlAddress(contract))
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetContractCreationTx %s", contract.Hex())
+	}
+	return hash, nil
+}
+
+// getHash returns the hash selected by the query, nil if not found
+func (s *SQLIndexer) getHash(query string, args ...any) (*common.Hash, error) {
+	var hex string
+	err := s.db.QueryRow(query, args...).Scan(&hex)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash := common.HexToHash(hex)
+	return &hash, nil
+}
+
+// LogIndexRange returns the latest range of consecutive indexed blocks, whose
+// logs can be looked up, -1 and -1 if none.
+func (s *SQLIndexer) LogIndexRange() (int64, int64, error) {
+	var first, last int64
+	err := s.db.QueryRow("SELECT first_height, last_height FROM log_ranges ORDER BY last_height DESC LIMIT 1").Scan(&first, &last)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, -1, nil
+	}
+	if err != nil {
+		return 0, 0, errorsmod.Wrap(err, "LogIndexRange")
+	}
+	return first, last, nil
+}
+
+// GetLogLocations finds the locations of the eth logs matching the addresses
+// and topics, see cosmosevmtypes.EVMLogIndexer.
+func (s *SQLIndexer) GetLogLocations(
+	addresses []common.Address,
+	topics [][]common.Hash,
+	fromBlock, toBlock int64,
+) ([]cosmosevmtypes.LogLocation, error) {
+	if len(topics) > maxLogTopics {
+		return nil, fmt.Errorf("too many topics, got %d: limit %d", len(topics), maxLogTopics)
+	}
+
+	args := []any{fromBlock, toBlock}
+	// in appends the values to the args, returning the condition matching the
+	// column against any of them
+	in := func(column string, values []string) string {
+		placeholders := make([]string, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = "$" + strconv.Itoa(len(args))
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	var conds []string
+	if len(addresses) > 0 {
+		values := make([]string, len(addresses))
+		for i, address := range addresses {
+			values[i] = sqlAddress(address)
+		}
+		conds = append(conds, in("address", values))
+	}
+	for position, topicList := range topics {
+		if len(topicList) == 0 {
+			// wildcard
+			continue
+		}
+		values := make([]string, len(topicList))
+		for i, topic := range topicList {
+			values[i] = topic.Hex()
+		}
+		conds = append(conds, in("topic"+strconv.Itoa(position), values))
+	}
+	if len(conds) == 0 {
+		return nil, fmt.Errorf("GetLogLocations requires at least one address or topic")
+	}
+
+	rows, err := s.db.Query(
+		"SELECT height, log_index FROM logs WHERE height >= $1 AND height <= $2 AND "+strings.Join(conds, " AND ")+" ORDER BY height, log_index",
+		args...,
+	)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "GetLogLocations")
+	}
+	defer rows.Close()
+
+	locations := []cosmosevmtypes.LogLocation{}
+	for rows.Next() {
+		var loc cosmosevmtypes.LogLocation
+		if err := rows.Scan(&loc.Height, &loc.LogIndex); err != nil {
+			return nil, errorsmod.Wrap(err, "GetLogLocations")
+		}
+		locations = append(locations, loc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errorsmod.Wrap(err, "GetLogLocations")
+	}
+	return locations, nil
+}
+
+// sqlAddress returns the address as stored in the SQL db
+func sqlAddress(address common.Address) string {
+	return strings.ToLower(address.Hex())
+}
+
+// isMsgEvent returns true if the event was emitted by the execution of the
+// message of the given index in the cosmos tx
+func isMsgEvent(event abci.Event, msgIndex int) bool {
+	for _, attr := range event.Attributes {
+		if attr.Key == msgIndexAttributeKey {
+			return attr.Value == strconv.Itoa(msgIndex)
+		}
+	}
+	return false
+}
+
+// parseTransferEvent parses the sender, recipient and amount of a bank
+// transfer event, converting the bech32 addresses to hex if possible
+func parseTransferEvent(event abci.Event) (string, string, sdk.Coins, bool) {
+	var (
+		sender, recipient string
+		coins             sdk.Coins
+		err               error
+	)
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case banktypes.AttributeKeySender:
+			sender = transferAddress(attr.Value)
+		case banktypes.AttributeKeyRecipient:
+			recipient = transferAddress(attr.Value)
+		case sdk.AttributeKeyAmount:
+			if coins, err = sdk.ParseCoinsNormalized(attr.Value); err != nil {
+				return "", "", nil, false
+			}
+		}
+	}
+	if sender == "" || recipient == "" {
+		return "", "", nil, false
+	}
+	return sender, recipient, coins, true
+}
+
+// transferAddress returns the hex form of the bech32 address of a transfer
+// event, or the address as is if it can't be decoded
+func transferAddress(bech32 string) string {
+	addr, err := sdk.AccAddressFromBech32(bech32)
+	if err != nil {
+		return bech32
+	}
+	return sqlAddress(common.BytesToAddress(addr))
+}
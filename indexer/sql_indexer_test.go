@@ -0,0 +1,210 @@
+package indexer_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/testutil/constants"
+	"github.com/cosmos/evm/testutil/integration/os/network"
+	utiltx "github.com/cosmos/evm/testutil/tx"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	"github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSQLIndexer(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "evmindexer.sqlite"))
+	require.NoError(t, err)
+	defer db.Close()
+	idxer, err := indexer.NewSQLIndexer(db, log.NewNopLogger(), clientCtx)
+	require.NoError(t, err)
+
+	last, err := idxer.LastIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), last)
+
+	// index a transfer, a contract deployment and a contract call emitting a
+	// log and transferring tokens in blocks 1 to 3
+	to := common.BigToAddress(big.NewInt(1))
+	contract := crypto.CreateAddress(from, 1)
+	topic := common.BigToHash(big.NewInt(1))
+	recipients := []*common.Address{&to, nil, &contract}
+	hashes := make([]common.Hash, len(recipients))
+	indexBlock := func(i int) {
+		tx := types.NewTx(&types.EvmTxArgs{
+			Nonce:    uint64(i), //nolint:gosec // G115
+			To:       recipients[i],
+			Amount:   big.NewInt(1000),
+			GasLimit: 100000,
+		})
+		tx.From = from.Hex()
+		require.NoError(t, tx.Sign(ethSigner, signer))
+		hashes[i] = tx.AsTransaction().Hash()
+
+		tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+		require.NoError(t, err)
+		txBz, err := clientCtx.TxConfig.TxEncoder()(tmTx)
+		require.NoError(t, err)
+
+		events := []abci.Event{
+			{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+				{Key: "ethereumTxHash", Value: hashes[i].Hex()},
+				{Key: "txIndex", Value: "0"},
+				{Key: "txGasUsed", Value: "21000"},
+			}},
+		}
+		if i == 2 {
+			txLog, err := json.Marshal(&types.Log{Address: contract.Hex(), Topics: []string{topic.Hex()}, Index: 0})
+			require.NoError(t, err)
+			events = append(events,
+				abci.Event{Type: types.EventTypeTxLog, Attributes: []abci.EventAttribute{
+					{Key: types.AttributeKeyTxLog, Value: string(txLog)},
+					{Key: "msg_index", Value: "0"},
+				}},
+				abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{
+					{Key: "recipient", Value: sdk.AccAddress(to.Bytes()).String()},
+					{Key: "sender", Value: sdk.AccAddress(contract.Bytes()).String()},
+					{Key: "amount", Value: "500" + constants.ExampleAttoDenom},
+					{Key: "msg_index", Value: "0"},
+				}},
+			)
+		}
+
+		block := &cmttypes.Block{Header: cmttypes.Header{Height: int64(i + 1)}, Data: cmttypes.Data{Txs: []cmttypes.Tx{txBz}}}
+		err = idxer.IndexBlock(block, []*abci.ExecTxResult{{Code: 0, GasUsed: 21000, Events: events}})
+		require.NoError(t, err)
+	}
+	for i := range recipients {
+		indexBlock(i)
+	}
+	// indexing a block again replaces it
+	indexBlock(2)
+
+	first, err := idxer.FirstIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first)
+	last, err = idxer.LastIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), last)
+
+	for i, hash := range hashes {
+		expRes := &cosmosevmtypes.TxResult{
+			Height:            int64(i + 1),
+			GasUsed:           21000,
+			CumulativeGasUsed: 21000,
+		}
+		res, err := idxer.GetByTxHash(hash)
+		require.NoError(t, err)
+		require.Equal(t, expRes, res)
+		res, err = idxer.GetByBlockAndIndex(int64(i+1), 0)
+		require.NoError(t, err)
+		require.Equal(t, expRes, res)
+	}
+	_, err = idxer.GetByTxHash(common.Hash{})
+	require.Error(t, err)
+	_, err = idxer.GetByBlockAndIndex(1, 1)
+	require.Error(t, err)
+
+	res, hasMore, err := idxer.GetByAddress(from, -1, true, 2)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[2], hashes[1]}, res)
+	require.True(t, hasMore)
+	res, hasMore, err = idxer.GetByAddress(contract, 2, false, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[1], hashes[2]}, res)
+	require.False(t, hasMore)
+
+	hash, err := idxer.GetBySenderAndNonce(from, 1)
+	require.NoError(t, err)
+	require.Equal(t, &hashes[1], hash)
+	hash, err = idxer.GetContractCreationTx(contract)
+	require.NoError(t, err)
+	require.Equal(t, &hashes[1], hash)
+	hash, err = idxer.GetContractCreationTx(to)
+	require.NoError(t, err)
+	require.Nil(t, hash)
+
+	logFirst, logLast, err := idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), logFirst)
+	require.Equal(t, int64(3), logLast)
+	locations, err := idxer.GetLogLocations([]common.Address{contract}, [][]common.Hash{{topic}}, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []cosmosevmtypes.LogLocation{{Height: 3, LogIndex: 0}}, locations)
+	locations, err = idxer.GetLogLocations(nil, [][]common.Hash{{}, {topic}}, 1, 3)
+	require.NoError(t, err)
+	require.Empty(t, locations)
+
+	// the tables can be queried directly
+	var (
+		sender, recipient, amount string
+		count                     int
+	)
+	require.NoError(t, db.QueryRow("SELECT sender, recipient, amount FROM internal_transfers WHERE tx_hash = $1", hashes[2].Hex()).Scan(&sender, &recipient, &amount))
+	require.Equal(t, []string{strings.ToLower(contract.Hex()), strings.ToLower(to.Hex()), "500"}, []string{sender, recipient, amount})
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM logs").Scan(&count))
+	require.Equal(t, 1, count)
+
+	require.NoError(t, idxer.PruneBlocks(3))
+	first, err = idxer.FirstIndexedBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), first)
+	_, err = idxer.GetByTxHash(hashes[0])
+	require.Error(t, err)
+	res, _, err = idxer.GetByAddress(from, -1, true, 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{hashes[2]}, res)
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM receipts").Scan(&count))
+	require.Equal(t, 1, count)
+	logFirst, logLast, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 3}, []int64{logFirst, logLast})
+
+	// block 4 isn't indexed, so the range restarts from block 5 until block 4
+	// joins both ranges
+	require.NoError(t, idxer.IndexBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 5}}, nil))
+	logFirst, logLast, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 5}, []int64{logFirst, logLast})
+	require.NoError(t, idxer.IndexBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 4}}, nil))
+	logFirst, logLast, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 5}, []int64{logFirst, logLast})
+
+	// deleting block 4 splits the range
+	require.NoError(t, idxer.DeleteBlocks(4, 4))
+	logFirst, logLast, err = idxer.LogIndexRange()
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 5}, []int64{logFirst, logLast})
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM blocks").Scan(&count))
+	require.Equal(t, 2, count)
+}
@@ -0,0 +1,134 @@
+package indexer
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
+)
+
+// blockEthTx is an eth tx of a block, along with its indexed result
+type blockEthTx struct {
+	msg      *evmtypes.MsgEthereumTx
+	hash     common.Hash
+	msgIndex int
+	// result is the result of the cosmos tx wrapping the eth tx
+	result   *abci.ExecTxResult
+	txResult cosmosevmtypes.TxResult
+}
+
+// parseBlockEthTxs parses the eth txs of a block through the following steps:
+// - Iterates over all of the Txs in Block
+// - Parses eth Tx infos from cosmos-sdk events for every TxResult
+// - Iterates over all the messages of the Tx
+// - Builds a indexer.TxResult based on parsed events for every message
+func parseBlockEthTxs(clientCtx client.Context, logger log.Logger, block *cmttypes.Block, txResults []*abci.ExecTxResult) []blockEthTx {
+	height := block.Height
+
+	var ethTxs []blockEthTx
+	// record index of valid eth tx during the iteration
+	var ethTxIndex int32
+	for txIndex, tx := range block.Txs {
+		result := txResults[txIndex]
+		if !rpctypes.TxSucessOrExpectedFailure(result) {
+			continue
+		}
+
+		tx, err := clientCtx.TxConfig.TxDecoder()(tx)
+		if err != nil {
+			logger.Error("Fail to decode tx", "err", err, "block", height, "txIndex", txIndex)
+			continue
+		}
+
+		if !isEthTx(tx) {
+			continue
+		}
+
+		txs, err := rpctypes.ParseTxResult(result, tx)
+		if err != nil {
+			logger.Error("Fail to parse event", "err", err, "block", height, "txIndex", txIndex)
+			continue
+		}
+
+		var cumulativeGasUsed uint64
+		for msgIndex, msg := range tx.GetMsgs() {
+			ethMsg := msg.(*evmtypes.MsgEthereumTx)
+			txHash := common.HexToHash(ethMsg.Hash)
+
+			txResult := cosmosevmtypes.TxResult{
+				Height:     height,
+				TxIndex:    uint32(txIndex),  //#nosec G115 -- int overflow is not a concern here
+				MsgIndex:   uint32(msgIndex), //#nosec G115 -- int overflow is not a concern here
+				EthTxIndex: ethTxIndex,
+			}
+			if result.Code != abci.CodeTypeOK {
+				// exceeds block gas limit scenario, set gas used to gas limit because that's what's charged by ante handler.
+				// some old versions don't emit any events, so workaround here directly.
+				txResult.GasUsed = ethMsg.GetGas()
+				txResult.Failed = true
+			} else {
+				parsedTx := txs.GetTxByMsgIndex(msgIndex)
+				if parsedTx == nil {
+					logger.Error("msg index not found in events", "msgIndex", msgIndex)
+					continue
+				}
+				if parsedTx.EthTxIndex >= 0 && parsedTx.EthTxIndex != ethTxIndex {
+					logger.Error("eth tx index don't match", "expect", ethTxIndex, "found", parsedTx.EthTxIndex)
+				}
+				txResult.GasUsed = parsedTx.GasUsed
+				txResult.Failed = parsedTx.Failed
+			}
+
+			cumulativeGasUsed += txResult.GasUsed
+			txResult.CumulativeGasUsed = cumulativeGasUsed
+			ethTxIndex++
+
+			ethTxs = append(ethTxs, blockEthTx{
+				msg:      ethMsg,
+				hash:     txHash,
+				msgIndex: msgIndex,
+				result:   result,
+				txResult: txResult,
+			})
+		}
+	}
+	return ethTxs
+}
+
+// isEthTx check if the tx is an eth tx
+func isEthTx(tx sdk.Tx) bool {
+	extTx, ok := tx.(authante.HasExtensionOptionsTx)
+	if !ok {
+		return false
+	}
+	opts := extTx.GetExtensionOptions()
+	if len(opts) != 1 || opts[0].GetTypeUrl() != "/cosmos.evm.vm.v1.ExtensionOptionsEthereumTx" {
+		return false
+	}
+	return true
+}
+
+// ethMsgSender returns the sender of the eth msg, verified by the ante handler
+// when the tx was included. The sender is only recovered from the signature if
+// the msg doesn't set it.
+func ethMsgSender(msg *evmtypes.MsgEthereumTx) (common.Address, error) {
+	if msg.From != "" {
+		return common.HexToAddress(msg.From), nil
+	}
+	tx := msg.AsTransaction()
+	if !tx.Protected() {
+		return ethtypes.Sender(ethtypes.HomesteadSigner{}, tx)
+	}
+	return msg.GetSender(tx.ChainId())
+}
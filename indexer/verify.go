@@ -0,0 +1,93 @@
+package indexer
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	cosmosevmtypes "github.com/cosmos/evm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	// IssueMissingTx is reported for an eth tx of the block which isn't indexed
+	IssueMissingTx = "missing_tx"
+	// IssueExtraTx is reported for an indexed eth tx which isn't in the block
+	IssueExtraTx = "extra_tx"
+	// IssueWrongIndex is reported for an eth tx indexed with a different
+	// result or at a different position than in the block
+	IssueWrongIndex = "wrong_index"
+)
+
+// BlockIssue is an inconsistency between the eth txs of a block and the ones
+// indexed for it
+type BlockIssue struct {
+	Height     int64                    `json:"height"`
+	Issue      string                   `json:"issue"`
+	EthTxIndex int32                    `json:"eth_tx_index"`
+	TxHash     string                   `json:"tx_hash,omitempty"`
+	Expected   *cosmosevmtypes.TxResult `json:"expected,omitempty"`
+	Indexed    *cosmosevmtypes.TxResult `json:"indexed,omitempty"`
+}
+
+// VerifyBlock compares the eth txs indexed by the indexer for the block with
+// the ones parsed from the block and its tx results, and returns the
+// inconsistencies found.
+func VerifyBlock(
+	idxer cosmosevmtypes.EVMTxIndexer,
+	clientCtx client.Context,
+	logger log.Logger,
+	block *cmttypes.Block,
+	txResults []*abci.ExecTxResult,
+) []BlockIssue {
+	ethTxs := parseBlockEthTxs(clientCtx, logger, block, txResults)
+
+	var issues []BlockIssue
+	for _, ethTx := range ethTxs {
+		expected := ethTx.txResult
+		issue := BlockIssue{
+			Height:     block.Height,
+			EthTxIndex: expected.EthTxIndex,
+			TxHash:     ethTx.hash.Hex(),
+			Expected:   &expected,
+		}
+
+		// the indexers fail to find the txs which aren't indexed
+		indexed, err := idxer.GetByTxHash(ethTx.hash)
+		if err != nil || indexed == nil {
+			issue.Issue = IssueMissingTx
+			issues = append(issues, issue)
+			continue
+		}
+		if *indexed != expected {
+			issue.Issue = IssueWrongIndex
+			issue.Indexed = indexed
+			issues = append(issues, issue)
+			continue
+		}
+
+		indexed, err = idxer.GetByBlockAndIndex(block.Height, expected.EthTxIndex)
+		if err != nil || indexed == nil || *indexed != expected {
+			issue.Issue = IssueWrongIndex
+			issue.Indexed = indexed
+			issues = append(issues, issue)
+		}
+	}
+
+	// the txs indexed past the eth txs of the block
+	for ethTxIndex := int32(len(ethTxs)); ; ethTxIndex++ { //#nosec G115 -- int overflow is not a concern here
+		indexed, err := idxer.GetByBlockAndIndex(block.Height, ethTxIndex)
+		if err != nil || indexed == nil {
+			break
+		}
+		issues = append(issues, BlockIssue{
+			Height:     block.Height,
+			Issue:      IssueExtraTx,
+			EthTxIndex: ethTxIndex,
+			Indexed:    indexed,
+		})
+	}
+	return issues
+}
@@ -0,0 +1,129 @@
+package indexer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/testutil/constants"
+	"github.com/cosmos/evm/testutil/integration/os/network"
+	utiltx "github.com/cosmos/evm/testutil/tx"
+	"github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func TestVerifyBlock(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := utiltx.NewSigner(priv)
+	ethSigner := ethtypes.LatestSignerForChainID(nil)
+
+	nw := network.New()
+	encodingConfig := nw.GetEncodingConfig()
+	clientCtx := client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Codec)
+
+	to := common.BigToAddress(big.NewInt(1))
+	txs := make([]cmttypes.Tx, 2)
+	txResults := make([]*abci.ExecTxResult, 2)
+	hashes := make([]common.Hash, 2)
+	for i := range txs {
+		tx := types.NewTx(&types.EvmTxArgs{
+			Nonce:    uint64(i), //nolint:gosec // G115
+			To:       &to,
+			Amount:   big.NewInt(1000),
+			GasLimit: 21000,
+		})
+		tx.From = from.Hex()
+		require.NoError(t, tx.Sign(ethSigner, signer))
+		hashes[i] = tx.AsTransaction().Hash()
+
+		tmTx, err := tx.BuildTx(clientCtx.TxConfig.NewTxBuilder(), constants.ExampleAttoDenom)
+		require.NoError(t, err)
+		txs[i], err = clientCtx.TxConfig.TxEncoder()(tmTx)
+		require.NoError(t, err)
+		txResults[i] = &abci.ExecTxResult{
+			Code: 0,
+			Events: []abci.Event{
+				{Type: types.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: hashes[i].Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "txGasUsed", Value: "21000"},
+				}},
+			},
+		}
+	}
+	indexBlock := func(idxer *indexer.KVIndexer, from, to int) {
+		block := &cmttypes.Block{Header: cmttypes.Header{Height: 1}, Data: cmttypes.Data{Txs: txs[from:to]}}
+		require.NoError(t, idxer.IndexBlock(block, txResults[from:to]))
+	}
+
+	testCases := []struct {
+		name      string
+		index     func(idxer *indexer.KVIndexer)
+		blockTxs  int
+		expIssues []string
+	}{
+		{
+			"consistent",
+			func(idxer *indexer.KVIndexer) {
+				indexBlock(idxer, 0, 2)
+			},
+			2,
+			nil,
+		},
+		{
+			"missing tx",
+			func(idxer *indexer.KVIndexer) {
+				indexBlock(idxer, 0, 1)
+			},
+			2,
+			[]string{indexer.IssueMissingTx},
+		},
+		{
+			"wrong index",
+			func(idxer *indexer.KVIndexer) {
+				// the second tx indexed again as the first one of the block
+				indexBlock(idxer, 0, 2)
+				indexBlock(idxer, 1, 2)
+			},
+			2,
+			[]string{indexer.IssueWrongIndex},
+		},
+		{
+			"extra tx",
+			func(idxer *indexer.KVIndexer) {
+				indexBlock(idxer, 0, 2)
+			},
+			1,
+			[]string{indexer.IssueExtraTx},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idxer := indexer.NewKVIndexer(dbm.NewMemDB(), log.NewNopLogger(), clientCtx)
+			tc.index(idxer)
+
+			block := &cmttypes.Block{Header: cmttypes.Header{Height: 1}, Data: cmttypes.Data{Txs: txs[:tc.blockTxs]}}
+			issues := indexer.VerifyBlock(idxer, clientCtx, log.NewNopLogger(), block, txResults[:tc.blockTxs])
+			var found []string
+			for _, issue := range issues {
+				found = append(found, issue.Issue)
+			}
+			require.Equal(t, tc.expIssues, found)
+		})
+	}
+}
@@ -32,7 +32,14 @@ func (bh *BalanceHandler) BeforeBalanceChange(ctx sdk.Context) {
 
 // AfterBalanceChange processes the recorded events and updates the stateDB accordingly.
 // It handles the bank events for coin spent and coin received, updating the balances
-// of the spender and receiver addresses respectively.
+// of the spender and receiver addresses respectively. Each balance change is
+// reported to the EVM tracer, if any.
+//
+// Only the bank events of the EVM coin denom are reflected: the moves of other
+// denoms, and the fractional amounts moved by x/precisebank on chains with
+// less than 18 decimals, don't emit balance-change hooks and are only visible
+// in the Cosmos SDK events of the call, e.g. the cosmosEvents of the
+// cosmosCallTracer.
 func (bh *BalanceHandler) AfterBalanceChange(ctx sdk.Context, stateDB *statedb.StateDB) error {
 	events := ctx.EventManager().Events()
 
@@ -49,7 +56,7 @@ func (bh *BalanceHandler) AfterBalanceChange(ctx sdk.Context, stateDB *statedb.S
 				return fmt.Errorf("failed to parse amount from event %q: %w", banktypes.EventTypeCoinSpent, err)
 			}
 
-			stateDB.SubBalance(spenderHexAddr, amount, tracing.BalanceChangeUnspecified)
+			SubBalance(stateDB, spenderHexAddr, amount, tracing.BalanceChangeUnspecified)
 
 		case banktypes.EventTypeCoinReceived:
 			receiverHexAddr, err := ParseHexAddress(event, banktypes.AttributeKeyReceiver)
@@ -62,7 +69,7 @@ func (bh *BalanceHandler) AfterBalanceChange(ctx sdk.Context, stateDB *statedb.S
 				return fmt.Errorf("failed to parse amount from event %q: %w", banktypes.EventTypeCoinReceived, err)
 			}
 
-			stateDB.AddBalance(receiverHexAddr, amount, tracing.BalanceChangeUnspecified)
+			AddBalance(stateDB, receiverHexAddr, amount, tracing.BalanceChangeUnspecified)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package common_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -171,6 +172,69 @@ func TestAfterBalanceChange(t *testing.T) {
 	require.Equal(t, "3", stateDB.GetBalance(receiver).String())
 }
 
+func TestAfterBalanceChangeTracing(t *testing.T) {
+	setupBalanceHandlerTest(t)
+
+	storeKey := storetypes.NewKVStoreKey("test")
+	tKey := storetypes.NewTransientStoreKey("test_t")
+	ctx := sdktestutil.DefaultContext(storeKey, tKey)
+
+	stateDB := statedb.New(ctx, mocks.NewEVMKeeper(), statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash())))
+
+	type balanceChange struct {
+		addr          common.Address
+		prev, current string
+	}
+	var changes []balanceChange
+	stateDB.SetTracer(&tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, prev, current *big.Int, _ tracing.BalanceChangeReason) {
+			changes = append(changes, balanceChange{addr, prev.String(), current.String()})
+		},
+	})
+
+	_, addrs, err := testutil.GeneratePrivKeyAddressPairs(2)
+	require.NoError(t, err)
+	spenderAcc := addrs[0]
+	receiverAcc := addrs[1]
+	spender := common.BytesToAddress(spenderAcc)
+	receiver := common.BytesToAddress(receiverAcc)
+
+	// initial balance for spender
+	stateDB.AddBalance(spender, uint256.NewInt(5), tracing.BalanceChangeUnspecified)
+
+	bh := cmn.NewBalanceHandler()
+	bh.BeforeBalanceChange(ctx)
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin(evmtypes.GetEVMCoinDenom(), 3))
+	ctx.EventManager().EmitEvents(sdk.Events{
+		banktypes.NewCoinSpentEvent(spenderAcc, coins),
+		banktypes.NewCoinReceivedEvent(receiverAcc, coins),
+	})
+
+	err = bh.AfterBalanceChange(ctx, stateDB)
+	require.NoError(t, err)
+
+	require.Equal(t, []balanceChange{
+		{spender, "5", "2"},
+		{receiver, "0", "3"},
+	}, changes)
+
+	// the moves of other denoms are only found in the events
+	changes = nil
+	bh.BeforeBalanceChange(ctx)
+	otherCoins := sdk.NewCoins(sdk.NewInt64Coin("other", 1))
+	ctx.EventManager().EmitEvents(sdk.Events{
+		banktypes.NewCoinSpentEvent(spenderAcc, otherCoins),
+		banktypes.NewCoinReceivedEvent(receiverAcc, otherCoins),
+	})
+
+	err = bh.AfterBalanceChange(ctx, stateDB)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, "2", stateDB.GetBalance(spender).String())
+	require.Equal(t, "3", stateDB.GetBalance(receiver).String())
+}
+
 func TestAfterBalanceChangeErrors(t *testing.T) {
 	setupBalanceHandlerTest(t)
 
@@ -95,6 +95,12 @@ func (p Precompile) RunSetup(
 		return sdk.Context{}, nil, nil, uint64(0), nil, err
 	}
 
+	// report the changes made by the call in the Cosmos SDK context to the
+	// EVM tracer, starting with the value transferred to the precompile
+	stateDB.SetTracer(evm.Config.Tracer)
+	stateDB.StartPrecompileTrace(p.Address())
+	traceValueTransfer(stateDB, contract, p.Address())
+
 	// NOTE: This is a special case where the calling transaction does not specify a function name.
 	// In this case we default to a `fallback` or `receive` function on the contract.
 
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AddBalance adds amount to the balance of the account on the stateDB and
+// reports the change to the EVM tracer. It is used by the precompiles to reflect
+// the balance changes made in the Cosmos SDK context, which the EVM can't see.
+func AddBalance(stateDB vm.StateDB, addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) {
+	prev := stateDB.AddBalance(addr, amount, reason)
+	traceBalanceChange(stateDB, addr, prev, reason)
+}
+
+// SubBalance subtracts amount from the balance of the account on the stateDB
+// and reports the change to the EVM tracer.
+func SubBalance(stateDB vm.StateDB, addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) {
+	prev := stateDB.SubBalance(addr, amount, reason)
+	traceBalanceChange(stateDB, addr, prev, reason)
+}
+
+// TraceMsg records the Cosmos SDK message executed by the precompile call, so
+// it can be attached to the call frame by the tracers.
+func TraceMsg(stateDB vm.StateDB, msg sdk.Msg) {
+	if stateDB, ok := stateDB.(*statedb.StateDB); ok {
+		stateDB.TraceMsg(msg)
+	}
+}
+
+// traceValueTransfer reports to the EVM tracer the value transferred by the
+// EVM to the precompile before the call, which is committed to the Cosmos SDK
+// context on the call setup.
+func traceValueTransfer(stateDB *statedb.StateDB, contract *vm.Contract, precompile common.Address) {
+	// no value is transferred on delegate calls
+	if contract.Address() != precompile {
+		return
+	}
+	value := contract.Value()
+	if value == nil || value.IsZero() {
+		return
+	}
+
+	caller := contract.Caller()
+	callerBalance := stateDB.GetBalance(caller)
+	traceBalanceChange(stateDB, caller, *new(uint256.Int).Add(callerBalance, value), tracing.BalanceChangeTransfer)
+
+	precompileBalance := stateDB.GetBalance(precompile)
+	traceBalanceChange(stateDB, precompile, *new(uint256.Int).Sub(precompileBalance, value), tracing.BalanceChangeTransfer)
+}
+
+// traceBalanceChange calls the OnBalanceChange hook of the EVM tracer, if any,
+// with the previous and current balance of the account.
+func traceBalanceChange(stateDB vm.StateDB, addr common.Address, prev uint256.Int, reason tracing.BalanceChangeReason) {
+	s, ok := stateDB.(*statedb.StateDB)
+	if !ok {
+		return
+	}
+	hooks := s.Tracer()
+	if hooks == nil || hooks.OnBalanceChange == nil {
+		return
+	}
+
+	balance := stateDB.GetBalance(addr)
+	if balance.Eq(&prev) {
+		return
+	}
+	hooks.OnBalanceChange(addr, prev.ToBig(), balance.ToBig(), reason)
+}
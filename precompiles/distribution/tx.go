@@ -108,6 +108,8 @@ func (p Precompile) SetWithdrawAddress(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitSetWithdrawAddressEvent(ctx, stateDB, delegatorHexAddr, msg.WithdrawAddress); err != nil {
 		return nil, err
 	}
@@ -139,6 +141,8 @@ func (p *Precompile) WithdrawDelegatorReward(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitWithdrawDelegatorRewardEvent(ctx, stateDB, delegatorHexAddr, msg.ValidatorAddress, res.Amount); err != nil {
 		return nil, err
 	}
@@ -170,6 +174,8 @@ func (p *Precompile) WithdrawValidatorCommission(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitWithdrawValidatorCommissionEvent(ctx, stateDB, msg.ValidatorAddress, res.Amount); err != nil {
 		return nil, err
 	}
@@ -201,6 +207,8 @@ func (p *Precompile) FundCommunityPool(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitFundCommunityPoolEvent(ctx, stateDB, depositorHexAddr, msg.Amount); err != nil {
 		return nil, err
 	}
@@ -233,6 +241,8 @@ func (p *Precompile) DepositValidatorRewardsPool(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitDepositValidatorRewardsPoolEvent(ctx, stateDB, depositorHexAddr, msg.ValidatorAddress, msg.Amount); err != nil {
 		return nil, err
 	}
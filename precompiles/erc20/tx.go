@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/vm"
 
+	cmn "github.com/cosmos/evm/precompiles/common"
 	"github.com/cosmos/evm/utils"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
@@ -124,6 +125,8 @@ func (p *Precompile) transfer(
 		return nil, ConvertErrToERC20Error(err)
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	// TODO: Properly handle native balance changes via the balance handler.
 	// Currently, decimal conversion issues exist with the precisebank module.
 	// As a temporary workaround, balances are adjusted directly using add/sub operations.
@@ -134,8 +137,8 @@ func (p *Precompile) transfer(
 			return nil, err
 		}
 
-		stateDB.SubBalance(from, convertedAmount, tracing.BalanceChangeUnspecified)
-		stateDB.AddBalance(to, convertedAmount, tracing.BalanceChangeUnspecified)
+		cmn.SubBalance(stateDB, from, convertedAmount, tracing.BalanceChangeUnspecified)
+		cmn.AddBalance(stateDB, to, convertedAmount, tracing.BalanceChangeUnspecified)
 	}
 
 	if err = p.EmitTransferEvent(ctx, stateDB, from, to, amount); err != nil {
@@ -37,6 +37,8 @@ func (p Precompile) SubmitEvidence(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitSubmitEvidenceEvent(ctx, stateDB, submitterHexAddr, res.Hash); err != nil {
 		return nil, err
 	}
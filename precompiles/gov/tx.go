@@ -48,6 +48,8 @@ func (p *Precompile) SubmitProposal(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitSubmitProposalEvent(ctx, stateDB, proposerHexAddr, res.ProposalId); err != nil {
 		return nil, err
 	}
@@ -77,6 +79,8 @@ func (p *Precompile) Deposit(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitDepositEvent(ctx, stateDB, depositorHexAddr, msg.ProposalId, msg.Amount); err != nil {
 		return nil, err
 	}
@@ -106,6 +110,8 @@ func (p *Precompile) CancelProposal(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitCancelProposalEvent(ctx, stateDB, proposerHexAddr, msg.ProposalId); err != nil {
 		return nil, err
 	}
@@ -136,6 +142,8 @@ func (p Precompile) Vote(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitVoteEvent(ctx, stateDB, voterHexAddr, msg.ProposalId, int32(msg.Option)); err != nil {
 		return nil, err
 	}
@@ -166,6 +174,8 @@ func (p Precompile) VoteWeighted(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitVoteWeightedEvent(ctx, stateDB, voterHexAddr, msg.ProposalId, options); err != nil {
 		return nil, err
 	}
@@ -124,6 +124,8 @@ func (p *Precompile) Transfer(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = EmitIBCTransferEvent(
 		ctx,
 		stateDB,
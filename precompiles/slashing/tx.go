@@ -52,6 +52,8 @@ func (p Precompile) Unjail(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err := p.EmitValidatorUnjailedEvent(ctx, stateDB, validatorAddress); err != nil {
 		return nil, err
 	}
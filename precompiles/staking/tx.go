@@ -74,6 +74,8 @@ func (p Precompile) CreateValidator(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	// Here we don't add journal entries here because calls from
 	// smart contracts are not supported at the moment for this method.
 
@@ -121,6 +123,8 @@ func (p Precompile) EditValidator(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	// Emit the event for the edit validator transaction
 	if err = p.EmitEditValidatorEvent(ctx, stateDB, msg, validatorHexAddr); err != nil {
 		return nil, err
@@ -168,6 +172,8 @@ func (p *Precompile) Delegate(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	// Emit the event for the delegate transaction
 	if err = p.EmitDelegateEvent(ctx, stateDB, msg, delegatorHexAddr); err != nil {
 		return nil, err
@@ -217,6 +223,8 @@ func (p Precompile) Undelegate(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	// Emit the event for the undelegate transaction
 	if err = p.EmitUnbondEvent(ctx, stateDB, msg, delegatorHexAddr, res.CompletionTime.UTC().Unix()); err != nil {
 		return nil, err
@@ -267,6 +275,8 @@ func (p Precompile) Redelegate(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitRedelegateEvent(ctx, stateDB, msg, delegatorHexAddr, res.CompletionTime.UTC().Unix()); err != nil {
 		return nil, err
 	}
@@ -315,6 +325,8 @@ func (p Precompile) CancelUnbondingDelegation(
 		return nil, err
 	}
 
+	cmn.TraceMsg(stateDB, msg)
+
 	if err = p.EmitCancelUnbondingDelegationEvent(ctx, stateDB, msg, delegatorHexAddr); err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/vm"
 
+	cmn "github.com/cosmos/evm/precompiles/common"
 	"github.com/cosmos/evm/x/precisebank/types"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
@@ -53,8 +54,8 @@ func (p Precompile) Deposit(
 	// TODO: Properly handle native balance changes via the balance handler.
 	// Currently, decimal conversion issues exist with the precisebank module.
 	// As a temporary workaround, balances are adjusted directly using add/sub operations.
-	stateDB.SubBalance(p.Address(), depositedAmount, tracing.BalanceChangeUnspecified)
-	stateDB.AddBalance(caller, depositedAmount, tracing.BalanceChangeUnspecified)
+	cmn.SubBalance(stateDB, p.Address(), depositedAmount, tracing.BalanceChangeUnspecified)
+	cmn.AddBalance(stateDB, caller, depositedAmount, tracing.BalanceChangeUnspecified)
 
 	if err := p.EmitDepositEvent(ctx, stateDB, caller, depositedAmount.ToBig()); err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cometbft/cometbft/libs/bytes"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 
 	rpctypes "github.com/cosmos/evm/rpc/types"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
@@ -19,6 +20,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
@@ -275,3 +277,61 @@ func (b *Backend) GetTransactionCount(address common.Address, blockNum rpctypes.
 	n = hexutil.Uint64(nonce)
 	return &n, nil
 }
+
+// StorageRangeAt returns the storage of the contract from the start key, on
+// top of the state after the first txIndex Ethereum transactions of the block.
+func (b *Backend) StorageRangeAt(
+	address common.Address,
+	startKey []byte,
+	maxResults int,
+	txIndex int,
+	block *tmrpctypes.ResultBlock,
+) (*evmtypes.QueryStorageRangeResponse, error) {
+	if maxResults < 0 {
+		return nil, fmt.Errorf("max results cannot be negative, got %d", maxResults)
+	}
+
+	ctxWithHeight, replay, err := b.traceBlockRequest(rpctypes.BlockNumber(block.Block.Height), nil, block)
+	if err != nil {
+		return nil, err
+	}
+
+	if txIndex < 0 || txIndex > len(replay.Txs) {
+		return nil, fmt.Errorf("transaction index %d out of range, block %d has %d transactions", txIndex, block.Block.Height, len(replay.Txs))
+	}
+	replay.Txs = replay.Txs[:txIndex]
+
+	req := &evmtypes.QueryStorageRangeRequest{
+		Address:    address.String(),
+		Pagination: &query.PageRequest{Key: startKey, Limit: uint64(maxResults)},
+		Replay:     replay,
+	}
+
+	return b.queryClient.StorageRange(ctxWithHeight, req)
+}
+
+// AccountRange returns the Ethereum accounts from the start address, at the
+// given block number.
+func (b *Backend) AccountRange(
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+	start []byte,
+	maxResults int,
+	noCode, noStorage bool,
+) (*evmtypes.QueryAccountRangeResponse, error) {
+	if maxResults < 0 {
+		return nil, fmt.Errorf("max results cannot be negative, got %d", maxResults)
+	}
+
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &evmtypes.QueryAccountRangeRequest{
+		Pagination: &query.PageRequest{Key: start, Limit: uint64(maxResults)},
+		NoCode:     noCode,
+		NoStorage:  noStorage,
+	}
+
+	return b.queryClient.AccountRange(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+}
@@ -10,12 +10,16 @@ import (
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtrpcclient "github.com/cometbft/cometbft/rpc/client"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
 
 	"github.com/cosmos/evm/rpc/backend/mocks"
 	rpctypes "github.com/cosmos/evm/rpc/types"
+	"github.com/cosmos/evm/server/config"
 	utiltx "github.com/cosmos/evm/testutil/tx"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
@@ -482,3 +486,68 @@ func (suite *BackendTestSuite) TestGetTransactionCount() {
 		})
 	}
 }
+
+func (suite *BackendTestSuite) TestStorageRangeAt() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	block := types.MakeBlock(1, []types.Tx{bz}, nil, nil)
+	block.ChainID = ChainID.ChainID
+	resBlock := &tmrpctypes.ResultBlock{Block: block, BlockID: block.LastBlockID}
+	addr := utiltx.GenerateAddress()
+	storage := &evmtypes.QueryStorageRangeResponse{
+		Storage: evmtypes.Storage{evmtypes.NewState(common.HexToHash("0x01"), common.HexToHash("0x02"))},
+	}
+
+	testCases := []struct {
+		name         string
+		txIndex      int
+		registerMock func()
+		expPass      bool
+	}{
+		{
+			"fail - transaction index out of range",
+			2,
+			func() {},
+			false,
+		},
+		{
+			"pass - storage before the first transaction",
+			0,
+			func() {
+				RegisterStorageRange(suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient), &evmtypes.QueryStorageRangeRequest{
+					Address:    addr.String(),
+					Pagination: &query.PageRequest{Limit: 10},
+					Replay:     &evmtypes.QueryTraceBlockRequest{Txs: []*evmtypes.MsgEthereumTx{}, BlockNumber: 1, ChainId: config.DefaultEVMChainID, BlockMaxGas: -1},
+				}, storage)
+			},
+			true,
+		},
+		{
+			"pass - storage after the transaction",
+			1,
+			func() {
+				RegisterStorageRange(suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient), &evmtypes.QueryStorageRangeRequest{
+					Address:    addr.String(),
+					Pagination: &query.PageRequest{Limit: 10},
+					Replay:     &evmtypes.QueryTraceBlockRequest{Txs: []*evmtypes.MsgEthereumTx{msgEthTx}, BlockNumber: 1, ChainId: config.DefaultEVMChainID, BlockMaxGas: -1},
+				}, storage)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			RegisterConsensusParams(suite.backend.clientCtx.Client.(*mocks.Client), 1)
+			tc.registerMock()
+
+			res, err := suite.backend.StorageRangeAt(addr, nil, 10, tc.txIndex, resBlock)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(storage, res)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+)
+
+// GetTransactionHashesByAddress returns the hashes of the Ethereum transactions
+// sent from or to the given address, or deploying a contract at it, collected
+// block by block from the given block. The returned flag reports whether more
+// transactions are left. It requires the custom EVM tx indexer.
+func (b *Backend) GetTransactionHashesByAddress(address common.Address, fromBlock int64, reverse bool, pageSize int) ([]common.Hash, bool, error) {
+	indexer, err := b.addressIndexer()
+	if err != nil {
+		return nil, false, err
+	}
+	return indexer.GetByAddress(address, fromBlock, reverse, pageSize)
+}
+
+// GetTransactionHashBySenderAndNonce returns the hash of the Ethereum
+// transaction sent by the given address with the given nonce, or nil if not
+// found. It requires the custom EVM tx indexer.
+func (b *Backend) GetTransactionHashBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error) {
+	indexer, err := b.addressIndexer()
+	if err != nil {
+		return nil, err
+	}
+	return indexer.GetBySenderAndNonce(sender, nonce)
+}
+
+// GetContractCreationTxHash returns the hash of the Ethereum transaction that
+// deployed the given contract, or nil if the contract wasn't deployed by a
+// transaction. It requires the custom EVM tx indexer.
+func (b *Backend) GetContractCreationTxHash(contract common.Address) (*common.Hash, error) {
+	indexer, err := b.addressIndexer()
+	if err != nil {
+		return nil, err
+	}
+	return indexer.GetContractCreationTx(contract)
+}
+
+// addressIndexer returns the custom EVM tx indexer if it indexes the
+// transactions by address.
+func (b *Backend) addressIndexer() (cosmosevmtypes.EVMAddressIndexer, error) {
+	indexer, ok := b.indexer.(cosmosevmtypes.EVMAddressIndexer)
+	if !ok {
+		return nil, rpctypes.ErrAddressIndexDisabled
+	}
+	return indexer, nil
+}
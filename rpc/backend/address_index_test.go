@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/indexer"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+)
+
+func (suite *BackendTestSuite) TestAddressIndex() {
+	msgEthereumTx := suite.buildEthereumTxWithChainID(suite.backend.ChainConfig().ChainID)
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+	tx := msgEthereumTx.AsTransaction()
+	from, err := msgEthereumTx.GetSender(tx.ChainId())
+	suite.Require().NoError(err)
+
+	block := &types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	txResults := []*abci.ExecTxResult{
+		{
+			Code: 0,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "txGasUsed", Value: "21000"},
+				}},
+			},
+		},
+	}
+
+	suite.Run("fail - indexer disabled", func() {
+		suite.SetupTest() // reset
+		suite.backend.indexer = nil
+
+		_, _, err := suite.backend.GetTransactionHashesByAddress(from, -1, true, 10)
+		suite.Require().ErrorIs(err, rpctypes.ErrAddressIndexDisabled)
+		_, err = suite.backend.GetTransactionHashBySenderAndNonce(from, 0)
+		suite.Require().ErrorIs(err, rpctypes.ErrAddressIndexDisabled)
+		_, err = suite.backend.GetContractCreationTxHash(from)
+		suite.Require().ErrorIs(err, rpctypes.ErrAddressIndexDisabled)
+	})
+
+	suite.Run("pass", func() {
+		suite.SetupTest() // reset
+		db := dbm.NewMemDB()
+		suite.backend.indexer = indexer.NewKVIndexer(db, log.NewNopLogger(), suite.backend.clientCtx)
+		err := suite.backend.indexer.IndexBlock(block, txResults)
+		suite.Require().NoError(err)
+
+		hashes, hasMore, err := suite.backend.GetTransactionHashesByAddress(*tx.To(), -1, true, 10)
+		suite.Require().NoError(err)
+		suite.Require().Equal([]common.Hash{txHash}, hashes)
+		suite.Require().False(hasMore)
+
+		hash, err := suite.backend.GetTransactionHashBySenderAndNonce(from, tx.Nonce())
+		suite.Require().NoError(err)
+		suite.Require().Equal(&txHash, hash)
+
+		hash, err = suite.backend.GetContractCreationTxHash(*tx.To())
+		suite.Require().NoError(err)
+		suite.Require().Nil(hash)
+	})
+}
@@ -52,8 +52,10 @@ type EVMBackend interface {
 	UnprotectedAllowed() bool
 	RPCGasCap() uint64            // global gas cap for eth_call over rpc: DoS protection
 	RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
+	RPCTraceFileDir() string      // directory of the trace files written by the debug namespace
 	RPCTxFeeCap() float64         // RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for send-transaction variants. The unit is ether.
 	RPCMinGasPrice() *big.Int
+	RPCBlockRangeCap() int32
 
 	// Sign Tx
 	Sign(address common.Address, data hexutil.Bytes) (hexutil.Bytes, error)
@@ -93,6 +95,8 @@ type EVMBackend interface {
 	BaseFee(blockRes *tmrpctypes.ResultBlockResults) (*big.Int, error)
 	CurrentHeader() (*ethtypes.Header, error)
 	PendingTransactions() ([]*sdk.Tx, error)
+	TxPoolContent() (pending, queued map[common.Address][]*rpctypes.RPCTransaction, err error)
+	TxPoolStatus() (pending, queued uint64, err error)
 	GetCoinbase() (sdk.AccAddress, error)
 	FeeHistory(blockCount, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
 	SuggestGasTipCap(baseFee *big.Int) (*big.Int, error)
@@ -103,26 +107,47 @@ type EVMBackend interface {
 	GetTxByTxIndex(height int64, txIndex uint) (*cosmosevmtypes.TxResult, error)
 	GetTransactionByBlockAndIndex(block *tmrpctypes.ResultBlock, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
 	GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error)
+	GetReceiptProof(hash common.Hash) (*rpctypes.ReceiptProofResult, error)
 	GetTransactionLogs(hash common.Hash) ([]*ethtypes.Log, error)
 	GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
 	GetTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
+	GetTransactionHashesByAddress(address common.Address, fromBlock int64, reverse bool, pageSize int) ([]common.Hash, bool, error)
+	GetTransactionHashBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error)
+	GetContractCreationTxHash(contract common.Address) (*common.Hash, error)
+
+	// Raw Data
+	GetRawTransactionByHash(hash common.Hash) (hexutil.Bytes, error)
+	GetRawTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (hexutil.Bytes, error)
+	GetRawTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (hexutil.Bytes, error)
+	GetRawReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]hexutil.Bytes, error)
+	GetRawBlock(blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error)
 
 	// Send Transaction
 	Resend(args evmtypes.TransactionArgs, gasPrice *hexutil.Big, gasLimit *hexutil.Uint64) (common.Hash, error)
 	SendRawTransaction(data hexutil.Bytes) (common.Hash, error)
 	SetTxDefaults(args evmtypes.TransactionArgs) (evmtypes.TransactionArgs, error)
-	EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber) (hexutil.Uint64, error)
-	DoCall(args evmtypes.TransactionArgs, blockNr rpctypes.BlockNumber) (*evmtypes.MsgEthereumTxResponse, error)
+	EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber, overrides *rpctypes.StateOverride) (hexutil.Uint64, error)
+	DoCall(args evmtypes.TransactionArgs, blockNr rpctypes.BlockNumber, overrides *rpctypes.StateOverride, blockOverrides *rpctypes.BlockOverrides) (*evmtypes.MsgEthereumTxResponse, error)
+	CreateAccessList(args evmtypes.TransactionArgs, blockNr rpctypes.BlockNumber, overrides *rpctypes.StateOverride) (*rpctypes.AccessListResult, error)
+	SimulateV1(opts evmtypes.SimOpts, blockNr rpctypes.BlockNumber) ([]map[string]interface{}, error)
 	GasPrice() (*hexutil.Big, error)
 
 	// Filter API
 	GetLogs(hash common.Hash) ([][]*ethtypes.Log, error)
 	GetLogsByHeight(height *int64) ([][]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
+	LogIndexRange() (int64, int64, error)
+	GetLogLocations(addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock int64) ([]cosmosevmtypes.LogLocation, error)
 
 	// Tracing
 	TraceTransaction(hash common.Hash, config *evmtypes.TraceConfig) (interface{}, error)
 	TraceBlock(height rpctypes.BlockNumber, config *evmtypes.TraceConfig, block *tmrpctypes.ResultBlock) ([]*evmtypes.TxTraceResult, error)
+	TraceBlockStream(height rpctypes.BlockNumber, config *evmtypes.TraceConfig, block *tmrpctypes.ResultBlock, fn func(*evmtypes.QueryTraceBlockStreamResponse) error) error
+	IntermediateRoots(height rpctypes.BlockNumber, block *tmrpctypes.ResultBlock) (*evmtypes.QueryIntermediateRootsResponse, error)
+	StorageRangeAt(address common.Address, startKey []byte, maxResults int, txIndex int, block *tmrpctypes.ResultBlock) (*evmtypes.QueryStorageRangeResponse, error)
+	AccountRange(blockNrOrHash rpctypes.BlockNumberOrHash, start []byte, maxResults int, noCode, noStorage bool) (*evmtypes.QueryAccountRangeResponse, error)
+	Preimage(hash common.Hash) (hexutil.Bytes, error)
+	TraceCall(args evmtypes.TransactionArgs, blockNr rpctypes.BlockNumber, config *evmtypes.TraceConfig, overrides *rpctypes.StateOverride, blockOverrides *rpctypes.BlockOverrides) (interface{}, error)
 }
 
 var _ BackendI = (*Backend)(nil)
@@ -141,6 +166,7 @@ type Backend struct {
 	cfg                 config.Config
 	allowUnprotectedTxs bool
 	indexer             cosmosevmtypes.EVMTxIndexer
+	gpo                 *gasPriceOracle
 	bankKeeper          cmn.BankKeeper // ELYS MODIFICATION: Add bank keeper for balance queries
 	baseDenom           string         // ELYS MODIFICATION: Add base denomination for balance queries
 	queryCtxFactory     QueryContextFactory // ELYS MODIFICATION: Factory for creating query contexts
@@ -182,6 +208,7 @@ func NewBackend(
 		cfg:                 appConf,
 		allowUnprotectedTxs: allowUnprotectedTxs,
 		indexer:             indexer,
+		gpo:                 newGasPriceOracle(),
 		bankKeeper:          bankKeeper,        // ELYS MODIFICATION: Store bank keeper
 		baseDenom:           baseDenom,         // ELYS MODIFICATION: Store base denomination
 		queryCtxFactory:     queryCtxFactory,  // ELYS MODIFICATION: Store query context factory
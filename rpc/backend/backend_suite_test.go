@@ -118,6 +118,9 @@ func (suite *BackendTestSuite) SetupTest() {
 	suite.backend.queryClient.FeeMarket = mocks.NewFeeMarketQueryClient(suite.T())
 	suite.backend.ctx = rpctypes.ContextWithHeight(1)
 
+	// the block roots are only read from the store once the events are lost
+	RegisterBlockRootsNotStored(suite.backend.clientCtx.Client.(*mocks.Client))
+
 	// Add codec
 	suite.backend.clientCtx.Codec = encodingConfig.Codec
 }
@@ -212,6 +215,7 @@ func (suite *BackendTestSuite) buildFormattedBlock(
 		bloom,
 		common.BytesToAddress(validator.Bytes()),
 		baseFee,
+		nil,
 	)
 }
 
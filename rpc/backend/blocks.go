@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	tmrpcclient "github.com/cometbft/cometbft/rpc/client"
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 
 	rpctypes "github.com/cosmos/evm/rpc/types"
@@ -301,7 +302,7 @@ func (b *Backend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Heade
 		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", resBlock.Block.Height, "error", err)
 	}
 
-	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee)
+	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header, bloom, baseFee, b.blockRoots(resBlock.Block.Height, blockRes))
 	return ethHeader, nil
 }
 
@@ -334,7 +335,7 @@ func (b *Backend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
 		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", height, "error", err)
 	}
 
-	ethHeader := rpctypes.EthHeaderFromTendermint(*resHeader.Header, bloom, baseFee)
+	ethHeader := rpctypes.EthHeaderFromTendermint(*resHeader.Header, bloom, baseFee, b.blockRoots(height, blockRes))
 	return ethHeader, nil
 }
 
@@ -354,6 +355,32 @@ func (b *Backend) BlockBloom(blockRes *tmrpctypes.ResultBlockResults) (ethtypes.
 	return ethtypes.Bloom{}, errors.New("block bloom event is not found")
 }
 
+// blockRootsStorePath is the ABCI query path of the keys of the EVM module store
+const blockRootsStorePath = "/store/" + evmtypes.StoreKey + "/key"
+
+// blockRoots returns the Ethereum transactions and receipts roots of the block
+// at the given height from its finalize block events. Once these events are
+// pruned or discarded, the roots stored by the EVM module for the recent blocks
+// are read instead. It returns nil if the block has no roots.
+func (b *Backend) blockRoots(height int64, blockRes *tmrpctypes.ResultBlockResults) *rpctypes.BlockRoots {
+	if roots := rpctypes.BlockRootsFromEvents(blockRes.FinalizeBlockEvents); roots != nil {
+		return roots
+	}
+	// the block bloom event is emitted for every block, its roots event only
+	// for the blocks holding Ethereum transactions
+	if _, err := b.BlockBloom(blockRes); err == nil {
+		return nil
+	}
+
+	key := evmtypes.BlockRootsKey(uint64(height)) //#nosec G115 -- block heights are positive
+	res, err := b.clientCtx.Client.ABCIQueryWithOptions(b.ctx, blockRootsStorePath, key, tmrpcclient.ABCIQueryOptions{})
+	if err != nil {
+		b.logger.Debug("failed to query the stored block roots", "height", height, "error", err.Error())
+		return nil
+	}
+	return rpctypes.BlockRootsFromBytes(res.Response.Value)
+}
+
 // RPCBlockFromTendermintBlock returns a JSON-RPC compatible Ethereum block from a
 // given Tendermint block and its block result.
 func (b *Backend) RPCBlockFromTendermintBlock(
@@ -447,6 +474,7 @@ func (b *Backend) RPCBlockFromTendermintBlock(
 		block.Header, block.Size(),
 		gasLimit, new(big.Int).SetUint64(gasUsed),
 		ethRPCTxs, bloom, validatorAddr, baseFee,
+		b.blockRoots(block.Height, blockRes),
 	)
 	return formattedBlock, nil
 }
@@ -490,7 +518,8 @@ func (b *Backend) EthBlockFromTendermintBlock(
 		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", height, "error", err)
 	}
 
-	ethHeader := rpctypes.EthHeaderFromTendermint(block.Header, bloom, baseFee)
+	roots := b.blockRoots(height, blockRes)
+	ethHeader := rpctypes.EthHeaderFromTendermint(block.Header, bloom, baseFee, roots)
 	msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
 
 	txs := make([]*ethtypes.Transaction, len(msgs))
@@ -498,6 +527,11 @@ func (b *Backend) EthBlockFromTendermintBlock(
 		txs[i] = ethMsg.AsTransaction()
 	}
 
+	if roots != nil {
+		// keep the roots derived by the EVM module
+		return ethtypes.NewBlockWithHeader(ethHeader).WithBody(ethtypes.Body{Transactions: txs}), nil
+	}
+
 	// TODO: add tx receipts
 	ethBlock := ethtypes.NewBlock(
 		ethHeader,
@@ -889,6 +889,75 @@ func (suite *BackendTestSuite) TestBlockBloom() {
 	}
 }
 
+func (suite *BackendTestSuite) TestBlockRoots() {
+	txRoot := common.HexToHash("0x01")
+	receiptsRoot := common.HexToHash("0x02")
+	expRoots := &ethrpc.BlockRoots{TxRoot: txRoot, ReceiptsRoot: receiptsRoot}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		blockRes     *cmtrpctypes.ResultBlockResults
+		expRoots     *ethrpc.BlockRoots
+	}{
+		{
+			"pass - roots from the block roots event",
+			func() {},
+			&cmtrpctypes.ResultBlockResults{
+				FinalizeBlockEvents: []types.Event{
+					{
+						Type: evmtypes.EventTypeBlockRoots,
+						Attributes: []types.EventAttribute{
+							{Key: evmtypes.AttributeKeyTxRoot, Value: txRoot.Hex()},
+							{Key: evmtypes.AttributeKeyReceiptsRoot, Value: receiptsRoot.Hex()},
+						},
+					},
+				},
+			},
+			expRoots,
+		},
+		{
+			"pass - block without Ethereum transactions",
+			func() {},
+			&cmtrpctypes.ResultBlockResults{
+				FinalizeBlockEvents: []types.Event{
+					{
+						Type: evmtypes.EventTypeBlockBloom,
+						Attributes: []types.EventAttribute{
+							{Key: evmtypes.AttributeKeyEthereumBloom},
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"pass - events lost, roots read from the store",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterStoredBlockRoots(client, txRoot, receiptsRoot)
+			},
+			&cmtrpctypes.ResultBlockResults{Height: 1},
+			expRoots,
+		},
+		{
+			"pass - events lost, roots not stored",
+			func() {},
+			&cmtrpctypes.ResultBlockResults{Height: 1},
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			roots := suite.backend.blockRoots(1, tc.blockRes)
+			suite.Require().Equal(tc.expRoots, roots)
+		})
+	}
+}
+
 func (suite *BackendTestSuite) TestGetEthBlockFromTendermint() {
 	msgEthereumTx, bz := suite.buildEthereumTx()
 	emptyBlock := cmttypes.MakeBlock(1, []cmttypes.Tx{}, nil, nil)
@@ -1122,6 +1191,7 @@ func (suite *BackendTestSuite) TestGetEthBlockFromTendermint() {
 				bloom,
 				common.BytesToAddress(tc.validator.Bytes()),
 				tc.baseFee,
+				nil,
 			)
 
 			if tc.expPass {
@@ -1300,7 +1370,7 @@ func (suite *BackendTestSuite) TestHeaderByNumber() {
 			header, err := suite.backend.HeaderByNumber(tc.blockNumber)
 
 			if tc.expPass {
-				expHeader := ethrpc.EthHeaderFromTendermint(expResultBlock.Block.Header, ethtypes.Bloom{}, tc.baseFee)
+				expHeader := ethrpc.EthHeaderFromTendermint(expResultBlock.Block.Header, ethtypes.Bloom{}, tc.baseFee, nil)
 				suite.Require().NoError(err)
 				suite.Require().Equal(expHeader, header)
 			} else {
@@ -1411,7 +1481,7 @@ func (suite *BackendTestSuite) TestHeaderByHash() {
 			header, err := suite.backend.HeaderByHash(tc.hash)
 
 			if tc.expPass {
-				expHeader := ethrpc.EthHeaderFromTendermint(*expResultHeader.Header, ethtypes.Bloom{}, tc.baseFee)
+				expHeader := ethrpc.EthHeaderFromTendermint(*expResultHeader.Header, ethtypes.Bloom{}, tc.baseFee, nil)
 				suite.Require().NoError(err)
 				suite.Require().Equal(expHeader, header)
 			} else {
@@ -1475,6 +1545,7 @@ func (suite *BackendTestSuite) TestEthBlockByNumber() {
 					emptyBlock.Header,
 					ethtypes.Bloom{},
 					math.NewInt(1).BigInt(),
+					nil,
 				),
 				&ethtypes.Body{},
 				nil,
@@ -1501,6 +1572,7 @@ func (suite *BackendTestSuite) TestEthBlockByNumber() {
 					emptyBlock.Header,
 					ethtypes.Bloom{},
 					math.NewInt(1).BigInt(),
+					nil,
 				),
 				&ethtypes.Body{
 					Transactions: []*ethtypes.Transaction{msgEthereumTx.AsTransaction()},
@@ -1566,6 +1638,7 @@ func (suite *BackendTestSuite) TestEthBlockFromTendermintBlock() {
 					emptyBlock.Header,
 					ethtypes.Bloom{},
 					math.NewInt(1).BigInt(),
+					nil,
 				),
 				&ethtypes.Body{},
 				nil,
@@ -1600,6 +1673,7 @@ func (suite *BackendTestSuite) TestEthBlockFromTendermintBlock() {
 					emptyBlock.Header,
 					ethtypes.Bloom{},
 					math.NewInt(1).BigInt(),
+					nil,
 				),
 				&ethtypes.Body{Transactions: []*ethtypes.Transaction{msgEthereumTx.AsTransaction()}},
 				nil,
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethparams "github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"google.golang.org/grpc/metadata"
 
@@ -19,6 +20,7 @@ import (
 
 	"cosmossdk.io/math"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -91,14 +93,15 @@ func (suite *BackendTestSuite) TestResend() {
 				var header metadata.MD
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
 				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
-				feeMarketClient := suite.backend.queryClient.FeeMarket.(*mocks.FeeMarketQueryClient)
 				RegisterParams(queryClient, &header, 1)
-				RegisterFeeMarketParams(feeMarketClient, 1)
 				_, err := RegisterBlock(client, 1, nil)
 				suite.Require().NoError(err)
 				_, err = RegisterBlockResults(client, 1)
 				suite.Require().NoError(err)
 				RegisterBaseFee(queryClient, baseFee)
+				// the fee history seeding the suggested tip for the empty block
+				RegisterValidatorAccount(queryClient, sdk.AccAddress(utiltx.GenerateAddress().Bytes()))
+				RegisterConsensusParams(client, 1)
 			},
 			evmtypes.TransactionArgs{
 				Nonce: &txNonce,
@@ -429,13 +432,27 @@ func (suite *BackendTestSuite) TestDoCall() {
 	argsBz, err := json.Marshal(callArgs)
 	suite.Require().NoError(err)
 
+	nonce := hexutil.Uint64(7)
+	overrides := rpctypes.StateOverride{
+		toAddr: rpctypes.OverrideAccount{Nonce: &nonce},
+	}
+	overridesBz, err := json.Marshal(overrides)
+	suite.Require().NoError(err)
+
+	blockTime := hexutil.Uint64(1_000_000_000)
+	blockOverrides := rpctypes.BlockOverrides{Time: &blockTime}
+	blockOverridesBz, err := json.Marshal(blockOverrides)
+	suite.Require().NoError(err)
+
 	testCases := []struct {
-		name         string
-		registerMock func()
-		blockNum     rpctypes.BlockNumber
-		callArgs     evmtypes.TransactionArgs
-		expEthTx     *evmtypes.MsgEthereumTxResponse
-		expPass      bool
+		name           string
+		registerMock   func()
+		blockNum       rpctypes.BlockNumber
+		callArgs       evmtypes.TransactionArgs
+		overrides      *rpctypes.StateOverride
+		blockOverrides *rpctypes.BlockOverrides
+		expEthTx       *evmtypes.MsgEthereumTxResponse
+		expPass        bool
 	}{
 		{
 			"fail - Invalid request",
@@ -448,6 +465,8 @@ func (suite *BackendTestSuite) TestDoCall() {
 			},
 			rpctypes.BlockNumber(1),
 			callArgs,
+			nil,
+			nil,
 			&evmtypes.MsgEthereumTxResponse{},
 			false,
 		},
@@ -462,6 +481,40 @@ func (suite *BackendTestSuite) TestDoCall() {
 			},
 			rpctypes.BlockNumber(1),
 			callArgs,
+			nil,
+			nil,
+			&evmtypes.MsgEthereumTxResponse{},
+			true,
+		},
+		{
+			"pass - Returned transaction response with state overrides",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				_, err := RegisterBlock(client, 1, bz)
+				suite.Require().NoError(err)
+				RegisterEthCall(queryClient, &evmtypes.EthCallRequest{Args: argsBz, ChainId: suite.backend.chainID.Int64(), Overrides: overridesBz})
+			},
+			rpctypes.BlockNumber(1),
+			callArgs,
+			&overrides,
+			nil,
+			&evmtypes.MsgEthereumTxResponse{},
+			true,
+		},
+		{
+			"pass - Returned transaction response with block overrides",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				_, err := RegisterBlock(client, 1, bz)
+				suite.Require().NoError(err)
+				RegisterEthCall(queryClient, &evmtypes.EthCallRequest{Args: argsBz, ChainId: suite.backend.chainID.Int64(), BlockOverrides: blockOverridesBz})
+			},
+			rpctypes.BlockNumber(1),
+			callArgs,
+			nil,
+			&blockOverrides,
 			&evmtypes.MsgEthereumTxResponse{},
 			true,
 		},
@@ -472,7 +525,7 @@ func (suite *BackendTestSuite) TestDoCall() {
 			suite.SetupTest() // reset test and queries
 			tc.registerMock()
 
-			msgEthTx, err := suite.backend.DoCall(tc.callArgs, tc.blockNum)
+			msgEthTx, err := suite.backend.DoCall(tc.callArgs, tc.blockNum, tc.overrides, tc.blockOverrides)
 
 			if tc.expPass {
 				suite.Require().Equal(tc.expEthTx, msgEthTx)
@@ -498,8 +551,6 @@ func (suite *BackendTestSuite) TestGasPrice() {
 				var header metadata.MD
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
 				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
-				feeMarketClient := suite.backend.queryClient.FeeMarket.(*mocks.FeeMarketQueryClient)
-				RegisterFeeMarketParams(feeMarketClient, 1)
 				RegisterParams(queryClient, &header, 1)
 				RegisterGlobalMinGasPrice(queryClient, 1)
 				_, err := RegisterBlock(client, 1, nil)
@@ -507,25 +558,39 @@ func (suite *BackendTestSuite) TestGasPrice() {
 				_, err = RegisterBlockResults(client, 1)
 				suite.Require().NoError(err)
 				RegisterBaseFee(queryClient, math.NewInt(1))
+				// the fee history seeding the suggested tip for the empty block
+				RegisterValidatorAccount(queryClient, sdk.AccAddress(utiltx.GenerateAddress().Bytes()))
+				RegisterConsensusParams(client, 1)
 			},
 			defaultGasPrice,
 			true,
 		},
 		{
-			"fail - can't get gasFee, FeeMarketParams error",
+			"pass - suggested tip is added to the base fee",
 			func() {
 				var header metadata.MD
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
 				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
-				feeMarketClient := suite.backend.queryClient.FeeMarket.(*mocks.FeeMarketQueryClient)
-				RegisterFeeMarketParamsError(feeMarketClient, 1)
 				RegisterParams(queryClient, &header, 1)
-				_, err := RegisterBlock(client, 1, nil)
+				RegisterGlobalMinGasPrice(queryClient, 1)
+				_, err := RegisterBlock(client, 1, suite.buildEthereumTxWithTip(big.NewInt(1), big.NewInt(1000)))
 				suite.Require().NoError(err)
 				_, err = RegisterBlockResults(client, 1)
 				suite.Require().NoError(err)
 				RegisterBaseFee(queryClient, math.NewInt(1))
 			},
+			(*hexutil.Big)(big.NewInt(1001)),
+			true,
+		},
+		{
+			"fail - can't get the latest block",
+			func() {
+				var header metadata.MD
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterBlockError(client, 1)
+			},
 			defaultGasPrice,
 			false,
 		},
@@ -545,3 +610,168 @@ func (suite *BackendTestSuite) TestGasPrice() {
 		})
 	}
 }
+
+func (suite *BackendTestSuite) TestCreateAccessList() {
+	_, bz := suite.buildEthereumTx()
+	from := utiltx.GenerateAddress()
+	toAddr := utiltx.GenerateAddress()
+	accessed := utiltx.GenerateAddress()
+	args := evmtypes.TransactionArgs{From: &from, To: &toAddr}
+	argsBz, err := json.Marshal(args)
+	suite.Require().NoError(err)
+
+	accessList := ethtypes.AccessList{{Address: accessed, StorageKeys: []common.Hash{{1}}}}
+	res := &evmtypes.QueryCreateAccessListResponse{
+		AccessList: evmtypes.NewAccessList(&accessList),
+		GasUsed:    25_000,
+		VmError:    "execution reverted",
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+		expResult    *rpctypes.AccessListResult
+	}{
+		{
+			"fail - query error",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterCreateAccessListError(queryClient, &evmtypes.EthCallRequest{Args: argsBz, ChainId: suite.backend.chainID.Int64()})
+			},
+			false,
+			nil,
+		},
+		{
+			"pass",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterCreateAccessList(queryClient, &evmtypes.EthCallRequest{Args: argsBz, ChainId: suite.backend.chainID.Int64()}, res)
+			},
+			true,
+			&rpctypes.AccessListResult{AccessList: &accessList, Error: res.VmError, GasUsed: hexutil.Uint64(res.GasUsed)},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			client := suite.backend.clientCtx.Client.(*mocks.Client)
+			_, err := RegisterBlock(client, 1, bz)
+			suite.Require().NoError(err)
+			tc.registerMock()
+
+			result, err := suite.backend.CreateAccessList(args, rpctypes.BlockNumber(1), nil)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expResult, result)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestSimulateV1() {
+	_, bz := suite.buildEthereumTx()
+	from := utiltx.GenerateAddress()
+	toAddr := utiltx.GenerateAddress()
+
+	tx := ethtypes.NewTx(&ethtypes.LegacyTx{To: &toAddr, Gas: ethparams.TxGas})
+	txBz, err := tx.MarshalBinary()
+	suite.Require().NoError(err)
+	header := &ethtypes.Header{
+		Number:     big.NewInt(2),
+		Difficulty: big.NewInt(0),
+		GasLimit:   100_000,
+		GasUsed:    ethparams.TxGas,
+		BaseFee:    big.NewInt(0),
+	}
+	calls := []evmtypes.SimCallResult{
+		{ReturnValue: hexutil.Bytes{}, Logs: []*ethtypes.Log{}, GasUsed: hexutil.Uint64(ethparams.TxGas), Status: hexutil.Uint64(ethtypes.ReceiptStatusSuccessful)},
+	}
+	data, err := json.Marshal([]evmtypes.SimBlockResult{
+		{
+			Header:       header,
+			Transactions: []evmtypes.SimTransaction{{From: from, Tx: txBz}},
+			Calls:        calls,
+		},
+	})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name         string
+		registerMock func(req *evmtypes.QuerySimulateV1Request)
+		fullTx       bool
+		expPass      bool
+	}{
+		{
+			"fail - query error",
+			func(req *evmtypes.QuerySimulateV1Request) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterSimulateV1Error(queryClient, req)
+			},
+			false,
+			false,
+		},
+		{
+			"pass - transaction hashes",
+			func(req *evmtypes.QuerySimulateV1Request) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterSimulateV1(queryClient, req, data)
+			},
+			false,
+			true,
+		},
+		{
+			"pass - full transactions",
+			func(req *evmtypes.QuerySimulateV1Request) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterSimulateV1(queryClient, req, data)
+			},
+			true,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+
+			opts := evmtypes.SimOpts{
+				BlockStateCalls: []evmtypes.SimBlock{
+					{Calls: []evmtypes.TransactionArgs{{From: &from, To: &toAddr}}},
+				},
+				ReturnFullTransactions: tc.fullTx,
+			}
+			optsBz, err := json.Marshal(opts)
+			suite.Require().NoError(err)
+
+			client := suite.backend.clientCtx.Client.(*mocks.Client)
+			_, err = RegisterBlock(client, 1, bz)
+			suite.Require().NoError(err)
+			tc.registerMock(&evmtypes.QuerySimulateV1Request{Opts: optsBz, ChainId: suite.backend.chainID.Int64()})
+
+			blocks, err := suite.backend.SimulateV1(opts, rpctypes.BlockNumber(1))
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Len(blocks, 1)
+			suite.Require().Equal(header.Hash(), blocks[0]["hash"])
+			suite.Require().Equal(calls, blocks[0]["calls"])
+
+			txs := blocks[0]["transactions"].([]interface{})
+			suite.Require().Len(txs, 1)
+			if !tc.fullTx {
+				suite.Require().Equal(tx.Hash(), txs[0])
+				return
+			}
+			rpcTx := txs[0].(*rpctypes.RPCTransaction)
+			suite.Require().Equal(tx.Hash(), rpcTx.Hash)
+			suite.Require().Equal(from, rpcTx.From)
+			suite.Require().Equal(header.Hash(), *rpcTx.BlockHash)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -79,6 +80,25 @@ func RegisterUnconfirmedTxsError(client *mocks.Client, limit *int) {
 		Return(nil, errortypes.ErrInvalidRequest)
 }
 
+// Block roots
+func RegisterBlockRootsNotStored(client *mocks.Client) {
+	client.On("ABCIQueryWithOptions", mock.Anything, blockRootsStorePath, mock.Anything, mock.Anything).
+		Return(&cmtrpctypes.ResultABCIQuery{}, nil).Maybe()
+}
+
+// RegisterStoredBlockRoots replaces the block roots stored for every height
+func RegisterStoredBlockRoots(client *mocks.Client, txRoot, receiptsRoot common.Hash) {
+	for _, call := range slices.Clone(client.ExpectedCalls) {
+		if call.Method == "ABCIQueryWithOptions" {
+			call.Unset()
+		}
+	}
+	client.On("ABCIQueryWithOptions", mock.Anything, blockRootsStorePath, mock.Anything, mock.Anything).
+		Return(&cmtrpctypes.ResultABCIQuery{
+			Response: abci.ResponseQuery{Value: append(txRoot.Bytes(), receiptsRoot.Bytes()...)},
+		}, nil)
+}
+
 // Status
 func RegisterStatus(client *mocks.Client) {
 	client.On("Status", rpc.ContextWithHeight(1)).
@@ -67,6 +67,42 @@ func RegisterTraceBlockError(queryClient *mocks.EVMQueryClient) {
 		Return(nil, errortypes.ErrInvalidRequest)
 }
 
+// IntermediateRoots
+func RegisterIntermediateRoots(queryClient *mocks.EVMQueryClient, txs []*evmtypes.MsgEthereumTx, res *evmtypes.QueryIntermediateRootsResponse) {
+	queryClient.On("IntermediateRoots", rpc.ContextWithHeight(1),
+		&evmtypes.QueryTraceBlockRequest{Txs: txs, BlockNumber: 1, ChainId: config.DefaultEVMChainID, BlockMaxGas: -1}).
+		Return(res, nil)
+}
+
+// StorageRange
+func RegisterStorageRange(queryClient *mocks.EVMQueryClient, request *evmtypes.QueryStorageRangeRequest, res *evmtypes.QueryStorageRangeResponse) {
+	queryClient.On("StorageRange", rpc.ContextWithHeight(1), request).
+		Return(res, nil)
+}
+
+// Preimage
+func RegisterPreimage(queryClient *mocks.EVMQueryClient, hash common.Hash, preimage []byte) {
+	queryClient.On("Preimage", mock.Anything, &evmtypes.QueryPreimageRequest{Hash: hash.Hex()}).
+		Return(&evmtypes.QueryPreimageResponse{Preimage: preimage}, nil)
+}
+
+func RegisterPreimageError(queryClient *mocks.EVMQueryClient, hash common.Hash) {
+	queryClient.On("Preimage", mock.Anything, &evmtypes.QueryPreimageRequest{Hash: hash.Hex()}).
+		Return(nil, status.Errorf(codes.NotFound, "preimage of %s not found", hash.Hex()))
+}
+
+// TraceCall
+func RegisterTraceCall(queryClient *mocks.EVMQueryClient, request *evmtypes.QueryTraceCallRequest) {
+	data := []byte{0x7b, 0x22, 0x74, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x22, 0x7d}
+	queryClient.On("TraceCall", rpc.ContextWithHeight(1), request).
+		Return(&evmtypes.QueryTraceCallResponse{Data: data}, nil)
+}
+
+func RegisterTraceCallError(queryClient *mocks.EVMQueryClient, request *evmtypes.QueryTraceCallRequest) {
+	queryClient.On("TraceCall", rpc.ContextWithHeight(1), request).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
 // Params
 func RegisterParams(queryClient *mocks.EVMQueryClient, header *metadata.MD, height int64) {
 	queryClient.On("Params", rpc.ContextWithHeight(height), &evmtypes.QueryParamsRequest{}, grpc.Header(header)).
@@ -154,6 +190,32 @@ func RegisterEthCallError(queryClient *mocks.EVMQueryClient, request *evmtypes.E
 		Return(nil, errortypes.ErrInvalidRequest)
 }
 
+// CreateAccessList
+func RegisterCreateAccessList(queryClient *mocks.EVMQueryClient, request *evmtypes.EthCallRequest, res *evmtypes.QueryCreateAccessListResponse) {
+	ctx, _ := context.WithCancel(rpc.ContextWithHeight(1)) //nolint
+	queryClient.On("CreateAccessList", ctx, request).
+		Return(res, nil)
+}
+
+func RegisterCreateAccessListError(queryClient *mocks.EVMQueryClient, request *evmtypes.EthCallRequest) {
+	ctx, _ := context.WithCancel(rpc.ContextWithHeight(1)) //nolint
+	queryClient.On("CreateAccessList", ctx, request).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
+// SimulateV1
+func RegisterSimulateV1(queryClient *mocks.EVMQueryClient, request *evmtypes.QuerySimulateV1Request, data []byte) {
+	ctx, _ := context.WithCancel(rpc.ContextWithHeight(1)) //nolint
+	queryClient.On("SimulateV1", ctx, request).
+		Return(&evmtypes.QuerySimulateV1Response{Data: data}, nil)
+}
+
+func RegisterSimulateV1Error(queryClient *mocks.EVMQueryClient, request *evmtypes.QuerySimulateV1Request) {
+	ctx, _ := context.WithCancel(rpc.ContextWithHeight(1)) //nolint
+	queryClient.On("SimulateV1", ctx, request).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
 // Estimate Gas
 func RegisterEstimateGas(queryClient *mocks.EVMQueryClient, args evmtypes.TransactionArgs) {
 	bz, _ := json.Marshal(args)
@@ -258,6 +320,16 @@ func RegisterAccount(queryClient *mocks.EVMQueryClient, addr common.Address, hei
 		)
 }
 
+func RegisterAccountWithNonce(queryClient *mocks.EVMQueryClient, addr common.Address, height int64, nonce uint64) {
+	queryClient.On("Account", rpc.ContextWithHeight(height), &evmtypes.QueryAccountRequest{Address: addr.String()}).
+		Return(&evmtypes.QueryAccountResponse{Balance: "0", Nonce: nonce}, nil)
+}
+
+func RegisterAccountError(queryClient *mocks.EVMQueryClient, addr common.Address, height int64) {
+	queryClient.On("Account", rpc.ContextWithHeight(height), &evmtypes.QueryAccountRequest{Address: addr.String()}).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
 // Balance
 func RegisterBalance(queryClient *mocks.EVMQueryClient, addr common.Address, height int64) {
 	queryClient.On("Balance", rpc.ContextWithHeight(height), &evmtypes.QueryBalanceRequest{Address: addr.String()}).
@@ -0,0 +1,173 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+)
+
+// gpoSampleNumber is the number of transactions sampled from each block.
+const gpoSampleNumber = 3
+
+// gasPriceOracle caches the gas tip cap suggested for the latest block.
+type gasPriceOracle struct {
+	mu         sync.Mutex
+	lastHeight int64
+	lastPrice  *big.Int
+}
+
+// newGasPriceOracle returns a gas price oracle without any cached suggestion.
+// The first suggestion sampling empty blocks is seeded from the fee history of
+// the latest blocks.
+func newGasPriceOracle() *gasPriceOracle {
+	return &gasPriceOracle{}
+}
+
+// suggestTipCap returns a gas tip cap for a transaction to be included in the
+// next block. Following go-ethereum, it samples the lowest effective tips paid
+// by the transactions of the latest blocks and returns the configured
+// percentile of them, capped to the configured maximum price. The blocks that
+// can't be loaded, e.g. pruned ones, are skipped. The suggestion is cached
+// until a new block is committed.
+func (b *Backend) suggestTipCap() (*big.Int, error) {
+	head, err := b.TendermintBlockByNumber(rpctypes.EthLatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, fmt.Errorf("latest block not found")
+	}
+
+	b.gpo.mu.Lock()
+	lastHeight, lastPrice := b.gpo.lastHeight, b.gpo.lastPrice
+	b.gpo.mu.Unlock()
+	if lastPrice != nil && head.Block.Height == lastHeight {
+		return new(big.Int).Set(lastPrice), nil
+	}
+
+	var (
+		cfg     = b.cfg.JSONRPC
+		number  = head.Block.Height
+		limit   = cfg.GPOBlocks
+		results []*big.Int
+	)
+	for sampled := 0; sampled < limit && number > 0; sampled++ {
+		values, err := b.blockTipValues(number, gpoSampleNumber, new(big.Int).SetUint64(cfg.GPOIgnorePrice))
+		number--
+		if err != nil {
+			b.logger.Debug("failed to sample the block tips", "height", number+1, "error", err.Error())
+			continue
+		}
+
+		// use the latest suggestion for empty blocks
+		if len(values) == 0 {
+			if lastPrice == nil {
+				lastPrice = b.seedTipCap(head.Block.Height)
+			}
+			values = []*big.Int{lastPrice}
+		}
+		// sample more blocks if this one didn't return meaningful data, up to
+		// twice the configured number of blocks
+		if len(values) == 1 && limit < 2*cfg.GPOBlocks {
+			limit++
+		}
+		results = append(results, values...)
+	}
+
+	var price *big.Int
+	switch {
+	case len(results) > 0:
+		price = percentile(results, cfg.GPOPercentile)
+	case lastPrice != nil:
+		price = lastPrice
+	default:
+		price = b.seedTipCap(head.Block.Height)
+	}
+	if maxPrice := new(big.Int).SetUint64(cfg.GPOMaxPrice); maxPrice.Sign() > 0 && price.Cmp(maxPrice) > 0 {
+		price = maxPrice
+	}
+
+	b.gpo.mu.Lock()
+	// a concurrent request may have cached the suggestion of a newer block
+	if head.Block.Height >= b.gpo.lastHeight {
+		b.gpo.lastHeight = head.Block.Height
+		b.gpo.lastPrice = price
+	}
+	b.gpo.mu.Unlock()
+
+	return new(big.Int).Set(price), nil
+}
+
+// seedTipCap returns the tip suggested for the empty blocks sampled before any
+// suggestion is cached: the configured percentile of the tips rewarded in the
+// fee history of the latest blocks, or zero if it can't be loaded.
+func (b *Backend) seedTipCap(height int64) *big.Int {
+	cfg := b.cfg.JSONRPC
+	blocks := min(int64(cfg.GPOBlocks), int64(cfg.FeeHistoryCap))
+	feeHistory, err := b.FeeHistory(rpc.BlockNumber(blocks), rpc.BlockNumber(height), []float64{float64(cfg.GPOPercentile)})
+	if err != nil {
+		b.logger.Debug("failed to load the fee history to seed the gas tip cap", "height", height, "error", err.Error())
+		return new(big.Int)
+	}
+
+	rewards := make([]*big.Int, 0, len(feeHistory.Reward))
+	for _, reward := range feeHistory.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			rewards = append(rewards, reward[0].ToInt())
+		}
+	}
+	if len(rewards) == 0 {
+		return new(big.Int)
+	}
+	return percentile(rewards, cfg.GPOPercentile)
+}
+
+// percentile sorts the given values and returns the given percentile of them.
+func percentile(values []*big.Int, p int) *big.Int {
+	slices.SortFunc(values, func(a, b *big.Int) int { return a.Cmp(b) })
+	return values[(len(values)-1)*p/100]
+}
+
+// blockTipValues returns the lowest effective tips paid by the Ethereum
+// transactions of the block at the given height, sorted in ascending order.
+// Tips lower than ignoreUnder are not sampled.
+func (b *Backend) blockTipValues(height int64, limit int, ignoreUnder *big.Int) ([]*big.Int, error) {
+	resBlock, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(height))
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil {
+		return nil, fmt.Errorf("block not found for height %d", height)
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d", height)
+	}
+
+	baseFee, err := b.BaseFee(blockRes)
+	if err != nil {
+		// handle the error for pruned node.
+		b.logger.Error("failed to fetch Base Fee from prunned block. Check node prunning configuration", "height", height, "error", err)
+	}
+
+	var tips []*big.Int
+	for _, msg := range b.EthMsgsFromTendermintBlock(resBlock, blockRes) {
+		tip, err := msg.AsTransaction().EffectiveGasTip(baseFee)
+		if err != nil || tip.Cmp(ignoreUnder) < 0 {
+			continue
+		}
+		tips = append(tips, tip)
+	}
+
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+	if len(tips) > limit {
+		tips = tips[:limit]
+	}
+	return tips, nil
+}
@@ -0,0 +1,232 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/metadata"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/evm/rpc/backend/mocks"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	utiltx "github.com/cosmos/evm/testutil/tx"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (suite *BackendTestSuite) TestSuggestTipCap() {
+	baseFee := big.NewInt(100)
+
+	// registerBlocks registers the blocks down to the given height, with the
+	// latest at height 3:
+	//   - 3: tips 40, 10, 30, 20
+	//   - 2: tips 50 and 1, which is below the ignore price
+	//   - 1: empty, sampled with the tip seeded from the fee history of the
+	//     blocks 2 and 3
+	registerBlocks := func(lowest int64) func() {
+		return func() {
+			var header metadata.MD
+			suite.backend.ctx = rpctypes.ContextWithHeight(3)
+			client := suite.backend.clientCtx.Client.(*mocks.Client)
+			queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+			RegisterParams(queryClient, &header, 3)
+			tips := map[int64][]int64{3: {40, 10, 30, 20}, 2: {50, 1}, 1: {}}
+			for height := int64(3); height >= lowest; height-- {
+				suite.registerBlockWithTips(client, queryClient, height, baseFee, tips[height]...)
+			}
+			if lowest == 1 {
+				suite.registerFeeHistoryBlocks(client, queryClient, 2, 3)
+			}
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		malleate     func()
+		expTipCap    *big.Int
+		expPass      bool
+	}{
+		{
+			"fail - latest block error",
+			func() {
+				var header metadata.MD
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterBlockError(client, 1)
+			},
+			func() {},
+			nil,
+			false,
+		},
+		{
+			"pass - percentile of the lowest tips of each block",
+			// samples the blocks 3 and 2, and the block 1 as the block 2 only
+			// has one tip above the ignore price: [0, 10, 20, 30, 50]
+			registerBlocks(1),
+			func() {
+				suite.backend.cfg.JSONRPC.GPOBlocks = 2
+			},
+			big.NewInt(20),
+			true,
+		},
+		{
+			"pass - highest percentile",
+			registerBlocks(1),
+			func() {
+				suite.backend.cfg.JSONRPC.GPOBlocks = 2
+				suite.backend.cfg.JSONRPC.GPOPercentile = 100
+			},
+			big.NewInt(50),
+			true,
+		},
+		{
+			"pass - only the latest block is sampled",
+			registerBlocks(3),
+			func() {
+				suite.backend.cfg.JSONRPC.GPOBlocks = 1
+				suite.backend.cfg.JSONRPC.GPOPercentile = 100
+			},
+			big.NewInt(30),
+			true,
+		},
+		{
+			"pass - empty blocks suggest the fee history tip",
+			func() {
+				var header metadata.MD
+				suite.backend.ctx = rpctypes.ContextWithHeight(1)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				suite.registerBlockWithTips(client, queryClient, 1, baseFee)
+				suite.registerFeeHistoryBlocks(client, queryClient, 1)
+			},
+			func() {},
+			big.NewInt(0),
+			true,
+		},
+		{
+			"pass - pruned blocks are skipped",
+			func() {
+				var header metadata.MD
+				suite.backend.ctx = rpctypes.ContextWithHeight(3)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 3)
+				suite.registerBlockWithTips(client, queryClient, 3, baseFee, 40, 10, 30, 20)
+				atHeight := mock.MatchedBy(func(h *int64) bool { return h != nil && *h == 2 })
+				client.On("Block", suite.backend.ctx, atHeight).
+					Return(nil, fmt.Errorf("height 2 is not available"))
+			},
+			func() {
+				suite.backend.cfg.JSONRPC.GPOBlocks = 2
+				suite.backend.cfg.JSONRPC.GPOPercentile = 100
+			},
+			big.NewInt(30),
+			true,
+		},
+		{
+			"pass - capped to the max price",
+			registerBlocks(1),
+			func() {
+				suite.backend.cfg.JSONRPC.GPOBlocks = 2
+				suite.backend.cfg.JSONRPC.GPOMaxPrice = 15
+			},
+			big.NewInt(15),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+			tc.malleate()
+
+			tipCap, err := suite.backend.SuggestGasTipCap(baseFee)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expTipCap, tipCap)
+
+			// the suggestion is cached until a new block is committed
+			suite.backend.cfg.JSONRPC.GPOPercentile = 0
+			tipCap, err = suite.backend.SuggestGasTipCap(baseFee)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expTipCap, tipCap)
+		})
+	}
+}
+
+// registerBlockWithTips registers a block at the given height containing one
+// successful Ethereum transaction per tip, and its base fee.
+func (suite *BackendTestSuite) registerBlockWithTips(
+	client *mocks.Client,
+	queryClient *mocks.EVMQueryClient,
+	height int64,
+	baseFee *big.Int,
+	tips ...int64,
+) {
+	txs := make([]types.Tx, 0, len(tips))
+	txResults := make([]*abci.ExecTxResult, 0, len(tips))
+	for _, tip := range tips {
+		txs = append(txs, suite.buildEthereumTxWithTip(baseFee, big.NewInt(tip)))
+		txResults = append(txResults, &abci.ExecTxResult{Code: 0})
+	}
+
+	block := types.MakeBlock(height, txs, nil, nil)
+	block.ChainID = ChainID.ChainID
+	atHeight := mock.MatchedBy(func(h *int64) bool { return h != nil && *h == height })
+	client.On("Block", suite.backend.ctx, atHeight).
+		Return(&cmtrpctypes.ResultBlock{Block: block}, nil)
+	client.On("BlockResults", suite.backend.ctx, atHeight).
+		Return(&cmtrpctypes.ResultBlockResults{Height: height, TxsResults: txResults}, nil)
+
+	fee := math.NewIntFromBigInt(baseFee)
+	queryClient.On("BaseFee", rpctypes.ContextWithHeight(height), &evmtypes.QueryBaseFeeRequest{}).
+		Return(&evmtypes.QueryBaseFeeResponse{BaseFee: &fee}, nil)
+}
+
+// registerFeeHistoryBlocks registers the validator account and consensus
+// params loaded with the blocks at the given heights by the fee history.
+func (suite *BackendTestSuite) registerFeeHistoryBlocks(client *mocks.Client, queryClient *mocks.EVMQueryClient, heights ...int64) {
+	validator := sdk.AccAddress(utiltx.GenerateAddress().Bytes())
+	for _, height := range heights {
+		queryClient.On("ValidatorAccount", rpctypes.ContextWithHeight(height), &evmtypes.QueryValidatorAccountRequest{}).
+			Return(&evmtypes.QueryValidatorAccountResponse{AccountAddress: validator.String()}, nil)
+		RegisterConsensusParams(client, height)
+	}
+}
+
+// buildEthereumTxWithTip returns an encoded legacy Ethereum transaction paying
+// the given tip on top of the base fee.
+func (suite *BackendTestSuite) buildEthereumTxWithTip(baseFee, tip *big.Int) []byte {
+	msgEthereumTx := evmtypes.NewTx(&evmtypes.EvmTxArgs{
+		ChainID:  suite.backend.chainID,
+		Nonce:    uint64(0),
+		To:       &common.Address{},
+		Amount:   big.NewInt(0),
+		GasLimit: 100000,
+		GasPrice: new(big.Int).Add(baseFee, tip),
+	})
+	msgEthereumTx.From = suite.from.Hex()
+
+	txBuilder := suite.backend.clientCtx.TxConfig.NewTxBuilder()
+	err := txBuilder.SetMsgs(msgEthereumTx)
+	suite.Require().NoError(err)
+
+	bz, err := suite.backend.clientCtx.TxConfig.TxEncoder()(txBuilder.GetTx())
+	suite.Require().NoError(err)
+	return bz
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+)
+
+// LogIndexRange returns the first and last blocks of the latest range of
+// consecutive blocks whose logs are indexed by address and topics, -1 and -1
+// if none. It requires the custom EVM tx
+// indexer.
+func (b *Backend) LogIndexRange() (int64, int64, error) {
+	indexer, err := b.logIndexer()
+	if err != nil {
+		return 0, 0, err
+	}
+	return indexer.LogIndexRange()
+}
+
+// GetLogLocations returns the locations of the logs emitted in the given
+// blocks matching the addresses and topics, sorted by block and log index. It
+// requires the custom EVM tx indexer.
+func (b *Backend) GetLogLocations(addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock int64) ([]cosmosevmtypes.LogLocation, error) {
+	indexer, err := b.logIndexer()
+	if err != nil {
+		return nil, err
+	}
+	return indexer.GetLogLocations(addresses, topics, fromBlock, toBlock)
+}
+
+// logIndexer returns the custom EVM tx indexer if it indexes the logs by
+// address and topics.
+func (b *Backend) logIndexer() (cosmosevmtypes.EVMLogIndexer, error) {
+	indexer, ok := b.indexer.(cosmosevmtypes.EVMLogIndexer)
+	if !ok {
+		return nil, rpctypes.ErrLogIndexDisabled
+	}
+	return indexer, nil
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -312,6 +313,15 @@ func (b *Backend) RPCEVMTimeout() time.Duration {
 	return b.cfg.JSONRPC.EVMTimeout
 }
 
+// RPCTraceFileDir is the directory of the trace files written by the debug
+// namespace, the temporary directory if not configured.
+func (b *Backend) RPCTraceFileDir() string {
+	if b.cfg.JSONRPC.TraceFileDir == "" {
+		return os.TempDir()
+	}
+	return b.cfg.JSONRPC.TraceFileDir
+}
+
 // RPCGasCap is the global gas cap for eth-call variants.
 func (b *Backend) RPCTxFeeCap() float64 {
 	return b.cfg.JSONRPC.TxFeeCap
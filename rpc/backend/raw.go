@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// GetRawTransactionByHash returns the EIP-2718 envelope of the transaction
+// identified by hash, looking it up in the mempool if it isn't part of a
+// block yet.
+func (b *Backend) GetRawTransactionByHash(hash common.Hash) (hexutil.Bytes, error) {
+	res, err := b.GetTxByEthHash(hash)
+	if err != nil {
+		return b.getRawTransactionPending(hash)
+	}
+
+	block, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(res.Height))
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.Block == nil {
+		return nil, fmt.Errorf("block not found at height %d", res.Height)
+	}
+
+	if int(res.TxIndex) >= len(block.Block.Txs) {
+		return nil, fmt.Errorf("tx index %d out of bound at height %d", res.TxIndex, res.Height)
+	}
+	tx, err := b.clientCtx.TxConfig.TxDecoder()(block.Block.Txs[res.TxIndex])
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := tx.GetMsgs()
+	if int(res.MsgIndex) >= len(msgs) {
+		return nil, fmt.Errorf("msg index %d out of bound in tx %d at height %d", res.MsgIndex, res.TxIndex, res.Height)
+	}
+	msg, ok := msgs[res.MsgIndex].(*evmtypes.MsgEthereumTx)
+	if !ok {
+		return nil, errors.New("invalid ethereum tx")
+	}
+	return msg.AsTransaction().MarshalBinary()
+}
+
+// getRawTransactionPending returns the EIP-2718 envelope of the transaction
+// identified by hash from the mempool, or nil if it isn't found.
+func (b *Backend) getRawTransactionPending(hash common.Hash) (hexutil.Bytes, error) {
+	txs, err := b.PendingTransactions()
+	if err != nil {
+		b.logger.Debug("tx not found", "hash", hash.Hex(), "error", err.Error())
+		return nil, nil
+	}
+
+	for _, tx := range txs {
+		msg, err := evmtypes.UnwrapEthereumMsg(tx, hash)
+		if err != nil {
+			// not ethereum tx
+			continue
+		}
+		if msg.Hash == hash.Hex() {
+			return msg.AsTransaction().MarshalBinary()
+		}
+	}
+
+	b.logger.Debug("tx not found", "hash", hash.Hex())
+	return nil, nil
+}
+
+// GetRawTransactionByBlockHashAndIndex returns the EIP-2718 envelope of the
+// transaction identified by block hash and index.
+func (b *Backend) GetRawTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (hexutil.Bytes, error) {
+	block, err := b.TendermintBlockByHash(hash)
+	if err != nil {
+		b.logger.Debug("block not found", "hash", hash.Hex(), "error", err.Error())
+		return nil, nil
+	}
+
+	if block == nil || block.Block == nil {
+		b.logger.Debug("block not found", "hash", hash.Hex())
+		return nil, nil
+	}
+
+	return b.getRawTransactionByBlockAndIndex(block, idx)
+}
+
+// GetRawTransactionByBlockNumberAndIndex returns the EIP-2718 envelope of the
+// transaction identified by block number and index.
+func (b *Backend) GetRawTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (hexutil.Bytes, error) {
+	block, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		b.logger.Debug("block not found", "height", blockNum.Int64(), "error", err.Error())
+		return nil, nil
+	}
+
+	if block == nil || block.Block == nil {
+		b.logger.Debug("block not found", "height", blockNum.Int64())
+		return nil, nil
+	}
+
+	return b.getRawTransactionByBlockAndIndex(block, idx)
+}
+
+// getRawTransactionByBlockAndIndex returns the EIP-2718 envelope of the
+// Ethereum transaction of the block at the given index, or nil if the block
+// has no such transaction.
+func (b *Backend) getRawTransactionByBlockAndIndex(block *tmrpctypes.ResultBlock, idx hexutil.Uint) (hexutil.Bytes, error) {
+	blockRes, err := b.rpcClient.BlockResults(b.ctx, &block.Block.Height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d: %w", block.Block.Height, err)
+	}
+
+	i := int(idx) // #nosec G115
+	ethMsgs := b.EthMsgsFromTendermintBlock(block, blockRes)
+	if i >= len(ethMsgs) {
+		b.logger.Debug("block txs index out of bound", "index", i)
+		return nil, nil
+	}
+	return ethMsgs[i].AsTransaction().MarshalBinary()
+}
+
+// GetRawReceipts returns the consensus encoding of the receipts of the
+// Ethereum transactions executed on the given block, as committed to by its
+// receipts root.
+func (b *Backend) GetRawReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]hexutil.Bytes, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, fmt.Errorf("block not found for height %d", blockNum.Int64())
+	}
+
+	blockRes, err := b.rpcClient.BlockResults(b.ctx, &resBlock.Block.Height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d: %w", resBlock.Block.Height, err)
+	}
+
+	receipts, _, err := b.blockConsensusReceipts(resBlock, blockRes, common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]hexutil.Bytes, len(receipts))
+	for i, receipt := range receipts {
+		if result[i], err = receipt.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetRawBlock returns the RLP encoding of the Ethereum block of the given
+// number or hash.
+func (b *Backend) GetRawBlock(blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := b.EthBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(block)
+}
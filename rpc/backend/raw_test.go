@@ -0,0 +1,175 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/rpc/backend/mocks"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+)
+
+func (suite *BackendTestSuite) rawDataTxResults(txHash common.Hash) []*abci.ExecTxResult {
+	return []*abci.ExecTxResult{
+		{
+			Code:    0,
+			GasUsed: 21000,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: "0x775b87ef5D82ca211811C1a02CE0fE0CA3a455d7"},
+				}},
+				{Type: evmtypes.EventTypeTxLog},
+			},
+		},
+	}
+}
+
+func (suite *BackendTestSuite) TestGetRawTransactionByHash() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+	expRaw, err := msgEthereumTx.AsTransaction().MarshalBinary()
+	suite.Require().NoError(err)
+
+	block := &types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	txResults := suite.rawDataTxResults(txHash)
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+	}{
+		{
+			"fail - block error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockError(client, 1)
+			},
+			false,
+		},
+		{
+			"fail - tx index out of bound",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, nil)
+				suite.Require().NoError(err)
+			},
+			false,
+		},
+		{
+			"pass",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			db := dbm.NewMemDB()
+			suite.backend.indexer = indexer.NewKVIndexer(db, log.NewNopLogger(), suite.backend.clientCtx)
+			err := suite.backend.indexer.IndexBlock(block, txResults)
+			suite.Require().NoError(err)
+
+			raw, err := suite.backend.GetRawTransactionByHash(txHash)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(expRaw, []byte(raw))
+
+			tx := new(ethtypes.Transaction)
+			suite.Require().NoError(tx.UnmarshalBinary(raw))
+			suite.Require().Equal(txHash, tx.Hash())
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestGetRawReceipts() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+
+	block := &types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	txResults := suite.rawDataTxResults(txHash)
+
+	receipt := &ethtypes.Receipt{
+		Type:              msgEthereumTx.AsTransaction().Type(),
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+	}
+	receipt.Bloom = ethtypes.CreateBloom(receipt)
+	receiptBz, err := receipt.MarshalBinary()
+	suite.Require().NoError(err)
+
+	blockNum := rpctypes.BlockNumber(1)
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+	}{
+		{
+			"fail - block result error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+				RegisterBlockResultsError(client, 1)
+			},
+			false,
+		},
+		{
+			"pass",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+				client.On("BlockResults", suite.backend.ctx, mock.AnythingOfType("*int64")).
+					Return(&cmtrpctypes.ResultBlockResults{Height: 1, TxsResults: txResults}, nil)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			db := dbm.NewMemDB()
+			suite.backend.indexer = indexer.NewKVIndexer(db, log.NewNopLogger(), suite.backend.clientCtx)
+			err := suite.backend.indexer.IndexBlock(block, txResults)
+			suite.Require().NoError(err)
+
+			raw, err := suite.backend.GetRawReceipts(rpctypes.BlockNumberOrHash{BlockNumber: &blockNum})
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Len(raw, 1)
+			suite.Require().Equal(receiptBz, []byte(raw[0]))
+		})
+	}
+}
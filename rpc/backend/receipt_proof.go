@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
+
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+)
+
+// GetReceiptProof returns the receipt of the given transaction along with the
+// Merkle-Patricia proof of its inclusion in the receipts root of its block.
+// The receipts of the block are rebuilt from the block results and checked
+// against the root derived by the EVM module.
+func (b *Backend) GetReceiptProof(hash common.Hash) (*rpctypes.ReceiptProofResult, error) {
+	res, err := b.GetTxByEthHash(hash)
+	if err != nil {
+		b.logger.Debug("tx not found", "hash", hash.Hex(), "error", err.Error())
+		return nil, err
+	}
+
+	resBlock, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(res.Height))
+	if err != nil {
+		return nil, fmt.Errorf("block not found at height %d: %w", res.Height, err)
+	}
+	if resBlock == nil {
+		return nil, fmt.Errorf("block not found at height %d", res.Height)
+	}
+
+	blockRes, err := b.rpcClient.BlockResults(b.ctx, &res.Height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found at height %d: %w", res.Height, err)
+	}
+
+	roots := b.blockRoots(res.Height, blockRes)
+	if roots == nil {
+		return nil, fmt.Errorf("receipts root not available for block %d", res.Height)
+	}
+
+	receipts, index, err := b.blockConsensusReceipts(resBlock, blockRes, hash)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("receipt of tx %s not found in block %d", hash.Hex(), res.Height)
+	}
+
+	var (
+		tr      = trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+		encoded = make([][]byte, len(receipts))
+	)
+	for i := range receipts {
+		var buf bytes.Buffer
+		receipts.EncodeIndex(i, &buf)
+		encoded[i] = buf.Bytes()
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), encoded[i]); err != nil { //#nosec G115 -- index is positive
+			return nil, err
+		}
+	}
+	if root := tr.Hash(); root != roots.ReceiptsRoot {
+		return nil, fmt.Errorf("receipts root mismatch for block %d: have %s, want %s", res.Height, root.Hex(), roots.ReceiptsRoot.Hex())
+	}
+
+	key := rlp.AppendUint64(nil, uint64(index)) //#nosec G115 -- index is positive
+	var proof trienode.ProofList
+	if err := tr.Prove(key, &proof); err != nil {
+		return nil, err
+	}
+
+	result := &rpctypes.ReceiptProofResult{
+		BlockHash:    common.BytesToHash(resBlock.Block.Header.Hash()),
+		BlockNumber:  hexutil.Uint64(res.Height), //nolint:gosec // G115 // won't exceed uint64
+		ReceiptsRoot: roots.ReceiptsRoot,
+		Key:          key,
+		Receipt:      encoded[index],
+		Proof:        make([]hexutil.Bytes, len(proof)),
+	}
+	for i, node := range proof {
+		result.Proof[i] = hexutil.Bytes(node)
+	}
+	return result, nil
+}
+
+// blockConsensusReceipts returns the consensus fields of the receipts of the
+// Ethereum transactions executed on the block, along with the index of the
+// receipt of the given transaction, or -1 if it isn't part of the block. The
+// cumulative gas used only accounts for the Ethereum transactions, as done by
+// the EVM module when deriving the receipts root.
+func (b *Backend) blockConsensusReceipts(
+	resBlock *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+	hash common.Hash,
+) (ethtypes.Receipts, int, error) {
+	var (
+		receipts          ethtypes.Receipts
+		index             = -1
+		cumulativeGasUsed uint64
+	)
+	for _, ethMsg := range b.EthMsgsFromTendermintBlock(resBlock, blockRes) {
+		txHash := common.HexToHash(ethMsg.Hash)
+		res, err := b.GetTxByEthHash(txHash)
+		if err != nil {
+			return nil, -1, fmt.Errorf("failed to get tx %s: %w", ethMsg.Hash, err)
+		}
+		txResult := blockRes.TxsResults[res.TxIndex]
+		if rpctypes.TxExceedBlockGasLimit(txResult) {
+			// not executed by the EVM
+			continue
+		}
+
+		logs, err := TxLogsFromEvents(txResult.Events, int(res.MsgIndex))
+		if err != nil {
+			return nil, -1, fmt.Errorf("failed to parse logs of tx %s: %w", ethMsg.Hash, err)
+		}
+
+		cumulativeGasUsed += res.GasUsed
+		receipt := &ethtypes.Receipt{
+			Type:              ethMsg.AsTransaction().Type(),
+			Status:            ethtypes.ReceiptStatusSuccessful,
+			CumulativeGasUsed: cumulativeGasUsed,
+			Logs:              logs,
+		}
+		if res.Failed {
+			receipt.Status = ethtypes.ReceiptStatusFailed
+		}
+		receipt.Bloom = ethtypes.CreateBloom(receipt)
+
+		if txHash == hash {
+			index = len(receipts)
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, index, nil
+}
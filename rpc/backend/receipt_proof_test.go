@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/stretchr/testify/mock"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/rpc/backend/mocks"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+)
+
+func (suite *BackendTestSuite) TestGetReceiptProof() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+
+	block := &types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	txResults := []*abci.ExecTxResult{
+		{
+			Code:    0,
+			GasUsed: 21000,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: "0x775b87ef5D82ca211811C1a02CE0fE0CA3a455d7"},
+				}},
+				{Type: evmtypes.EventTypeTxLog},
+			},
+		},
+	}
+
+	receipt := &ethtypes.Receipt{
+		Type:              msgEthereumTx.AsTransaction().Type(),
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+	}
+	receipt.Bloom = ethtypes.CreateBloom(receipt)
+	receiptsRoot := ethtypes.DeriveSha(ethtypes.Receipts{receipt}, trie.NewStackTrie(nil))
+	receiptBz, err := receipt.MarshalBinary()
+	suite.Require().NoError(err)
+
+	registerBlockResults := func(roots *rpctypes.BlockRoots) {
+		var events []abci.Event
+		if roots != nil {
+			events = append(events, abci.Event{Type: evmtypes.EventTypeBlockRoots, Attributes: []abci.EventAttribute{
+				{Key: evmtypes.AttributeKeyTxRoot, Value: roots.TxRoot.Hex()},
+				{Key: evmtypes.AttributeKeyReceiptsRoot, Value: roots.ReceiptsRoot.Hex()},
+			}})
+		}
+		client := suite.backend.clientCtx.Client.(*mocks.Client)
+		client.On("BlockResults", suite.backend.ctx, mock.AnythingOfType("*int64")).
+			Return(&cmtrpctypes.ResultBlockResults{Height: 1, TxsResults: txResults, FinalizeBlockEvents: events}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+	}{
+		{
+			"fail - roots not available",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+				registerBlockResults(nil)
+			},
+			false,
+		},
+		{
+			"fail - receipts root mismatch",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+				registerBlockResults(&rpctypes.BlockRoots{ReceiptsRoot: common.HexToHash("0x01")})
+			},
+			false,
+		},
+		{
+			"pass",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				_, err := RegisterBlock(client, 1, txBz)
+				suite.Require().NoError(err)
+				registerBlockResults(&rpctypes.BlockRoots{ReceiptsRoot: receiptsRoot})
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			db := dbm.NewMemDB()
+			suite.backend.indexer = indexer.NewKVIndexer(db, log.NewNopLogger(), suite.backend.clientCtx)
+			err := suite.backend.indexer.IndexBlock(block, txResults)
+			suite.Require().NoError(err)
+
+			res, err := suite.backend.GetReceiptProof(txHash)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(receiptsRoot, res.ReceiptsRoot)
+			suite.Require().Equal(rlp.AppendUint64(nil, 0), []byte(res.Key))
+			suite.Require().Equal(receiptBz, []byte(res.Receipt))
+
+			proof := make(trienode.ProofList, len(res.Proof))
+			for i, node := range res.Proof {
+				proof[i] = []byte(node)
+			}
+			value, err := trie.VerifyProof(res.ReceiptsRoot, res.Key, proof.Set())
+			suite.Require().NoError(err)
+			suite.Require().Equal(receiptBz, value)
+		})
+	}
+}
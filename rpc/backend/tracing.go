@@ -1,11 +1,14 @@
 package backend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 
 	tmrpcclient "github.com/cometbft/cometbft/rpc/client"
@@ -141,14 +144,131 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 	config *evmtypes.TraceConfig,
 	block *tmrpctypes.ResultBlock,
 ) ([]*evmtypes.TxTraceResult, error) {
-	txs := block.Block.Txs
-	txsLength := len(txs)
+	txsLength := len(block.Block.Txs)
 
 	if txsLength == 0 {
 		// If there are no transactions return empty array
 		return []*evmtypes.TxTraceResult{}, nil
 	}
 
+	ctxWithHeight, traceBlockRequest, err := b.traceBlockRequest(height, config, block)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := b.queryClient.TraceBlock(ctxWithHeight, traceBlockRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedResults := make([]*evmtypes.TxTraceResult, txsLength)
+	if err := json.Unmarshal(res.Data, &decodedResults); err != nil {
+		return nil, err
+	}
+
+	return decodedResults, nil
+}
+
+// TraceBlockStream is like TraceBlock, but calls fn with the trace of each
+// transaction of the block as soon as it's received. The traces are streamed
+// from the node if its gRPC client is set, and queried at once otherwise.
+func (b *Backend) TraceBlockStream(height rpctypes.BlockNumber,
+	config *evmtypes.TraceConfig,
+	block *tmrpctypes.ResultBlock,
+	fn func(*evmtypes.QueryTraceBlockStreamResponse) error,
+) error {
+	if len(block.Block.Txs) == 0 {
+		return nil
+	}
+
+	ctxWithHeight, traceBlockRequest, err := b.traceBlockRequest(height, config, block)
+	if err != nil {
+		return err
+	}
+
+	if b.clientCtx.GRPCClient == nil {
+		res, err := b.queryClient.TraceBlock(ctxWithHeight, traceBlockRequest)
+		if err != nil {
+			return err
+		}
+
+		var results []json.RawMessage
+		if err := json.Unmarshal(res.Data, &results); err != nil {
+			return err
+		}
+		for i, data := range results {
+			var result struct {
+				Result json.RawMessage `json:"result"`
+				Error  string          `json:"error"`
+			}
+			if err := json.Unmarshal(data, &result); err != nil {
+				return err
+			}
+			if err := fn(&evmtypes.QueryTraceBlockStreamResponse{
+				TxIndex: uint64(i), //#nosec G115 -- int overflow is not a concern here
+				TxHash:  traceBlockRequest.Txs[i].AsTransaction().Hash().Hex(),
+				Data:    result.Result,
+				Error:   result.Error,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	stream, err := b.queryClient.TraceBlockStream(ctxWithHeight, traceBlockRequest)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+}
+
+// IntermediateRoots replays the Ethereum transactions of the block and returns
+// the commitments to the modified state after each of them, along with the
+// accounts modified by the block.
+func (b *Backend) IntermediateRoots(height rpctypes.BlockNumber,
+	block *tmrpctypes.ResultBlock,
+) (*evmtypes.QueryIntermediateRootsResponse, error) {
+	if len(block.Block.Txs) == 0 {
+		return &evmtypes.QueryIntermediateRootsResponse{}, nil
+	}
+
+	ctxWithHeight, req, err := b.traceBlockRequest(height, nil, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.queryClient.IntermediateRoots(ctxWithHeight, req)
+}
+
+// Preimage returns the SHA3 preimage of the hash recorded by the node, if the
+// recording of the preimages is enabled.
+func (b *Backend) Preimage(hash common.Hash) (hexutil.Bytes, error) {
+	res, err := b.queryClient.Preimage(b.ctx, &evmtypes.QueryPreimageRequest{Hash: hash.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	return res.Preimage, nil
+}
+
+// traceBlockRequest returns the request tracing the Ethereum transactions of
+// the block, along with the context of the beginning of the block.
+func (b *Backend) traceBlockRequest(height rpctypes.BlockNumber,
+	config *evmtypes.TraceConfig,
+	block *tmrpctypes.ResultBlock,
+) (context.Context, *evmtypes.QueryTraceBlockRequest, error) {
+	txs := block.Block.Txs
 	txDecoder := b.clientCtx.TxConfig.TxDecoder()
 
 	var txsMessages []*evmtypes.MsgEthereumTx
@@ -179,15 +299,15 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 
 	nc, ok := b.clientCtx.Client.(tmrpcclient.NetworkClient)
 	if !ok {
-		return nil, errors.New("invalid rpc client")
+		return nil, nil, errors.New("invalid rpc client")
 	}
 
 	cp, err := nc.ConsensusParams(b.ctx, &block.Block.Height)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	traceBlockRequest := &evmtypes.QueryTraceBlockRequest{
+	return ctxWithHeight, &evmtypes.QueryTraceBlockRequest{
 		Txs:             txsMessages,
 		TraceConfig:     config,
 		BlockNumber:     block.Block.Height,
@@ -196,17 +316,61 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 		ProposerAddress: sdk.ConsAddress(block.Block.ProposerAddress),
 		ChainId:         b.chainID.Int64(),
 		BlockMaxGas:     cp.ConsensusParams.Block.MaxGas,
+	}, nil
+}
+
+// TraceCall configures a new tracer according to the provided configuration, and
+// executes the given call on top of the state of the given block, with the
+// optional state and block overrides applied. The return value is dependent on
+// the requested tracer.
+func (b *Backend) TraceCall(
+	args evmtypes.TransactionArgs,
+	blockNr rpctypes.BlockNumber,
+	config *evmtypes.TraceConfig,
+	overrides *rpctypes.StateOverride,
+	blockOverrides *rpctypes.BlockOverrides,
+) (interface{}, error) {
+	bz, err := json.Marshal(&args)
+	if err != nil {
+		return nil, err
+	}
+	overridesBz, err := marshalOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	blockOverridesBz, err := marshalOverrides(blockOverrides)
+	if err != nil {
+		return nil, err
+	}
+	header, err := b.TendermintBlockByNumber(blockNr)
+	if err != nil {
+		// the error message imitates geth behavior
+		return nil, errors.New("header not found")
 	}
 
-	res, err := b.queryClient.TraceBlock(ctxWithHeight, traceBlockRequest)
+	req := evmtypes.QueryTraceCallRequest{
+		Args:            bz,
+		GasCap:          b.RPCGasCap(),
+		TraceConfig:     config,
+		ProposerAddress: sdk.ConsAddress(header.Block.ProposerAddress),
+		ChainId:         b.chainID.Int64(),
+		Overrides:       overridesBz,
+		BlockOverrides:  blockOverridesBz,
+	}
+
+	// From ContextWithHeight: if the provided height is 0,
+	// it will return an empty context and the gRPC query will use
+	// the latest block height for querying.
+	traceResult, err := b.queryClient.TraceCall(rpctypes.ContextWithHeight(blockNr.Int64()), &req)
 	if err != nil {
 		return nil, err
 	}
 
-	decodedResults := make([]*evmtypes.TxTraceResult, txsLength)
-	if err := json.Unmarshal(res.Data, &decodedResults); err != nil {
+	// Response format is unknown due to custom tracer config param
+	var decodedResult interface{}
+	if err := json.Unmarshal(traceResult.Data, &decodedResult); err != nil {
 		return nil, err
 	}
 
-	return decodedResults, nil
+	return decodedResult, nil
 }
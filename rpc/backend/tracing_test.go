@@ -1,10 +1,17 @@
 package backend
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -14,11 +21,15 @@ import (
 	"github.com/cosmos/evm/crypto/ethsecp256k1"
 	"github.com/cosmos/evm/indexer"
 	"github.com/cosmos/evm/rpc/backend/mocks"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	"github.com/cosmos/evm/server/config"
+	utiltx "github.com/cosmos/evm/testutil/tx"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
 	"cosmossdk.io/log"
 
 	"github.com/cosmos/cosmos-sdk/crypto"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 func (suite *BackendTestSuite) TestTraceTransaction() {
@@ -264,3 +275,311 @@ func (suite *BackendTestSuite) TestTraceBlock() {
 		})
 	}
 }
+
+func (suite *BackendTestSuite) TestIntermediateRoots() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	emptyBlock := types.MakeBlock(1, []types.Tx{}, nil, nil)
+	emptyBlock.ChainID = ChainID.ChainID
+	filledBlock := types.MakeBlock(1, []types.Tx{bz}, nil, nil)
+	filledBlock.ChainID = ChainID.ChainID
+	roots := &evmtypes.QueryIntermediateRootsResponse{
+		Roots:            []string{common.HexToHash("0x01").Hex()},
+		ModifiedAccounts: []string{common.HexToAddress("0x02").Hex()},
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		resBlock     *tmrpctypes.ResultBlock
+		expRes       *evmtypes.QueryIntermediateRootsResponse
+	}{
+		{
+			"pass - no transaction returning no roots",
+			func() {},
+			&tmrpctypes.ResultBlock{Block: emptyBlock, BlockID: emptyBlock.LastBlockID},
+			&evmtypes.QueryIntermediateRootsResponse{},
+		},
+		{
+			"pass - roots of the block transactions",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterIntermediateRoots(queryClient, []*evmtypes.MsgEthereumTx{msgEthTx}, roots)
+				RegisterConsensusParams(client, 1)
+			},
+			&tmrpctypes.ResultBlock{Block: filledBlock, BlockID: filledBlock.LastBlockID},
+			roots,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			res, err := suite.backend.IntermediateRoots(1, tc.resBlock)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expRes, res)
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestPreimage() {
+	preimage := []byte("preimage")
+	hash := ethcrypto.Keccak256Hash(preimage)
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPreimage  hexutil.Bytes
+		expPass      bool
+	}{
+		{
+			"fail - unknown preimage",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterPreimageError(queryClient, hash)
+			},
+			nil,
+			false,
+		},
+		{
+			"pass - recorded preimage",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterPreimage(queryClient, hash, preimage)
+			},
+			preimage,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			res, err := suite.backend.Preimage(hash)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPreimage, res)
+		})
+	}
+}
+
+// traceBlockStreamClient is a TraceBlockStream client receiving the given responses
+type traceBlockStreamClient struct {
+	grpc.ClientStream
+	responses []*evmtypes.QueryTraceBlockStreamResponse
+}
+
+func (c *traceBlockStreamClient) Recv() (*evmtypes.QueryTraceBlockStreamResponse, error) {
+	if len(c.responses) == 0 {
+		return nil, io.EOF
+	}
+	res := c.responses[0]
+	c.responses = c.responses[1:]
+	return res, nil
+}
+
+func (suite *BackendTestSuite) TestTraceBlockStream() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	txHash := msgEthTx.AsTransaction().Hash().Hex()
+	filledBlock := types.MakeBlock(1, []types.Tx{bz}, nil, nil)
+	filledBlock.ChainID = ChainID.ChainID
+	resBlockFilled := tmrpctypes.ResultBlock{Block: filledBlock, BlockID: filledBlock.LastBlockID}
+	request := &evmtypes.QueryTraceBlockRequest{
+		Txs:         []*evmtypes.MsgEthereumTx{msgEthTx},
+		BlockNumber: 1,
+		TraceConfig: &evmtypes.TraceConfig{},
+		ChainId:     config.DefaultEVMChainID,
+		BlockMaxGas: -1,
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expTraces    []*evmtypes.QueryTraceBlockStreamResponse
+		expPass      bool
+	}{
+		{
+			"pass - traces queried at once without the gRPC client",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterConsensusParams(client, 1)
+				queryClient.On("TraceBlock", rpctypes.ContextWithHeight(1), request).
+					Return(&evmtypes.QueryTraceBlockResponse{Data: []byte(`[{"result":{"gas":21000}}]`)}, nil)
+			},
+			[]*evmtypes.QueryTraceBlockStreamResponse{{TxIndex: 0, TxHash: txHash, Data: []byte(`{"gas":21000}`)}},
+			true,
+		},
+		{
+			"pass - traces streamed with the gRPC client",
+			func() {
+				grpcClient, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+				suite.Require().NoError(err)
+				suite.backend.clientCtx = suite.backend.clientCtx.WithGRPCClient(grpcClient)
+
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterConsensusParams(client, 1)
+				queryClient.On("TraceBlockStream", rpctypes.ContextWithHeight(1), request).
+					Return(&traceBlockStreamClient{responses: []*evmtypes.QueryTraceBlockStreamResponse{
+						{TxIndex: 0, TxHash: txHash, Error: "execution reverted"},
+					}}, nil)
+			},
+			[]*evmtypes.QueryTraceBlockStreamResponse{{TxIndex: 0, TxHash: txHash, Error: "execution reverted"}},
+			true,
+		},
+		{
+			"fail - stream error",
+			func() {
+				grpcClient, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+				suite.Require().NoError(err)
+				suite.backend.clientCtx = suite.backend.clientCtx.WithGRPCClient(grpcClient)
+
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterConsensusParams(client, 1)
+				queryClient.On("TraceBlockStream", rpctypes.ContextWithHeight(1), request).
+					Return(nil, errortypes.ErrInvalidRequest)
+			},
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			var traces []*evmtypes.QueryTraceBlockStreamResponse
+			err := suite.backend.TraceBlockStream(1, &evmtypes.TraceConfig{}, &resBlockFilled, func(trace *evmtypes.QueryTraceBlockStreamResponse) error {
+				traces = append(traces, trace)
+				return nil
+			})
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Len(traces, len(tc.expTraces))
+				for i, trace := range traces {
+					suite.Require().Equal(tc.expTraces[i].TxIndex, trace.TxIndex)
+					suite.Require().Equal(tc.expTraces[i].TxHash, trace.TxHash)
+					suite.Require().Equal(string(tc.expTraces[i].Data), string(trace.Data))
+					suite.Require().Equal(tc.expTraces[i].Error, trace.Error)
+				}
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestTraceCall() {
+	_, bz := suite.buildEthereumTx()
+	toAddr := utiltx.GenerateAddress()
+	callArgs := evmtypes.TransactionArgs{To: &toAddr}
+	argsBz, err := json.Marshal(callArgs)
+	suite.Require().NoError(err)
+	config := &evmtypes.TraceConfig{Tracer: "callTracer"}
+
+	code := hexutil.Bytes{0x00}
+	overrides := rpctypes.StateOverride{toAddr: rpctypes.OverrideAccount{Code: &code}}
+	overridesBz, err := json.Marshal(overrides)
+	suite.Require().NoError(err)
+
+	blockNumber := (*hexutil.Big)(big.NewInt(100))
+	blockOverrides := rpctypes.BlockOverrides{Number: blockNumber}
+	blockOverridesBz, err := json.Marshal(blockOverrides)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name           string
+		registerMock   func()
+		overrides      *rpctypes.StateOverride
+		blockOverrides *rpctypes.BlockOverrides
+		expResult      interface{}
+		expPass        bool
+	}{
+		{
+			"fail - block not found",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockError(client, 1)
+			},
+			nil,
+			nil,
+			nil,
+			false,
+		},
+		{
+			"fail - invalid request",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				_, err := RegisterBlock(client, 1, bz)
+				suite.Require().NoError(err)
+				RegisterTraceCallError(queryClient, &evmtypes.QueryTraceCallRequest{Args: argsBz, TraceConfig: config, ChainId: suite.backend.chainID.Int64()})
+			},
+			nil,
+			nil,
+			nil,
+			false,
+		},
+		{
+			"pass - returns the trace result",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				_, err := RegisterBlock(client, 1, bz)
+				suite.Require().NoError(err)
+				RegisterTraceCall(queryClient, &evmtypes.QueryTraceCallRequest{Args: argsBz, TraceConfig: config, ChainId: suite.backend.chainID.Int64()})
+			},
+			nil,
+			nil,
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
+		{
+			"pass - returns the trace result with state and block overrides",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				_, err := RegisterBlock(client, 1, bz)
+				suite.Require().NoError(err)
+				RegisterTraceCall(queryClient, &evmtypes.QueryTraceCallRequest{
+					Args:           argsBz,
+					TraceConfig:    config,
+					ChainId:        suite.backend.chainID.Int64(),
+					Overrides:      overridesBz,
+					BlockOverrides: blockOverridesBz,
+				})
+			},
+			&overrides,
+			&blockOverrides,
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			result, err := suite.backend.TraceCall(callArgs, 1, config, tc.overrides, tc.blockOverrides)
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expResult, result)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
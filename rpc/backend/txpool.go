@@ -0,0 +1,116 @@
+package backend
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// MaxUnconfirmedTxs is the maximum number of transactions CometBFT returns
+// from its mempool in a single request.
+const MaxUnconfirmedTxs = 100
+
+// TxPoolContent returns the Ethereum transactions in the mempool grouped by
+// sender and sorted by nonce. A transaction is pending if it can be executed
+// after the sender's on-chain nonce and the transactions before it, and queued
+// if there is a nonce gap. Transactions with a nonce lower than the on-chain
+// one are stale and are omitted. Only the first MaxUnconfirmedTxs transactions
+// of the mempool are read.
+func (b *Backend) TxPoolContent() (pending, queued map[common.Address][]*rpctypes.RPCTransaction, err error) {
+	limit := MaxUnconfirmedTxs
+	txs, err := b.unconfirmedTxs(&limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bySender := make(map[common.Address][]*rpctypes.RPCTransaction)
+	for _, tx := range txs {
+		for _, msg := range (*tx).GetMsgs() {
+			ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+			if !ok {
+				// not ethereum tx
+				break
+			}
+
+			// use zero block values since it's not included in a block yet
+			rpctx, err := rpctypes.NewTransactionFromMsg(
+				ethMsg,
+				common.Hash{},
+				uint64(0),
+				uint64(0),
+				nil,
+				b.chainID,
+			)
+			if err != nil {
+				b.logger.Debug("failed to parse pending tx", "hash", ethMsg.Hash, "error", err.Error())
+				continue
+			}
+
+			bySender[rpctx.From] = append(bySender[rpctx.From], rpctx)
+		}
+	}
+
+	pending = make(map[common.Address][]*rpctypes.RPCTransaction)
+	queued = make(map[common.Address][]*rpctypes.RPCTransaction)
+	for sender, senderTxs := range bySender {
+		res, err := b.queryClient.Account(b.ctx, &evmtypes.QueryAccountRequest{Address: sender.Hex()})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sort.SliceStable(senderTxs, func(i, j int) bool {
+			return senderTxs[i].Nonce < senderTxs[j].Nonce
+		})
+
+		next := res.Nonce
+		for i, rpctx := range senderTxs {
+			nonce := uint64(rpctx.Nonce)
+			if nonce < next {
+				// stale or replaced transaction
+				continue
+			}
+			if nonce > next {
+				// every transaction after a nonce gap is queued
+				queued[sender] = uniqueNonces(senderTxs[i:])
+				break
+			}
+			pending[sender] = append(pending[sender], rpctx)
+			next++
+		}
+	}
+
+	return pending, queued, nil
+}
+
+// uniqueNonces returns the given nonce-sorted transactions, keeping only the
+// first transaction for each nonce.
+func uniqueNonces(txs []*rpctypes.RPCTransaction) []*rpctypes.RPCTransaction {
+	result := make([]*rpctypes.RPCTransaction, 0, len(txs))
+	for i, tx := range txs {
+		if i > 0 && tx.Nonce == txs[i-1].Nonce {
+			continue
+		}
+		result = append(result, tx)
+	}
+	return result
+}
+
+// TxPoolStatus returns the number of pending and queued Ethereum transactions
+// among the mempool transactions read by TxPoolContent. The other mempool
+// transactions aren't counted.
+func (b *Backend) TxPoolStatus() (pending, queued uint64, err error) {
+	pendingTxs, queuedTxs, err := b.TxPoolContent()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, txs := range pendingTxs {
+		pending += uint64(len(txs))
+	}
+	for _, txs := range queuedTxs {
+		queued += uint64(len(txs))
+	}
+	return pending, queued, nil
+}
@@ -0,0 +1,193 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	"github.com/cosmos/evm/rpc/backend/mocks"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	utiltx "github.com/cosmos/evm/testutil/tx"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+func (suite *BackendTestSuite) TestTxPoolContent() {
+	sender1, key1 := utiltx.NewAddrKey()
+	sender2, key2 := utiltx.NewAddrKey()
+	limit := MaxUnconfirmedTxs
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+		expPending   map[common.Address][]uint64
+		expQueued    map[common.Address][]uint64
+	}{
+		{
+			"fail - unconfirmed txs error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxsError(client, &limit)
+			},
+			false,
+			nil,
+			nil,
+		},
+		{
+			"fail - account query error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxs(client, &limit, []types.Tx{suite.buildSignedEthTx(key1, 0)})
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterAccountError(queryClient, sender1, 1)
+			},
+			false,
+			nil,
+			nil,
+		},
+		{
+			"pass - empty mempool",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxs(client, &limit, nil)
+			},
+			true,
+			map[common.Address][]uint64{},
+			map[common.Address][]uint64{},
+		},
+		{
+			"pass - transactions grouped by nonce gap",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxs(client, &limit, []types.Tx{
+					suite.buildSignedEthTx(key1, 5),
+					suite.buildSignedEthTx(key1, 2),
+					suite.buildSignedEthTx(key1, 0),
+					suite.buildSignedEthTx(key1, 1),
+					suite.buildSignedEthTx(key1, 4),
+					suite.buildSignedEthTx(key1, 2),
+					suite.buildSignedEthTx(key2, 3),
+				})
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterAccountWithNonce(queryClient, sender1, 1, 1)
+				RegisterAccountWithNonce(queryClient, sender2, 1, 3)
+			},
+			true,
+			map[common.Address][]uint64{sender1: {1, 2}, sender2: {3}},
+			map[common.Address][]uint64{sender1: {4, 5}},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			pending, queued, err := suite.backend.TxPoolContent()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPending, nonces(pending))
+			suite.Require().Equal(tc.expQueued, nonces(queued))
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestTxPoolStatus() {
+	sender1, key1 := utiltx.NewAddrKey()
+	limit := MaxUnconfirmedTxs
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+		expPending   uint64
+		expQueued    uint64
+	}{
+		{
+			"fail - unconfirmed txs error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxsError(client, &limit)
+			},
+			false,
+			0,
+			0,
+		},
+		{
+			"pass - only the eth txs read are counted",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxs(client, &limit, []types.Tx{
+					suite.buildSignedEthTx(key1, 0),
+					suite.buildSignedEthTx(key1, 1),
+					suite.buildSignedEthTx(key1, 3),
+				})
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterAccountWithNonce(queryClient, sender1, 1, 1)
+			},
+			true,
+			1,
+			1,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			pending, queued, err := suite.backend.TxPoolStatus()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPending, pending)
+			suite.Require().Equal(tc.expQueued, queued)
+		})
+	}
+}
+
+// buildSignedEthTx returns an encoded Ethereum transaction with the given
+// nonce, signed with the given key.
+func (suite *BackendTestSuite) buildSignedEthTx(key *ethsecp256k1.PrivKey, nonce uint64) types.Tx {
+	msgEthereumTx := evmtypes.NewTx(&evmtypes.EvmTxArgs{
+		ChainID:  suite.backend.chainID,
+		Nonce:    nonce,
+		To:       &common.Address{},
+		Amount:   big.NewInt(0),
+		GasLimit: 100000,
+		GasPrice: big.NewInt(1),
+	})
+	msgEthereumTx.From = common.BytesToAddress(key.PubKey().Address()).String()
+
+	ethSigner := ethtypes.LatestSigner(suite.backend.ChainConfig())
+	err := msgEthereumTx.Sign(ethSigner, utiltx.NewSigner(key))
+	suite.Require().NoError(err)
+
+	tx, err := msgEthereumTx.BuildTx(suite.backend.clientCtx.TxConfig.NewTxBuilder(), evmtypes.GetEVMCoinDenom())
+	suite.Require().NoError(err)
+
+	txBz, err := suite.backend.clientCtx.TxConfig.TxEncoder()(tx)
+	suite.Require().NoError(err)
+	return txBz
+}
+
+// nonces returns the nonces of the transactions of each sender.
+func nonces(content map[common.Address][]*rpctypes.RPCTransaction) map[common.Address][]uint64 {
+	result := make(map[common.Address][]uint64, len(content))
+	for sender, txs := range content {
+		for _, tx := range txs {
+			result[sender] = append(result[sender], uint64(tx.Nonce))
+		}
+	}
+	return result
+}
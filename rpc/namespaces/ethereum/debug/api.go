@@ -3,11 +3,13 @@ package debug
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"runtime"
 	"runtime/debug"
 	"runtime/pprof"
+	"sort"
 	"sync"
 	"time"
 
@@ -102,6 +104,22 @@ func (a *API) TraceBlockByHash(hash common.Hash, config *evmtypes.TraceConfig) (
 	return a.backend.TraceBlock(rpctypes.BlockNumber(resBlock.Block.Height), config, resBlock)
 }
 
+// TraceCall lets you trace a given eth_call. It executes the call on top of
+// the state of the given block, with the state and block overrides of the
+// config applied, and returns the result of the requested tracer.
+func (a *API) TraceCall(args evmtypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *rpctypes.TraceCallConfig) (interface{}, error) {
+	a.logger.Debug("debug_traceCall", "args", args.String(), "block number or hash", blockNrOrHash)
+	blockNr, err := a.backend.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		config = &rpctypes.TraceCallConfig{}
+	}
+	return a.backend.TraceCall(args, blockNr, &config.TraceConfig, config.StateOverrides, config.BlockOverrides)
+}
+
 // BlockProfile turns on goroutine profiling for nsec seconds and writes profile data to
 // file. It uses a profile rate of 1 for most accurate information. If a different rate is
 // desired, set the rate and write the profile manually.
@@ -363,6 +381,26 @@ func (a *API) GetBlockRlp(number uint64) (hexutil.Bytes, error) {
 	return rlp.EncodeToBytes(block)
 }
 
+// GetRawTransaction returns the EIP-2718 envelope of the transaction
+// identified by hash.
+func (a *API) GetRawTransaction(hash common.Hash) (hexutil.Bytes, error) {
+	a.logger.Debug("debug_getRawTransaction", "hash", hash.Hex())
+	return a.backend.GetRawTransactionByHash(hash)
+}
+
+// GetRawReceipts returns the consensus encoding of the receipts of the
+// Ethereum transactions of the given block.
+func (a *API) GetRawReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]hexutil.Bytes, error) {
+	a.logger.Debug("debug_getRawReceipts", "block number or hash", blockNrOrHash)
+	return a.backend.GetRawReceipts(blockNrOrHash)
+}
+
+// GetRawBlock returns the RLP encoding of the given block.
+func (a *API) GetRawBlock(blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	a.logger.Debug("debug_getRawBlock", "block number or hash", blockNrOrHash)
+	return a.backend.GetRawBlock(blockNrOrHash)
+}
+
 // PrintBlock retrieves a block and returns its pretty printed form.
 func (a *API) PrintBlock(number uint64) (string, error) {
 	if !a.profilingEnabled {
@@ -376,12 +414,88 @@ func (a *API) PrintBlock(number uint64) (string, error) {
 	return spew.Sdump(block), nil
 }
 
-// IntermediateRoots executes a block, and returns a list
-// of intermediate roots: the stateroot after each transaction.
+// maxModifiedAccountsBlocks is the maximum number of blocks replayed by
+// debug_getModifiedAccountsByNumber
+const maxModifiedAccountsBlocks = 128
+
+// IntermediateRoots executes a block, and returns a list of intermediate
+// roots: the commitment to the state modified by the block after each
+// transaction. As there is no state root per transaction in Cosmos, each
+// commitment hashes the modified EVM accounts and storage, and the modified
+// bank balances, chained to the previous commitment. Comparing them between
+// nodes finds the first transaction whose execution diverges.
 func (a *API) IntermediateRoots(hash common.Hash, _ *evmtypes.TraceConfig) ([]common.Hash, error) {
 	a.logger.Debug("debug_intermediateRoots", "hash", hash)
-	if !a.profilingEnabled {
-		return nil, rpctypes.ErrProfilingDisabled
+	resBlock, err := a.backend.TendermintBlockByHash(hash)
+	if err != nil {
+		a.logger.Debug("get block failed", "hash", hash.Hex(), "error", err.Error())
+		return nil, err
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		a.logger.Debug("block not found", "hash", hash.Hex())
+		return nil, errors.New("block not found")
+	}
+
+	res, err := a.backend.IntermediateRoots(rpctypes.BlockNumber(resBlock.Block.Height), resBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]common.Hash, len(res.Roots))
+	for i, root := range res.Roots {
+		roots[i] = common.HexToHash(root)
+	}
+	return roots, nil
+}
+
+// GetModifiedAccountsByNumber returns all accounts that have changed between
+// the two blocks specified: the accounts modified by the blocks after the
+// start block up to the end block included. With only the start block, it
+// returns the accounts modified by the start block.
+func (a *API) GetModifiedAccountsByNumber(startNum uint64, endNum *uint64) ([]common.Address, error) {
+	a.logger.Debug("debug_getModifiedAccountsByNumber", "start", startNum, "end", endNum)
+	if startNum == 0 && endNum == nil {
+		return nil, errors.New("genesis is not traceable")
+	}
+
+	// the accounts modified by the blocks in (start, end]
+	start, end := startNum-1, startNum
+	if endNum != nil {
+		start, end = startNum, *endNum
+	}
+	if start >= end {
+		return nil, fmt.Errorf("start block (%d) must be less than end block (%d)", start, end)
+	}
+	if end-start > maxModifiedAccountsBlocks {
+		return nil, fmt.Errorf("too many blocks, got %d: limit %d", end-start, maxModifiedAccountsBlocks)
+	}
+
+	modified := make(map[common.Address]struct{})
+	for height := start + 1; height <= end; height++ {
+		resBlock, err := a.backend.TendermintBlockByNumber(rpctypes.BlockNumber(height)) //#nosec G115 -- int overflow is not a concern here -- block number is not likely to exceed int64 max value
+		if err != nil {
+			a.logger.Debug("get block failed", "height", height, "error", err.Error())
+			return nil, err
+		}
+
+		if resBlock == nil || resBlock.Block == nil {
+			return nil, fmt.Errorf("block %d not found", height)
+		}
+
+		res, err := a.backend.IntermediateRoots(rpctypes.BlockNumber(resBlock.Block.Height), resBlock)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range res.ModifiedAccounts {
+			modified[common.HexToAddress(addr)] = struct{}{}
+		}
+	}
+
+	addrs := make([]common.Address, 0, len(modified))
+	for addr := range modified {
+		addrs = append(addrs, addr)
 	}
-	return ([]common.Hash)(nil), nil
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0 })
+	return addrs, nil
 }
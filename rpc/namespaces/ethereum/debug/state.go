@@ -0,0 +1,198 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+const (
+	// accountRangeMaxResults is the maximum number of accounts returned by
+	// debug_accountRange, and the number of accounts queried at once by
+	// debug_dumpBlock
+	accountRangeMaxResults = 256
+	// dumpBlockMaxResults is the maximum number of accounts returned by
+	// debug_dumpBlock, the following ones being paginated by the next key
+	dumpBlockMaxResults = 16 * accountRangeMaxResults
+)
+
+// StorageRangeAt returns the storage of the contract at the given block, after
+// the first txIndex transactions of the block, from the start key. Unlike geth,
+// the storage is iterated by key and not by key hash, so the start and next
+// keys are storage keys.
+func (a *API) StorageRangeAt(
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+	txIndex int,
+	contractAddress common.Address,
+	keyStart hexutil.Bytes,
+	maxResult int,
+) (rpctypes.StorageRangeResult, error) {
+	a.logger.Debug("debug_storageRangeAt", "block number or hash", blockNrOrHash, "tx index", txIndex, "address", contractAddress)
+	if len(keyStart) > common.HashLength {
+		return rpctypes.StorageRangeResult{}, fmt.Errorf("start key too long, got %d bytes", len(keyStart))
+	}
+
+	resBlock, err := a.blockByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+
+	res, err := a.backend.StorageRangeAt(contractAddress, common.BytesToHash(keyStart).Bytes(), maxResult, txIndex, resBlock)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+
+	result := rpctypes.StorageRangeResult{Storage: make(map[common.Hash]rpctypes.StorageEntry, len(res.Storage))}
+	for _, entry := range res.Storage {
+		key := common.HexToHash(entry.Key)
+		result.Storage[crypto.Keccak256Hash(key.Bytes())] = rpctypes.StorageEntry{
+			Key:   &key,
+			Value: common.HexToHash(entry.Value),
+		}
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) > 0 {
+		nextKey := common.BytesToHash(res.Pagination.NextKey)
+		result.NextKey = &nextKey
+	}
+	return result, nil
+}
+
+// AccountRange returns the Ethereum accounts of the state at the given block,
+// from the start address. Unlike geth, the accounts are iterated by address
+// and not by address hash, so the start and next keys are addresses.
+func (a *API) AccountRange(
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+	start hexutil.Bytes,
+	maxResults int,
+	nocode, nostorage, _ bool,
+) (state.Dump, error) {
+	a.logger.Debug("debug_accountRange", "block number or hash", blockNrOrHash, "start", start)
+	if maxResults <= 0 || maxResults > accountRangeMaxResults {
+		maxResults = accountRangeMaxResults
+	}
+
+	resBlock, err := a.blockByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return state.Dump{}, err
+	}
+
+	return a.dumpAccounts(resBlock, start, maxResults, nocode, nostorage)
+}
+
+// DumpBlock returns the Ethereum accounts of the state at the given block,
+// with their code and storage. At most dumpBlockMaxResults accounts are
+// returned: the next key of the dump is then the start address of the
+// following accounts, to be dumped with debug_accountRange.
+func (a *API) DumpBlock(blockNr rpctypes.BlockNumber) (state.Dump, error) {
+	a.logger.Debug("debug_dumpBlock", "block number", blockNr)
+	resBlock, err := a.backend.TendermintBlockByNumber(blockNr)
+	if err != nil {
+		a.logger.Debug("get block failed", "height", blockNr, "error", err.Error())
+		return state.Dump{}, err
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		return state.Dump{}, errors.New("block not found")
+	}
+
+	return a.dumpAccounts(resBlock, nil, dumpBlockMaxResults, false, false)
+}
+
+// Preimage returns the SHA3 preimage of the hash, if the node has recorded it.
+// The preimages are only recorded if the `evm.cache-preimage` option is enabled,
+// from the blocks executed since.
+func (a *API) Preimage(hash common.Hash) (hexutil.Bytes, error) {
+	a.logger.Debug("debug_preimage", "hash", hash)
+	return a.backend.Preimage(hash)
+}
+
+// blockByNumberOrHash returns the block of the given number or hash.
+func (a *API) blockByNumberOrHash(blockNrOrHash rpctypes.BlockNumberOrHash) (*tmrpctypes.ResultBlock, error) {
+	blockNr, err := a.backend.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := a.backend.TendermintBlockByNumber(blockNr)
+	if err != nil {
+		a.logger.Debug("get block failed", "height", blockNr, "error", err.Error())
+		return nil, err
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, errors.New("block not found")
+	}
+	return resBlock, nil
+}
+
+// dumpAccounts returns the dump of at most maxResults accounts of the state at
+// the given block from the start address, querying them by pages of
+// accountRangeMaxResults accounts.
+func (a *API) dumpAccounts(
+	resBlock *tmrpctypes.ResultBlock,
+	start []byte,
+	maxResults int,
+	nocode, nostorage bool,
+) (state.Dump, error) {
+	blockNrOrHash := rpctypes.BlockNumberOrHash{BlockNumber: (*rpctypes.BlockNumber)(&resBlock.Block.Height)}
+	dump := state.Dump{
+		Root:     common.BytesToHash(resBlock.Block.AppHash).Hex(),
+		Accounts: make(map[string]state.DumpAccount),
+	}
+
+	for {
+		pageSize := min(accountRangeMaxResults, maxResults-len(dump.Accounts))
+		res, err := a.backend.AccountRange(blockNrOrHash, start, pageSize, nocode, nostorage)
+		if err != nil {
+			return state.Dump{}, err
+		}
+
+		for _, account := range res.Accounts {
+			dumped := dumpAccount(account, !nostorage)
+			dump.Accounts[dumped.Address.String()] = dumped
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return dump, nil
+		}
+		if len(dump.Accounts) >= maxResults {
+			dump.Next = res.Pagination.NextKey
+			return dump, nil
+		}
+		start = res.Pagination.NextKey
+	}
+}
+
+// dumpAccount returns the geth dump of the account, with its storage if
+// included. The storage isn't committed to a trie, so the root of the dump is
+// always the zero hash.
+func dumpAccount(account evmtypes.DumpAccount, withStorage bool) state.DumpAccount {
+	address := common.HexToAddress(account.Address)
+	dumped := state.DumpAccount{
+		Balance:  account.Balance,
+		Nonce:    account.Nonce,
+		CodeHash: common.HexToHash(account.CodeHash).Bytes(),
+		Code:     account.Code,
+		Root:     common.Hash{}.Bytes(),
+		Address:  &address,
+	}
+	if !withStorage {
+		return dumped
+	}
+
+	dumped.Storage = make(map[common.Hash]string, len(account.Storage))
+	for _, entry := range account.Storage {
+		key, value := common.HexToHash(entry.Key), common.HexToHash(entry.Value)
+		dumped.Storage[key] = common.Bytes2Hex(common.TrimLeftZeroes(value.Bytes()))
+	}
+	return dumped
+}
@@ -0,0 +1,166 @@
+package debug
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// traceWriter writes the trace of a transaction to its trace file.
+type traceWriter func(w io.Writer, trace *evmtypes.QueryTraceBlockStreamResponse) error
+
+// StandardTraceBlockToFile traces the transactions of the block with the struct
+// logger and writes the logs of each transaction to a JSONL file in the trace
+// file directory of the node, one line per opcode followed by the execution
+// result. It returns the paths of the files.
+func (a *API) StandardTraceBlockToFile(hash common.Hash, config *rpctypes.StdTraceConfig) ([]string, error) {
+	a.logger.Debug("debug_standardTraceBlockToFile", "hash", hash)
+	resBlock, err := a.backend.TendermintBlockByHash(hash)
+	if err != nil {
+		a.logger.Debug("get block failed", "hash", hash.Hex(), "error", err.Error())
+		return nil, err
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		a.logger.Debug("block not found", "hash", hash.Hex())
+		return nil, errors.New("block not found")
+	}
+
+	if config == nil {
+		config = &rpctypes.StdTraceConfig{}
+	}
+	// the standard traces are always the struct logs
+	traceConfig := config.TraceConfig
+	traceConfig.Tracer = ""
+	return a.traceBlockToFiles(resBlock, &traceConfig, config.TxHash, writeStructLogs)
+}
+
+// TraceBlockToFile traces the transactions of the block with the configured
+// tracer and writes the result of each transaction to a JSONL file in the trace
+// file directory of the node. It returns the paths of the files.
+func (a *API) TraceBlockToFile(height rpctypes.BlockNumber, config *evmtypes.TraceConfig) ([]string, error) {
+	a.logger.Debug("debug_traceBlockToFile", "height", height)
+	if height == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	// Get Tendermint Block
+	resBlock, err := a.backend.TendermintBlockByNumber(height)
+	if err != nil {
+		a.logger.Debug("get block failed", "height", height, "error", err.Error())
+		return nil, err
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		a.logger.Debug("block not found", "height", height)
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+
+	return a.traceBlockToFiles(resBlock, config, common.Hash{}, writeTraceResult)
+}
+
+// traceBlockToFiles streams the traces of the block transactions, only the one
+// with the given hash if set, and writes each of them to a new file.
+func (a *API) traceBlockToFiles(
+	resBlock *tmrpctypes.ResultBlock,
+	config *evmtypes.TraceConfig,
+	txHash common.Hash,
+	write traceWriter,
+) ([]string, error) {
+	dir := a.backend.RPCTraceFileDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	blockHash := common.BytesToHash(resBlock.BlockID.Hash)
+	files := []string{}
+	err := a.backend.TraceBlockStream(rpctypes.BlockNumber(resBlock.Block.Height), config, resBlock, func(trace *evmtypes.QueryTraceBlockStreamResponse) error {
+		hash := common.HexToHash(trace.TxHash)
+		if txHash != (common.Hash{}) && hash != txHash {
+			return nil
+		}
+
+		pattern := fmt.Sprintf("block_%#x-%d-%#x-*.jsonl", blockHash.Bytes()[:4], trace.TxIndex, hash.Bytes()[:4])
+		file, err := os.CreateTemp(dir, pattern)
+		if err != nil {
+			return err
+		}
+		files = append(files, file.Name())
+
+		w := bufio.NewWriter(file)
+		if err := write(w, trace); err != nil {
+			_ = file.Close()
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if txHash != (common.Hash{}) && len(files) == 0 {
+		return nil, fmt.Errorf("transaction %s not found in block %s", txHash, blockHash)
+	}
+	return files, nil
+}
+
+// writeStructLogs writes the struct logs of the transaction, one per line,
+// followed by its execution result.
+func writeStructLogs(w io.Writer, trace *evmtypes.QueryTraceBlockStreamResponse) error {
+	if trace.Error != "" {
+		return writeTraceResult(w, trace)
+	}
+
+	var result logger.ExecutionResult
+	if err := json.Unmarshal(trace.Data, &result); err != nil {
+		return err
+	}
+	for _, log := range result.StructLogs {
+		if err := writeLine(w, log); err != nil {
+			return err
+		}
+	}
+
+	return writeLine(w, struct {
+		Gas         uint64        `json:"gas"`
+		Failed      bool          `json:"failed"`
+		ReturnValue hexutil.Bytes `json:"returnValue"`
+	}{result.Gas, result.Failed, result.ReturnValue})
+}
+
+// writeTraceResult writes the trace result of the transaction on a single line.
+func writeTraceResult(w io.Writer, trace *evmtypes.QueryTraceBlockStreamResponse) error {
+	result := struct {
+		Result json.RawMessage `json:"result,omitempty"`
+		Error  string          `json:"error,omitempty"`
+	}{Error: trace.Error}
+	if trace.Error == "" {
+		result.Result = trace.Data
+	}
+	return writeLine(w, result)
+}
+
+// writeLine writes the value as a compact JSON line.
+func writeLine(w io.Writer, v interface{}) error {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(bz, '\n'))
+	return err
+}
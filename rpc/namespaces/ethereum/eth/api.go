@@ -2,7 +2,6 @@ package eth
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -41,8 +40,12 @@ type EthereumAPI interface {
 	GetTransactionByHash(hash common.Hash) (*rpctypes.RPCTransaction, error)
 	GetTransactionCount(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Uint64, error)
 	GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error)
+	GetReceiptProof(hash common.Hash) (*rpctypes.ReceiptProofResult, error)
 	GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
 	GetTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
+	GetRawTransactionByHash(hash common.Hash) (hexutil.Bytes, error)
+	GetRawTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (hexutil.Bytes, error)
+	GetRawTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (hexutil.Bytes, error)
 	// eth_getBlockReceipts
 
 	// Writing Transactions
@@ -67,14 +70,16 @@ type EthereumAPI interface {
 	//
 	// Allows developers to read data from the blockchain which includes executing
 	// smart contracts. However, no data is published to the Ethereum network.
-	Call(args evmtypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, override *rpctypes.StateOverride) (hexutil.Bytes, error)
+	Call(args evmtypes.TransactionArgs, blockNrOrHash rpctypes.BlockNumberOrHash, override *rpctypes.StateOverride, blockOverrides *rpctypes.BlockOverrides) (hexutil.Bytes, error)
+	CreateAccessList(args evmtypes.TransactionArgs, blockNrOrHash *rpctypes.BlockNumberOrHash, overrides *rpctypes.StateOverride) (*rpctypes.AccessListResult, error)
+	SimulateV1(opts evmtypes.SimOpts, blockNrOrHash *rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error)
 
 	// Chain Information
 	//
 	// Returns information on the Ethereum network and internal settings.
 	ProtocolVersion() hexutil.Uint
 	GasPrice() (*hexutil.Big, error)
-	EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber) (hexutil.Uint64, error)
+	EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber, overrides *rpctypes.StateOverride) (hexutil.Uint64, error)
 	FeeHistory(blockCount, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error)
 	MaxPriorityFeePerGas() (*hexutil.Big, error)
 	ChainId() (*hexutil.Big, error)
@@ -185,6 +190,13 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 	return e.backend.GetTransactionReceipt(hash)
 }
 
+// GetReceiptProof returns the receipt of the transaction identified by hash
+// along with the Merkle-Patricia proof of its inclusion in the receipts root.
+func (e *PublicAPI) GetReceiptProof(hash common.Hash) (*rpctypes.ReceiptProofResult, error) {
+	e.logger.Debug("eth_getReceiptProof", "hash", hash.Hex())
+	return e.backend.GetReceiptProof(hash)
+}
+
 // GetBlockTransactionCountByHash returns the number of transactions in the block identified by hash.
 func (e *PublicAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint {
 	e.logger.Debug("eth_getBlockTransactionCountByHash", "hash", hash.Hex())
@@ -209,6 +221,24 @@ func (e *PublicAPI) GetTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockN
 	return e.backend.GetTransactionByBlockNumberAndIndex(blockNum, idx)
 }
 
+// GetRawTransactionByHash returns the EIP-2718 envelope of the transaction identified by hash.
+func (e *PublicAPI) GetRawTransactionByHash(hash common.Hash) (hexutil.Bytes, error) {
+	e.logger.Debug("eth_getRawTransactionByHash", "hash", hash.Hex())
+	return e.backend.GetRawTransactionByHash(hash)
+}
+
+// GetRawTransactionByBlockHashAndIndex returns the EIP-2718 envelope of the transaction identified by hash and index.
+func (e *PublicAPI) GetRawTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (hexutil.Bytes, error) {
+	e.logger.Debug("eth_getRawTransactionByBlockHashAndIndex", "hash", hash.Hex(), "index", idx)
+	return e.backend.GetRawTransactionByBlockHashAndIndex(hash, idx)
+}
+
+// GetRawTransactionByBlockNumberAndIndex returns the EIP-2718 envelope of the transaction identified by number and index.
+func (e *PublicAPI) GetRawTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (hexutil.Bytes, error) {
+	e.logger.Debug("eth_getRawTransactionByBlockNumberAndIndex", "number", blockNum, "index", idx)
+	return e.backend.GetRawTransactionByBlockNumberAndIndex(blockNum, idx)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 ///                           Write Txs					                            ///
 ///////////////////////////////////////////////////////////////////////////////
@@ -270,19 +300,15 @@ func (e *PublicAPI) GetProof(address common.Address,
 func (e *PublicAPI) Call(args evmtypes.TransactionArgs,
 	blockNrOrHash rpctypes.BlockNumberOrHash,
 	override *rpctypes.StateOverride,
+	blockOverrides *rpctypes.BlockOverrides,
 ) (hexutil.Bytes, error) {
 	e.logger.Debug("eth_call", "args", args.String(), "block number or hash", blockNrOrHash)
 
-	if override != nil {
-		e.logger.Debug("eth_call", "error", "overrides are unsupported in call queries")
-		return nil, fmt.Errorf("overrides are unsupported in call queries")
-	}
-
 	blockNum, err := e.backend.BlockNumberFromTendermint(blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
-	data, err := e.backend.DoCall(args, blockNum)
+	data, err := e.backend.DoCall(args, blockNum, override, blockOverrides)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -290,6 +316,44 @@ func (e *PublicAPI) Call(args evmtypes.TransactionArgs,
 	return (hexutil.Bytes)(data.Ret), nil
 }
 
+// CreateAccessList creates an EIP-2930 type AccessList for the given transaction.
+// Reexec and blockNumberOrHash can be specified to create the accessList on top of a certain state.
+func (e *PublicAPI) CreateAccessList(
+	args evmtypes.TransactionArgs,
+	blockNrOrHash *rpctypes.BlockNumberOrHash,
+	overrides *rpctypes.StateOverride,
+) (*rpctypes.AccessListResult, error) {
+	e.logger.Debug("eth_createAccessList", "args", args.String(), "block number or hash", blockNrOrHash)
+
+	blockNum := rpctypes.EthPendingBlockNumber
+	if blockNrOrHash != nil {
+		var err error
+		blockNum, err = e.backend.BlockNumberFromTendermint(*blockNrOrHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return e.backend.CreateAccessList(args, blockNum, overrides)
+}
+
+// SimulateV1 executes a series of calls on top of a sequence of simulated
+// blocks, carrying over the state changes between them.
+func (e *PublicAPI) SimulateV1(opts evmtypes.SimOpts, blockNrOrHash *rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	e.logger.Debug("eth_simulateV1", "blocks", len(opts.BlockStateCalls), "block number or hash", blockNrOrHash)
+
+	blockNum := rpctypes.EthLatestBlockNumber
+	if blockNrOrHash != nil {
+		var err error
+		blockNum, err = e.backend.BlockNumberFromTendermint(*blockNrOrHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return e.backend.SimulateV1(opts, blockNum)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 ///                           Event Logs													          ///
 ///////////////////////////////////////////////////////////////////////////////
@@ -312,9 +376,13 @@ func (e *PublicAPI) GasPrice() (*hexutil.Big, error) {
 }
 
 // EstimateGas returns an estimate of gas usage for the given smart contract call.
-func (e *PublicAPI) EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber) (hexutil.Uint64, error) {
+func (e *PublicAPI) EstimateGas(
+	args evmtypes.TransactionArgs,
+	blockNrOptional *rpctypes.BlockNumber,
+	overrides *rpctypes.StateOverride,
+) (hexutil.Uint64, error) {
 	e.logger.Debug("eth_estimateGas")
-	return e.backend.EstimateGas(args, blockNrOptional)
+	return e.backend.EstimateGas(args, blockNrOptional, overrides)
 }
 
 func (e *PublicAPI) FeeHistory(blockCount,
@@ -34,6 +34,11 @@ var (
 		sdk.EventTypeMessage,
 		sdk.AttributeKeyModule, evmtypes.ModuleName)).String()
 	headerEvents = cmttypes.QueryForEvent(cmttypes.EventNewBlockHeader).String()
+
+	// eventSystems holds the event systems of each Tendermint WS client, to
+	// subscribe again once it reconnects, until it's stopped
+	eventSystems    = make(map[*rpcclient.WSClient][]*EventSystem)
+	eventSystemsMux sync.Mutex
 )
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -81,11 +86,59 @@ func NewEventSystem(logger log.Logger, tmWSClient *rpcclient.WSClient) *EventSys
 		eventBus:   pubsub.NewEventBus(),
 	}
 
+	if tmWSClient != nil {
+		eventSystemsMux.Lock()
+		if _, ok := eventSystems[tmWSClient]; !ok {
+			go forgetEventSystems(tmWSClient)
+		}
+		eventSystems[tmWSClient] = append(eventSystems[tmWSClient], es)
+		eventSystemsMux.Unlock()
+	}
+
 	go es.eventLoop()
 	go es.consumeEvents()
 	return es
 }
 
+// Resubscribe subscribes the event systems of the Tendermint WS client again
+// to the events of their filters, which are dropped once it reconnects, be it
+// to the same node or another one.
+func Resubscribe(tmWSClient *rpcclient.WSClient) {
+	eventSystemsMux.Lock()
+	systems := eventSystems[tmWSClient]
+	eventSystemsMux.Unlock()
+
+	for _, es := range systems {
+		es.resubscribe()
+	}
+}
+
+// forgetEventSystems drops the event systems of the Tendermint WS client once
+// it's stopped, be it replaced by a new client, so that they don't leak.
+func forgetEventSystems(tmWSClient *rpcclient.WSClient) {
+	<-tmWSClient.Quit()
+
+	eventSystemsMux.Lock()
+	delete(eventSystems, tmWSClient)
+	eventSystemsMux.Unlock()
+}
+
+// resubscribe subscribes again to the events of the installed filters
+func (es *EventSystem) resubscribe() {
+	es.indexMux.RLock()
+	topics := make([]string, 0, len(es.topicChans))
+	for topic := range es.topicChans {
+		topics = append(topics, topic)
+	}
+	es.indexMux.RUnlock()
+
+	for _, topic := range topics {
+		if err := es.tmWSClient.Subscribe(context.Background(), topic); err != nil {
+			es.logger.Error("failed to resubscribe to query", "query", topic, "error", err.Error())
+		}
+	}
+}
+
 // WithContext sets a new context to the EventSystem. This is required to set a timeout context when
 // a new filter is intantiated.
 func (es *EventSystem) WithContext(ctx context.Context) {
@@ -2,6 +2,8 @@ package filters
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -10,8 +12,11 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
 
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 
 	"github.com/cosmos/evm/rpc/ethereum/pubsub"
 
@@ -72,3 +77,39 @@ func TestFilterSystem(t *testing.T) {
 		t.Error("expect topic channel unchanged")
 	}
 }
+
+func TestEventSystemsForgotten(t *testing.T) {
+	// a websocket server accepting the connection of the client
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	tmWSClient, err := rpcclient.NewWS(srv.URL, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, tmWSClient.Start())
+
+	es := NewEventSystem(log.NewTestLogger(t), tmWSClient)
+	eventSystemsMux.Lock()
+	require.Equal(t, []*EventSystem{es}, eventSystems[tmWSClient])
+	eventSystemsMux.Unlock()
+
+	// the event systems of the client are dropped once it's stopped
+	require.NoError(t, tmWSClient.Stop())
+	require.Eventually(t, func() bool {
+		eventSystemsMux.Lock()
+		defer eventSystemsMux.Unlock()
+		_, ok := eventSystems[tmWSClient]
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
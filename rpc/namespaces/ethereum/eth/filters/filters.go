@@ -89,6 +89,8 @@ func newFilter(logger log.Logger, backend Backend, criteria filters.FilterCriter
 
 const (
 	maxToOverhang = 600
+	// maxTopics is the maximum number of topics of a log
+	maxTopics = 4
 )
 
 // Logs searches the blockchain for matching log entries, returning all from the
@@ -141,7 +143,14 @@ func (f *Filter) Logs(_ context.Context, logLimit int, blockLimit int64) ([]*eth
 		f.criteria.ToBlock = big.NewInt(1)
 	}
 
-	if f.criteria.ToBlock.Int64()-f.criteria.FromBlock.Int64() > blockLimit {
+	// the blocks whose logs are indexed are looked up in the log index instead
+	// of being scanned, so they don't count towards the block limit
+	indexedFrom, indexedTo, indexed := f.indexedRange(f.criteria.FromBlock.Int64(), f.criteria.ToBlock.Int64())
+	distance := f.criteria.ToBlock.Int64() - f.criteria.FromBlock.Int64()
+	if indexed {
+		distance -= indexedTo - indexedFrom + 1
+	}
+	if distance > blockLimit {
 		return nil, fmt.Errorf("maximum [from, to] blocks distance: %d", blockLimit)
 	}
 
@@ -156,6 +165,16 @@ func (f *Filter) Logs(_ context.Context, logLimit int, blockLimit int64) ([]*eth
 	to := f.criteria.ToBlock.Int64()
 
 	for height := from; height <= to; height++ {
+		if indexed && height == indexedFrom {
+			indexed, err := f.indexedLogs(indexedFrom, min(indexedTo, to), logLimit-len(logs))
+			if err != nil {
+				return nil, err
+			}
+			logs = append(logs, indexed...)
+			height = indexedTo
+			continue
+		}
+
 		blockRes, err := f.backend.TendermintBlockResultByNumber(&height)
 		if err != nil {
 			f.logger.Debug("failed to fetch block result from Tendermint", "height", height, "error", err.Error())
@@ -181,12 +200,97 @@ func (f *Filter) Logs(_ context.Context, logLimit int, blockLimit int64) ([]*eth
 	return logs, nil
 }
 
+// indexedRange returns the part of the [from, to] range whose logs can be
+// looked up in the log index, and false if there is none. The log index is
+// only used if the filter has address or topic criteria.
+func (f *Filter) indexedRange(from, to int64) (int64, int64, bool) {
+	if !f.hasIndexedCriteria() {
+		return 0, 0, false
+	}
+
+	first, last, err := f.backend.LogIndexRange()
+	if err != nil {
+		if !errors.Is(err, types.ErrLogIndexDisabled) {
+			f.logger.Debug("failed to fetch the log index range", "error", err.Error())
+		}
+		return 0, 0, false
+	}
+	if first < 0 {
+		return 0, 0, false
+	}
+
+	from, to = max(from, first), min(to, last)
+	if from > to {
+		// the range isn't indexed
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// hasIndexedCriteria returns true if the filter matches on addresses or
+// topics, which the log index can look up.
+func (f *Filter) hasIndexedCriteria() bool {
+	if len(f.criteria.Topics) > maxTopics {
+		return false
+	}
+	if len(f.criteria.Addresses) > 0 {
+		return true
+	}
+	for _, topicList := range f.criteria.Topics {
+		if len(topicList) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// indexedLogs returns the logs matching the filter criteria from the blocks
+// from to to, included, only fetching the blocks that the log index reports
+// matching logs in. It fails if more than logLimit logs are found.
+func (f *Filter) indexedLogs(from, to int64, logLimit int) ([]*ethtypes.Log, error) {
+	locations, err := f.backend.GetLogLocations(f.criteria.Addresses, f.criteria.Topics, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the log index: %w", err)
+	}
+	if len(locations) > logLimit {
+		return nil, fmt.Errorf("query returned more than %d results", logLimit)
+	}
+
+	logs := []*ethtypes.Log{}
+	for i, loc := range locations {
+		if i > 0 && locations[i-1].Height == loc.Height {
+			// block already fetched
+			continue
+		}
+
+		height := loc.Height
+		blockRes, err := f.backend.TendermintBlockResultByNumber(&height)
+		if err != nil {
+			f.logger.Debug("failed to fetch block result from Tendermint", "height", height, "error", err.Error())
+			return nil, fmt.Errorf("failed to fetch block result from Tendermint: %w", err)
+		}
+
+		filtered, err := f.filterBlockLogs(blockRes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block by number %d: %w", height, err)
+		}
+		logs = append(logs, filtered...)
+	}
+	return logs, nil
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(blockRes *tmrpctypes.ResultBlockResults, bloom ethtypes.Bloom) ([]*ethtypes.Log, error) {
 	if !bloomFilter(bloom, f.criteria.Addresses, f.criteria.Topics) {
 		return []*ethtypes.Log{}, nil
 	}
 
+	return f.filterBlockLogs(blockRes)
+}
+
+// filterBlockLogs returns the logs of the block results matching the filter
+// criteria.
+func (f *Filter) filterBlockLogs(blockRes *tmrpctypes.ResultBlockResults) ([]*ethtypes.Log, error) {
 	logsList, err := backend.GetLogsFromBlockResults(blockRes)
 	if err != nil {
 		return []*ethtypes.Log{}, errors.Wrapf(err, "failed to fetch logs block number %d", blockRes.Height)
@@ -2,6 +2,7 @@ package filters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -12,11 +13,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 	comettypes "github.com/cometbft/cometbft/types"
 
 	filtermocks "github.com/cosmos/evm/rpc/namespaces/ethereum/eth/filters/mocks"
 	rpctypes "github.com/cosmos/evm/rpc/types"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
 
 	"cosmossdk.io/log"
 )
@@ -45,6 +49,14 @@ func (m *MockBackend) BloomStatus() (uint64, uint64) {
 	panic("implement me")
 }
 
+func (m *MockBackend) LogIndexRange() (int64, int64, error) {
+	panic("implement me")
+}
+
+func (m *MockBackend) GetLogLocations([]common.Address, [][]common.Hash, int64, int64) ([]cosmosevmtypes.LogLocation, error) {
+	panic("implement me")
+}
+
 func (m *MockBackend) RPCFilterCap() int32 {
 	panic("implement me")
 }
@@ -185,8 +197,33 @@ func TestLogs(t *testing.T) {
 	}
 }
 
+// logsBlockResults returns the results of a block with one tx emitting the
+// logs
+func logsBlockResults(t *testing.T, height int64, logs ...*evmtypes.Log) *tmrpctypes.ResultBlockResults {
+	t.Helper()
+	attrs := make([]abci.EventAttribute, len(logs))
+	for i, txLog := range logs {
+		bz, err := json.Marshal(txLog)
+		require.NoError(t, err)
+		attrs[i] = abci.EventAttribute{Key: evmtypes.AttributeKeyTxLog, Value: string(bz)}
+	}
+	return &tmrpctypes.ResultBlockResults{
+		Height: height,
+		TxsResults: []*abci.ExecTxResult{
+			{Events: []abci.Event{{Type: evmtypes.EventTypeTxLog, Attributes: attrs}}},
+		},
+	}
+}
+
 func TestFilter(t *testing.T) {
 	logger := log.NewNopLogger()
+	address := common.HexToAddress("0x1")
+	topic := common.HexToHash("0x2")
+	matchingLog := &evmtypes.Log{Address: address.Hex(), Topics: []string{topic.Hex()}, BlockNumber: 70, Index: 1}
+	otherLog := &evmtypes.Log{Address: common.HexToAddress("0x3").Hex(), Topics: []string{topic.Hex()}, BlockNumber: 70}
+	height := int64(70)
+	var bloom ethtypes.Bloom
+	bloom.Add(topic.Bytes())
 	testCases := []struct {
 		name         string
 		filter       filters.FilterCriteria
@@ -202,6 +239,83 @@ func TestFilter(t *testing.T) {
 			},
 			expErr: "invalid block range params",
 		},
+		{
+			name:   "block range over limit without log index returns error",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(100), Addresses: []common.Address{address}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(100)}, nil)
+				b.EXPECT().LogIndexRange().Return(0, 0, rpctypes.ErrLogIndexDisabled)
+			},
+			expErr: "maximum [from, to] blocks distance",
+		},
+		{
+			name:   "block range over limit with log index",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(100), Addresses: []common.Address{address}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(100)}, nil)
+				b.EXPECT().LogIndexRange().Return(1, 100, nil)
+				b.EXPECT().GetLogLocations([]common.Address{address}, [][]common.Hash(nil), int64(1), int64(100)).
+					Return([]cosmosevmtypes.LogLocation{{Height: height, LogIndex: 1}}, nil)
+				b.EXPECT().TendermintBlockResultByNumber(&height).Return(logsBlockResults(t, height, otherLog, matchingLog), nil)
+			},
+			expLogs: evmtypes.LogsToEthereum([]*evmtypes.Log{matchingLog}),
+		},
+		{
+			name:   "log index only covering part of the range",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(70), ToBlock: big.NewInt(100), Topics: [][]common.Hash{{topic}}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(100)}, nil)
+				b.EXPECT().LogIndexRange().Return(71, 100, nil)
+				b.EXPECT().TendermintBlockResultByNumber(&height).Return(logsBlockResults(t, height, otherLog), nil)
+				b.EXPECT().BlockBloom(mock.Anything).Return(bloom, nil)
+				b.EXPECT().GetLogLocations([]common.Address(nil), [][]common.Hash{{topic}}, int64(71), int64(100)).
+					Return(nil, nil)
+			},
+			expLogs: evmtypes.LogsToEthereum([]*evmtypes.Log{otherLog}),
+		},
+		{
+			name:   "log index behind the range",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(20), ToBlock: big.NewInt(30), Addresses: []common.Address{address}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(30)}, nil)
+				b.EXPECT().LogIndexRange().Return(1, 10, nil)
+				b.EXPECT().TendermintBlockResultByNumber(mock.Anything).Return(&tmrpctypes.ResultBlockResults{}, nil).Times(11)
+				b.EXPECT().BlockBloom(mock.Anything).Return(ethtypes.Bloom{}, nil).Times(11)
+			},
+			expLogs: []*ethtypes.Log{},
+		},
+		{
+			name:   "log index disabled from the first block",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(3), Addresses: []common.Address{address}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(3)}, nil)
+				b.EXPECT().LogIndexRange().Return(0, 0, rpctypes.ErrLogIndexDisabled)
+				b.EXPECT().TendermintBlockResultByNumber(mock.Anything).Return(&tmrpctypes.ResultBlockResults{}, nil).Times(3)
+				b.EXPECT().BlockBloom(mock.Anything).Return(ethtypes.Bloom{}, nil).Times(3)
+			},
+			expLogs: []*ethtypes.Log{},
+		},
+		{
+			name:   "no criteria from the first block",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(3)},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(3)}, nil)
+				b.EXPECT().TendermintBlockResultByNumber(mock.Anything).Return(&tmrpctypes.ResultBlockResults{}, nil).Times(3)
+				b.EXPECT().BlockBloom(mock.Anything).Return(ethtypes.Bloom{}, nil).Times(3)
+			},
+			expLogs: []*ethtypes.Log{},
+		},
+		{
+			name:   "log index results over limit returns error",
+			filter: filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(100), Addresses: []common.Address{address}},
+			expectations: func(b *filtermocks.Backend) {
+				b.EXPECT().HeaderByNumber(rpctypes.EthLatestBlockNumber).Return(&ethtypes.Header{Number: big.NewInt(100)}, nil)
+				b.EXPECT().LogIndexRange().Return(1, 100, nil)
+				b.EXPECT().GetLogLocations([]common.Address{address}, [][]common.Hash(nil), int64(1), int64(100)).
+					Return(make([]cosmosevmtypes.LogLocation, 16), nil)
+			},
+			expErr: "query returned more than 15 results",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	cosmosevmtypes "github.com/cosmos/evm/types"
+
 	rpctypes "github.com/cosmos/evm/rpc/types"
 
 	types "github.com/ethereum/go-ethereum/core/types"
@@ -198,6 +200,67 @@ func (_c *Backend_GetBlockByNumber_Call) RunAndReturn(run func(rpctypes.BlockNum
 	return _c
 }
 
+// GetLogLocations provides a mock function with given fields: addresses, topics, fromBlock, toBlock
+func (_m *Backend) GetLogLocations(addresses []common.Address, topics [][]common.Hash, fromBlock int64, toBlock int64) ([]cosmosevmtypes.LogLocation, error) {
+	ret := _m.Called(addresses, topics, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogLocations")
+	}
+
+	var r0 []cosmosevmtypes.LogLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]common.Address, [][]common.Hash, int64, int64) ([]cosmosevmtypes.LogLocation, error)); ok {
+		return rf(addresses, topics, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func([]common.Address, [][]common.Hash, int64, int64) []cosmosevmtypes.LogLocation); ok {
+		r0 = rf(addresses, topics, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cosmosevmtypes.LogLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]common.Address, [][]common.Hash, int64, int64) error); ok {
+		r1 = rf(addresses, topics, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Backend_GetLogLocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogLocations'
+type Backend_GetLogLocations_Call struct {
+	*mock.Call
+}
+
+// GetLogLocations is a helper method to define mock.On call
+//   - addresses []common.Address
+//   - topics [][]common.Hash
+//   - fromBlock int64
+//   - toBlock int64
+func (_e *Backend_Expecter) GetLogLocations(addresses interface{}, topics interface{}, fromBlock interface{}, toBlock interface{}) *Backend_GetLogLocations_Call {
+	return &Backend_GetLogLocations_Call{Call: _e.mock.On("GetLogLocations", addresses, topics, fromBlock, toBlock)}
+}
+
+func (_c *Backend_GetLogLocations_Call) Run(run func(addresses []common.Address, topics [][]common.Hash, fromBlock int64, toBlock int64)) *Backend_GetLogLocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]common.Address), args[1].([][]common.Hash), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *Backend_GetLogLocations_Call) Return(_a0 []cosmosevmtypes.LogLocation, _a1 error) *Backend_GetLogLocations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Backend_GetLogLocations_Call) RunAndReturn(run func([]common.Address, [][]common.Hash, int64, int64) ([]cosmosevmtypes.LogLocation, error)) *Backend_GetLogLocations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLogs provides a mock function with given fields: blockHash
 func (_m *Backend) GetLogs(blockHash common.Hash) ([][]*types.Log, error) {
 	ret := _m.Called(blockHash)
@@ -430,6 +493,68 @@ func (_c *Backend_HeaderByNumber_Call) RunAndReturn(run func(rpctypes.BlockNumbe
 	return _c
 }
 
+// LogIndexRange provides a mock function with no fields
+func (_m *Backend) LogIndexRange() (int64, int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogIndexRange")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (int64, int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() int64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Backend_LogIndexRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogIndexRange'
+type Backend_LogIndexRange_Call struct {
+	*mock.Call
+}
+
+// LogIndexRange is a helper method to define mock.On call
+func (_e *Backend_Expecter) LogIndexRange() *Backend_LogIndexRange_Call {
+	return &Backend_LogIndexRange_Call{Call: _e.mock.On("LogIndexRange")}
+}
+
+func (_c *Backend_LogIndexRange_Call) Run(run func()) *Backend_LogIndexRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Backend_LogIndexRange_Call) Return(_a0 int64, _a1 int64, _a2 error) *Backend_LogIndexRange_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Backend_LogIndexRange_Call) RunAndReturn(run func() (int64, int64, error)) *Backend_LogIndexRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RPCBlockRangeCap provides a mock function with no fields
 func (_m *Backend) RPCBlockRangeCap() int32 {
 	ret := _m.Called()
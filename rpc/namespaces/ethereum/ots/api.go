@@ -0,0 +1,400 @@
+package ots
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+
+	cosmosevmrpc "github.com/cosmos/evm/rpc"
+	"github.com/cosmos/evm/rpc/backend"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	"github.com/cosmos/evm/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+const (
+	// Namespace is the JSON-RPC namespace of the Otterscan APIs.
+	Namespace = "ots"
+
+	// APILevel is the version of the Otterscan APIs implemented, as expected by
+	// the Otterscan UI.
+	APILevel = 8
+)
+
+func init() {
+	if err := cosmosevmrpc.RegisterAPINamespace(Namespace, CreateAPIs); err != nil {
+		panic(err)
+	}
+}
+
+// CreateAPIs is the API creator of the `ots` namespace. It requires the custom
+// EVM tx indexer to search the transactions of an address, which only finds
+// the transactions of the blocks indexed with the address indexes.
+func CreateAPIs(ctx *server.Context,
+	clientCtx client.Context,
+	_ *rpcclient.WSClient,
+	allowUnprotectedTxs bool,
+	indexer types.EVMTxIndexer,
+) []rpc.API {
+	// ELYS MODIFICATION: Use global RPC configuration
+	evmBackend := backend.NewBackend(ctx, ctx.Logger, clientCtx, allowUnprotectedTxs, indexer,
+		cosmosevmrpc.GetBankKeeper(), cosmosevmrpc.GetBaseDenom(), cosmosevmrpc.GetQueryContextFactory())
+	return []rpc.API{
+		{
+			Namespace: Namespace,
+			Version:   "1.0",
+			Service:   NewAPI(ctx, evmBackend),
+			Public:    true,
+		},
+	}
+}
+
+// Types of the internal operations returned by ots_getInternalOperations.
+const (
+	OpTransfer = iota
+	OpSelfDestruct
+	OpCreate
+	OpCreate2
+)
+
+// TransactionsWithReceipts is a page of the transactions of an address, along
+// with their receipts. The transactions are in descending order, the first
+// page holding the most recent ones.
+type TransactionsWithReceipts struct {
+	Txs       []*rpctypes.RPCTransaction `json:"txs"`
+	Receipts  []map[string]interface{}   `json:"receipts"`
+	FirstPage bool                       `json:"firstPage"`
+	LastPage  bool                       `json:"lastPage"`
+}
+
+// ContractCreator is the result of ots_getContractCreator.
+type ContractCreator struct {
+	Hash    common.Hash    `json:"hash"`
+	Creator common.Address `json:"creator"`
+}
+
+// InternalOperation is a value transfer, contract creation or self-destruct
+// performed by a nested call of a transaction.
+type InternalOperation struct {
+	Type  int            `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+}
+
+// TraceEntry is a call frame of the trace returned by ots_traceTransaction.
+type TraceEntry struct {
+	Type   string         `json:"type"`
+	Depth  int            `json:"depth"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value"`
+	Input  hexutil.Bytes  `json:"input"`
+	Output hexutil.Bytes  `json:"output"`
+}
+
+// BlockDetails is the result of ots_getBlockDetails.
+type BlockDetails struct {
+	Block     map[string]interface{} `json:"block"`
+	Issuance  Issuance               `json:"issuance"`
+	TotalFees *hexutil.Big           `json:"totalFees"`
+}
+
+// Issuance is the native currency issued by a block. Blocks don't issue any
+// EVM native currency, the staking rewards being minted by the Cosmos SDK.
+type Issuance struct {
+	BlockReward *hexutil.Big `json:"blockReward"`
+	UncleReward *hexutil.Big `json:"uncleReward"`
+	Issuance    *hexutil.Big `json:"issuance"`
+}
+
+// API is the collection of Otterscan APIs.
+type API struct {
+	ctx     *server.Context
+	logger  log.Logger
+	backend backend.EVMBackend
+}
+
+// NewAPI creates a new API definition for the Otterscan methods.
+func NewAPI(
+	ctx *server.Context,
+	backend backend.EVMBackend,
+) *API {
+	return &API{
+		ctx:     ctx,
+		logger:  ctx.Logger.With("module", "ots"),
+		backend: backend,
+	}
+}
+
+// GetApiLevel returns the version of the Otterscan APIs implemented by the node.
+func (a *API) GetApiLevel() uint64 { //nolint: revive
+	a.logger.Debug("ots_getApiLevel")
+	return APILevel
+}
+
+// SearchTransactionsBefore returns a page of the transactions sent from or to
+// the given address in the blocks before the given one, the most recent ones
+// first. A zero block number returns the most recent transactions. The
+// transactions reaching the address through internal calls aren't returned.
+func (a *API) SearchTransactionsBefore(address common.Address, blockNumber uint64, pageSize uint16) (*TransactionsWithReceipts, error) {
+	a.logger.Debug("ots_searchTransactionsBefore", "address", address, "block", blockNumber, "page size", pageSize)
+
+	fromBlock := int64(-1)
+	if blockNumber > 0 {
+		fromBlock = int64(blockNumber) - 1 //#nosec G115 -- int overflow is not a concern here
+	}
+	hashes, hasMore, err := a.backend.GetTransactionHashesByAddress(address, fromBlock, true, int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := a.transactionsWithReceipts(hashes)
+	if err != nil {
+		return nil, err
+	}
+	results.FirstPage = blockNumber == 0
+	results.LastPage = !hasMore
+	return results, nil
+}
+
+// SearchTransactionsAfter returns a page of the transactions sent from or to
+// the given address in the blocks after the given one, the most recent ones
+// first. A zero block number returns the oldest transactions. The
+// transactions reaching the address through internal calls aren't returned.
+func (a *API) SearchTransactionsAfter(address common.Address, blockNumber uint64, pageSize uint16) (*TransactionsWithReceipts, error) {
+	a.logger.Debug("ots_searchTransactionsAfter", "address", address, "block", blockNumber, "page size", pageSize)
+
+	fromBlock := int64(-1)
+	if blockNumber > 0 {
+		fromBlock = int64(blockNumber) + 1 //#nosec G115 -- int overflow is not a concern here
+	}
+	hashes, hasMore, err := a.backend.GetTransactionHashesByAddress(address, fromBlock, false, int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(hashes)
+
+	results, err := a.transactionsWithReceipts(hashes)
+	if err != nil {
+		return nil, err
+	}
+	results.FirstPage = !hasMore
+	results.LastPage = blockNumber == 0
+	return results, nil
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the transaction sent by the
+// given address with the given nonce, or nil if not found.
+func (a *API) GetTransactionBySenderAndNonce(address common.Address, nonce uint64) (*common.Hash, error) {
+	a.logger.Debug("ots_getTransactionBySenderAndNonce", "address", address, "nonce", nonce)
+	return a.backend.GetTransactionHashBySenderAndNonce(address, nonce)
+}
+
+// GetContractCreator returns the transaction that deployed the given contract
+// and its sender, or nil if the contract wasn't deployed by a transaction.
+// Contracts created by other contracts, with CREATE or CREATE2, aren't indexed
+// and return nil.
+func (a *API) GetContractCreator(address common.Address) (*ContractCreator, error) {
+	a.logger.Debug("ots_getContractCreator", "address", address)
+	hash, err := a.backend.GetContractCreationTxHash(address)
+	if err != nil || hash == nil {
+		return nil, err
+	}
+
+	tx, err := a.backend.GetTransactionByHash(*hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", hash.Hex())
+	}
+	return &ContractCreator{Hash: *hash, Creator: tx.From}, nil
+}
+
+// TraceTransaction returns the call frames of the given transaction, in
+// execution order.
+func (a *API) TraceTransaction(hash common.Hash) ([]*TraceEntry, error) {
+	a.logger.Debug("ots_traceTransaction", "hash", hash)
+	root, err := a.callTrace(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*TraceEntry{}
+	root.walk(0, func(frame *callFrame, depth int) bool {
+		entries = append(entries, &TraceEntry{
+			Type:   frame.Type,
+			Depth:  depth,
+			From:   frame.From,
+			To:     frame.To,
+			Value:  frame.Value,
+			Input:  frame.Input,
+			Output: frame.Output,
+		})
+		return true
+	})
+	return entries, nil
+}
+
+// GetInternalOperations returns the value transfers, contract creations and
+// self-destructs performed by the nested calls of the given transaction. The
+// operations of reverted calls are omitted.
+func (a *API) GetInternalOperations(hash common.Hash) ([]*InternalOperation, error) {
+	a.logger.Debug("ots_getInternalOperations", "hash", hash)
+	root, err := a.callTrace(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []*InternalOperation{}
+	root.walk(0, func(frame *callFrame, depth int) bool {
+		if frame.Error != "" {
+			return false
+		}
+		if depth == 0 {
+			return true
+		}
+		if op, ok := frame.internalOperation(); ok {
+			operations = append(operations, op)
+		}
+		return true
+	})
+	return operations, nil
+}
+
+// GetBlockDetails returns the header of the given block along with its
+// transaction count, issuance and total fees.
+func (a *API) GetBlockDetails(number rpctypes.BlockNumber) (*BlockDetails, error) {
+	a.logger.Debug("ots_getBlockDetails", "number", number)
+	block, err := a.backend.GetBlockByNumber(number, false)
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	height, ok := block["number"].(hexutil.Uint64)
+	if !ok {
+		return nil, fmt.Errorf("invalid block number type: %T", block["number"])
+	}
+	blockNumber := rpctypes.BlockNumber(height) //#nosec G115 -- int overflow is not a concern here
+	receipts, err := a.backend.GetBlockReceipts(rpctypes.BlockNumberOrHash{BlockNumber: &blockNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	totalFees, err := totalFees(receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	if txs, ok := block["transactions"].([]interface{}); ok {
+		block["transactionCount"] = len(txs)
+	}
+	delete(block, "transactions")
+	block["logsBloom"] = nil
+
+	return &BlockDetails{
+		Block: block,
+		Issuance: Issuance{
+			BlockReward: new(hexutil.Big),
+			UncleReward: new(hexutil.Big),
+			Issuance:    new(hexutil.Big),
+		},
+		TotalFees: (*hexutil.Big)(totalFees),
+	}, nil
+}
+
+// transactionsWithReceipts returns the given transactions along with their
+// receipts, which hold the timestamp of their block.
+func (a *API) transactionsWithReceipts(hashes []common.Hash) (*TransactionsWithReceipts, error) {
+	results := &TransactionsWithReceipts{
+		Txs:      make([]*rpctypes.RPCTransaction, 0, len(hashes)),
+		Receipts: make([]map[string]interface{}, 0, len(hashes)),
+	}
+	timestamps := make(map[int64]hexutil.Uint64)
+	for _, hash := range hashes {
+		tx, err := a.backend.GetTransactionByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		if tx == nil || tx.BlockNumber == nil {
+			return nil, fmt.Errorf("transaction %s not found", hash.Hex())
+		}
+
+		receipt, err := a.backend.GetTransactionReceipt(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		height := tx.BlockNumber.ToInt().Int64()
+		timestamp, ok := timestamps[height]
+		if !ok {
+			header, err := a.backend.HeaderByNumber(rpctypes.BlockNumber(height))
+			if err != nil {
+				return nil, err
+			}
+			timestamp = hexutil.Uint64(header.Time)
+			timestamps[height] = timestamp
+		}
+		receipt["timestamp"] = timestamp
+
+		results.Txs = append(results.Txs, tx)
+		results.Receipts = append(results.Receipts, receipt)
+	}
+	return results, nil
+}
+
+// callTrace returns the call frames of the given transaction.
+func (a *API) callTrace(hash common.Hash) (*callFrame, error) {
+	result, err := a.backend.TraceTransaction(hash, &evmtypes.TraceConfig{Tracer: "callTracer"})
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var root callFrame
+	if err := json.Unmarshal(bz, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// totalFees returns the sum of the fees paid by the transactions of the given
+// receipts.
+func totalFees(receipts []map[string]interface{}) (*big.Int, error) {
+	total := new(big.Int)
+	for _, receipt := range receipts {
+		bz, err := json.Marshal(receipt)
+		if err != nil {
+			return nil, err
+		}
+		var fees struct {
+			GasUsed           hexutil.Uint64 `json:"gasUsed"`
+			EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+		}
+		if err := json.Unmarshal(bz, &fees); err != nil {
+			return nil, err
+		}
+		if fees.EffectiveGasPrice == nil {
+			continue
+		}
+		fee := new(big.Int).SetUint64(uint64(fees.GasUsed))
+		total.Add(total, fee.Mul(fee, fees.EffectiveGasPrice.ToInt()))
+	}
+	return total, nil
+}
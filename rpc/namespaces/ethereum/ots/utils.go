@@ -0,0 +1,56 @@
+package ots
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// callFrame is a call frame of the callTracer output.
+type callFrame struct {
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value"`
+	Input  hexutil.Bytes  `json:"input"`
+	Output hexutil.Bytes  `json:"output"`
+	Error  string         `json:"error"`
+	Calls  []*callFrame   `json:"calls"`
+}
+
+// walk visits the call frame and its nested calls depth-first, in execution
+// order. The nested calls of a frame are skipped if visit returns false.
+func (f *callFrame) walk(depth int, visit func(frame *callFrame, depth int) bool) {
+	if !visit(f, depth) {
+		return
+	}
+	for _, call := range f.Calls {
+		call.walk(depth+1, visit)
+	}
+}
+
+// internalOperation returns the operation performed by the nested call frame,
+// if any: a value transfer, a contract creation or a self-destruct.
+func (f *callFrame) internalOperation() (*InternalOperation, bool) {
+	op := &InternalOperation{From: f.From, To: f.To, Value: f.Value}
+	if op.Value == nil {
+		op.Value = new(hexutil.Big)
+	}
+
+	switch f.Type {
+	case vm.CALL.String():
+		if op.Value.ToInt().Sign() == 0 {
+			return nil, false
+		}
+		op.Type = OpTransfer
+	case vm.CREATE.String():
+		op.Type = OpCreate
+	case vm.CREATE2.String():
+		op.Type = OpCreate2
+	case vm.SELFDESTRUCT.String():
+		op.Type = OpSelfDestruct
+	default:
+		return nil, false
+	}
+	return op, true
+}
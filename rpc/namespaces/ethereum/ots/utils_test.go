@@ -0,0 +1,72 @@
+package ots
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalOperations(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		contract = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		other    = common.HexToAddress("0x1000000000000000000000000000000000000003")
+		created  = common.HexToAddress("0x1000000000000000000000000000000000000004")
+	)
+
+	var root callFrame
+	err := json.Unmarshal([]byte(`{
+		"type": "CALL", "from": "`+sender.Hex()+`", "to": "`+contract.Hex()+`", "value": "0x10",
+		"calls": [
+			{"type": "CALL", "from": "`+contract.Hex()+`", "to": "`+other.Hex()+`", "value": "0x1"},
+			{"type": "STATICCALL", "from": "`+contract.Hex()+`", "to": "`+other.Hex()+`"},
+			{"type": "CALL", "from": "`+contract.Hex()+`", "to": "`+other.Hex()+`", "value": "0x2", "error": "execution reverted",
+				"calls": [{"type": "CALL", "from": "`+other.Hex()+`", "to": "`+sender.Hex()+`", "value": "0x2"}]},
+			{"type": "CREATE2", "from": "`+contract.Hex()+`", "to": "`+created.Hex()+`", "value": "0x0",
+				"calls": [{"type": "SELFDESTRUCT", "from": "`+created.Hex()+`", "to": "`+sender.Hex()+`", "value": "0x0"}]}
+		]
+	}`), &root)
+	require.NoError(t, err)
+
+	var depths []int
+	root.walk(0, func(_ *callFrame, depth int) bool {
+		depths = append(depths, depth)
+		return true
+	})
+	require.Equal(t, []int{0, 1, 1, 1, 2, 1, 2}, depths)
+
+	var operations []*InternalOperation
+	root.walk(0, func(frame *callFrame, depth int) bool {
+		if frame.Error != "" {
+			return false
+		}
+		if op, ok := frame.internalOperation(); ok && depth > 0 {
+			operations = append(operations, op)
+		}
+		return true
+	})
+	bz, err := json.Marshal(operations)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"type": `+fmt.Sprint(OpTransfer)+`, "from": "`+contract.Hex()+`", "to": "`+other.Hex()+`", "value": "0x1"},
+		{"type": `+fmt.Sprint(OpCreate2)+`, "from": "`+contract.Hex()+`", "to": "`+created.Hex()+`", "value": "0x0"},
+		{"type": `+fmt.Sprint(OpSelfDestruct)+`, "from": "`+created.Hex()+`", "to": "`+sender.Hex()+`", "value": "0x0"}
+	]`, string(bz))
+}
+
+func TestTotalFees(t *testing.T) {
+	price := hexutil.Big(*big.NewInt(10))
+	receipts := []map[string]interface{}{
+		{"gasUsed": hexutil.Uint64(21000), "effectiveGasPrice": &price},
+		{"gasUsed": hexutil.Uint64(50000), "effectiveGasPrice": hexutil.Big(*big.NewInt(2))},
+	}
+
+	total, err := totalFees(receipts)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(21000*10+50000*2), total)
+}
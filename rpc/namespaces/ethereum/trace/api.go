@@ -0,0 +1,244 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/cosmos/evm/rpc/backend"
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+// Trace types that can be requested to trace_call and trace_replayTransaction.
+const (
+	TraceTypeTrace     = "trace"
+	TraceTypeStateDiff = "stateDiff"
+	TraceTypeVMTrace   = "vmTrace"
+)
+
+// Native tracers used to build the Parity traces.
+const (
+	callTracer     = "callTracer"
+	flatCallTracer = "flatCallTracer"
+	muxTracer      = "muxTracer"
+	prestateTracer = "prestateTracer"
+	parityVMTracer = "parityVmTracer"
+)
+
+// maxFilterBlocks is the maximum number of blocks replayed by trace_filter.
+// Every block of the range is re-executed, so it's much lower than the block
+// range cap of eth_getLogs.
+const maxFilterBlocks = 100
+
+// TraceFilterArgs defines the arguments of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *rpctypes.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpctypes.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address      `json:"fromAddress"`
+	ToAddress   []common.Address      `json:"toAddress"`
+	After       *uint64               `json:"after"`
+	Count       *uint64               `json:"count"`
+}
+
+// TraceResults is the result of trace_call and trace_replayTransaction. The
+// fields of the trace types that were not requested are empty.
+type TraceResults struct {
+	Output          hexutil.Bytes                   `json:"output"`
+	StateDiff       map[common.Address]*AccountDiff `json:"stateDiff"`
+	Trace           []json.RawMessage               `json:"trace"`
+	VMTrace         json.RawMessage                 `json:"vmTrace"`
+	TransactionHash *common.Hash                    `json:"transactionHash,omitempty"`
+}
+
+// API is the collection of Parity/OpenEthereum compatible tracing APIs.
+type API struct {
+	ctx     *server.Context
+	logger  log.Logger
+	backend backend.EVMBackend
+}
+
+// NewAPI creates a new API definition for the Parity tracing methods.
+func NewAPI(
+	ctx *server.Context,
+	backend backend.EVMBackend,
+) *API {
+	return &API{
+		ctx:     ctx,
+		logger:  ctx.Logger.With("module", "trace"),
+		backend: backend,
+	}
+}
+
+// Transaction returns the flat call traces of the given transaction.
+func (a *API) Transaction(hash common.Hash) ([]json.RawMessage, error) {
+	a.logger.Debug("trace_transaction", "hash", hash)
+	result, err := a.backend.TraceTransaction(hash, &evmtypes.TraceConfig{Tracer: flatCallTracer})
+	if err != nil {
+		return nil, err
+	}
+
+	var traces []json.RawMessage
+	if err := remarshal(result, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// Block returns the flat call traces of all the transactions of the given block.
+func (a *API) Block(blockNr rpctypes.BlockNumber) ([]json.RawMessage, error) {
+	a.logger.Debug("trace_block", "number", blockNr)
+	return a.blockTraces(blockNr)
+}
+
+// Filter returns the flat call traces of the given block range that match the
+// given sender and recipient addresses.
+func (a *API) Filter(args TraceFilterArgs) ([]json.RawMessage, error) {
+	a.logger.Debug("trace_filter", "args", args)
+
+	latest, err := a.backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	from, err := resolveBlockNumber(args.FromBlock, int64(latest)) //#nosec G115 -- int overflow is not a concern here
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveBlockNumber(args.ToBlock, int64(latest)) //#nosec G115 -- int overflow is not a concern here
+	if err != nil {
+		return nil, err
+	}
+	if from < 1 {
+		// genesis is not traceable
+		from = 1
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: from %d to %d", from, to)
+	}
+	if to-from+1 > maxFilterBlocks {
+		return nil, fmt.Errorf("maximum [from, to] blocks distance: %d", maxFilterBlocks)
+	}
+
+	var (
+		after   uint64
+		results = []json.RawMessage{}
+	)
+	if args.After != nil {
+		after = *args.After
+	}
+	for height := from; height <= to; height++ {
+		traces, err := a.blockTraces(rpctypes.BlockNumber(height))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range traces {
+			match, err := matchTrace(trace, args.FromAddress, args.ToAddress)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+			if after > 0 {
+				after--
+				continue
+			}
+			results = append(results, trace)
+			if args.Count != nil && uint64(len(results)) >= *args.Count {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
+
+// Call executes the given call on top of the state of the given block and
+// returns the requested trace types.
+func (a *API) Call(args evmtypes.TransactionArgs, traceTypes []string, blockNrOrHash *rpctypes.BlockNumberOrHash) (*TraceResults, error) {
+	a.logger.Debug("trace_call", "args", args.String(), "types", traceTypes)
+	config, err := traceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockNrOrHash == nil {
+		latest := rpctypes.EthLatestBlockNumber
+		blockNrOrHash = &rpctypes.BlockNumberOrHash{BlockNumber: &latest}
+	}
+	blockNr, err := a.backend.BlockNumberFromTendermint(*blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.backend.TraceCall(args, blockNr, config, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return traceResults(result, traceTypes)
+}
+
+// ReplayTransaction replays the given transaction and returns the requested
+// trace types.
+func (a *API) ReplayTransaction(hash common.Hash, traceTypes []string) (*TraceResults, error) {
+	a.logger.Debug("trace_replayTransaction", "hash", hash, "types", traceTypes)
+	config, err := traceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.backend.TraceTransaction(hash, config)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := traceResults(result, traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	results.TransactionHash = &hash
+	return results, nil
+}
+
+// blockTraces returns the flat call traces of all the transactions of the
+// given block.
+func (a *API) blockTraces(blockNr rpctypes.BlockNumber) ([]json.RawMessage, error) {
+	if blockNr == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+
+	resBlock, err := a.backend.TendermintBlockByNumber(blockNr)
+	if err != nil {
+		a.logger.Debug("get block failed", "number", blockNr, "error", err.Error())
+		return nil, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNr)
+	}
+
+	results, err := a.backend.TraceBlock(rpctypes.BlockNumber(resBlock.Block.Height), &evmtypes.TraceConfig{Tracer: flatCallTracer}, resBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := []json.RawMessage{}
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction of block %d: %s", resBlock.Block.Height, result.Error)
+		}
+
+		var txTraces []json.RawMessage
+		if err := remarshal(result.Result, &txTraces); err != nil {
+			return nil, err
+		}
+		traces = append(traces, txTraces...)
+	}
+	return traces, nil
+}
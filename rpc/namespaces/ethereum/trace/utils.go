@@ -0,0 +1,275 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// AccountDiff is the Parity representation of the changes of an account. Each
+// field is either "=" if unchanged, or an object keyed by "+" (created), "-"
+// (deleted) or "*" (changed from/to).
+type AccountDiff struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// diffUnchanged is the Parity diff of a field that is not changed.
+const diffUnchanged = "="
+
+// diffChanged is the value of a field that is changed.
+type diffChanged struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// prestateAccount is an account of the prestateTracer output.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    hexutil.Bytes               `json:"code"`
+	Nonce   uint64                      `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// prestateDiff is the prestateTracer output in diff mode.
+type prestateDiff struct {
+	Pre  map[common.Address]*prestateAccount `json:"pre"`
+	Post map[common.Address]*prestateAccount `json:"post"`
+}
+
+// flatTraceAddresses holds the addresses of a flat call trace.
+type flatTraceAddresses struct {
+	Action struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+}
+
+// traceConfig returns the configuration of the mux tracer collecting the
+// given trace types. The top-level call is always traced to get its output.
+func traceConfig(traceTypes []string) (*evmtypes.TraceConfig, error) {
+	tracerConfig := map[string]json.RawMessage{
+		callTracer: json.RawMessage(`{"onlyTopCall":true}`),
+	}
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case TraceTypeTrace:
+			tracerConfig[flatCallTracer] = json.RawMessage(`{}`)
+		case TraceTypeStateDiff:
+			tracerConfig[prestateTracer] = json.RawMessage(`{"diffMode":true}`)
+		case TraceTypeVMTrace:
+			tracerConfig[parityVMTracer] = json.RawMessage(`{}`)
+		default:
+			return nil, fmt.Errorf("invalid trace type %q, expected one of: %s", traceType,
+				strings.Join([]string{TraceTypeTrace, TraceTypeStateDiff, TraceTypeVMTrace}, ", "))
+		}
+	}
+
+	bz, err := json.Marshal(tracerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &evmtypes.TraceConfig{Tracer: muxTracer, TracerJsonConfig: string(bz)}, nil
+}
+
+// traceResults builds the Parity trace results from the output of the mux
+// tracer configured by traceConfig.
+func traceResults(result interface{}, traceTypes []string) (*TraceResults, error) {
+	var muxResult map[string]json.RawMessage
+	if err := remarshal(result, &muxResult); err != nil {
+		return nil, err
+	}
+
+	var topCall struct {
+		Output hexutil.Bytes `json:"output"`
+	}
+	if err := json.Unmarshal(muxResult[callTracer], &topCall); err != nil {
+		return nil, err
+	}
+
+	results := &TraceResults{
+		Output: topCall.Output,
+		Trace:  []json.RawMessage{},
+	}
+	if results.Output == nil {
+		results.Output = hexutil.Bytes{}
+	}
+
+	if slices.Contains(traceTypes, TraceTypeTrace) {
+		if err := json.Unmarshal(muxResult[flatCallTracer], &results.Trace); err != nil {
+			return nil, err
+		}
+	}
+	if slices.Contains(traceTypes, TraceTypeStateDiff) {
+		var diff prestateDiff
+		if err := json.Unmarshal(muxResult[prestateTracer], &diff); err != nil {
+			return nil, err
+		}
+		results.StateDiff = stateDiff(diff)
+	}
+	if slices.Contains(traceTypes, TraceTypeVMTrace) {
+		results.VMTrace = muxResult[parityVMTracer]
+	}
+	return results, nil
+}
+
+// stateDiff converts the prestateTracer output in diff mode to the Parity
+// state diff. The pre state only holds the modified accounts, the accounts
+// missing from the post state are deleted and the accounts missing from the
+// pre state are created.
+func stateDiff(diff prestateDiff) map[common.Address]*AccountDiff {
+	result := make(map[common.Address]*AccountDiff, len(diff.Pre)+len(diff.Post))
+	for addr, pre := range diff.Pre {
+		post, ok := diff.Post[addr]
+		if !ok {
+			result[addr] = accountDiff(pre, "-")
+			continue
+		}
+
+		accDiff := &AccountDiff{
+			Balance: diffUnchanged,
+			Code:    diffUnchanged,
+			Nonce:   diffUnchanged,
+			Storage: make(map[common.Hash]interface{}),
+		}
+		if post.Balance != nil {
+			accDiff.Balance = map[string]diffChanged{"*": {From: balanceOrZero(pre.Balance), To: post.Balance}}
+		}
+		if post.Nonce != 0 {
+			accDiff.Nonce = map[string]diffChanged{"*": {From: hexutil.Uint64(pre.Nonce), To: hexutil.Uint64(post.Nonce)}}
+		}
+		if post.Code != nil {
+			accDiff.Code = map[string]diffChanged{"*": {From: codeOrEmpty(pre.Code), To: post.Code}}
+		}
+		for key, to := range post.Storage {
+			if from, ok := pre.Storage[key]; ok {
+				accDiff.Storage[key] = map[string]diffChanged{"*": {From: from, To: to}}
+			} else {
+				accDiff.Storage[key] = map[string]common.Hash{"+": to}
+			}
+		}
+		for key, from := range pre.Storage {
+			if _, ok := post.Storage[key]; !ok {
+				// slots cleared are omitted from the post state
+				accDiff.Storage[key] = map[string]diffChanged{"*": {From: from, To: common.Hash{}}}
+			}
+		}
+		result[addr] = accDiff
+	}
+
+	for addr, post := range diff.Post {
+		if _, ok := diff.Pre[addr]; !ok {
+			result[addr] = accountDiff(post, "+")
+		}
+	}
+	return result
+}
+
+// accountDiff returns the diff of a created ("+") or deleted ("-") account.
+func accountDiff(account *prestateAccount, op string) *AccountDiff {
+	accDiff := &AccountDiff{
+		Balance: map[string]*hexutil.Big{op: balanceOrZero(account.Balance)},
+		Code:    map[string]hexutil.Bytes{op: codeOrEmpty(account.Code)},
+		Nonce:   map[string]hexutil.Uint64{op: hexutil.Uint64(account.Nonce)},
+		Storage: make(map[common.Hash]interface{}, len(account.Storage)),
+	}
+	for key, value := range account.Storage {
+		accDiff.Storage[key] = map[string]common.Hash{op: value}
+	}
+	return accDiff
+}
+
+func balanceOrZero(balance *hexutil.Big) *hexutil.Big {
+	if balance == nil {
+		return new(hexutil.Big)
+	}
+	return balance
+}
+
+func codeOrEmpty(code hexutil.Bytes) hexutil.Bytes {
+	if code == nil {
+		return hexutil.Bytes{}
+	}
+	return code
+}
+
+// matchTrace returns whether the flat call trace matches the given sender and
+// recipient addresses. An empty address list matches any address.
+func matchTrace(trace json.RawMessage, fromAddresses, toAddresses []common.Address) (bool, error) {
+	if len(fromAddresses) == 0 && len(toAddresses) == 0 {
+		return true, nil
+	}
+
+	var addrs flatTraceAddresses
+	if err := json.Unmarshal(trace, &addrs); err != nil {
+		return false, err
+	}
+
+	from := addrs.Action.From
+	if from == nil {
+		// selfdestruct
+		from = addrs.Action.Address
+	}
+	to := addrs.Action.To
+	if to == nil && addrs.Result != nil {
+		// contract creation
+		to = addrs.Result.Address
+	}
+	if to == nil {
+		// selfdestruct
+		to = addrs.Action.RefundAddress
+	}
+
+	return matchAddress(from, fromAddresses) && matchAddress(to, toAddresses), nil
+}
+
+func matchAddress(addr *common.Address, addresses []common.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	return addr != nil && slices.Contains(addresses, *addr)
+}
+
+// resolveBlockNumber returns the height of the given block number, defaulting
+// to the latest block. The finalized and safe tags are decoded as the latest
+// block since blocks are final once committed, and the pending block is the
+// latest one as there is no pending block to trace.
+func resolveBlockNumber(blockNr *rpctypes.BlockNumber, latest int64) (int64, error) {
+	if blockNr == nil {
+		return latest, nil
+	}
+
+	switch *blockNr {
+	case rpctypes.EthLatestBlockNumber, rpctypes.EthPendingBlockNumber:
+		return latest, nil
+	case rpctypes.EthEarliestBlockNumber:
+		return 0, nil
+	}
+	if *blockNr < 0 {
+		return 0, fmt.Errorf("unsupported block tag %d", *blockNr)
+	}
+	return int64(*blockNr), nil
+}
+
+// remarshal converts the generic json-decoded tracer result to the given type.
+func remarshal(result interface{}, v interface{}) error {
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -0,0 +1,170 @@
+package trace
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cosmos/evm/rpc/types"
+)
+
+func TestTraceConfig(t *testing.T) {
+	_, err := traceConfig([]string{TraceTypeTrace, "invalid"})
+	require.Error(t, err)
+
+	config, err := traceConfig([]string{TraceTypeTrace, TraceTypeStateDiff, TraceTypeVMTrace})
+	require.NoError(t, err)
+	require.Equal(t, muxTracer, config.Tracer)
+	require.JSONEq(t, `{
+		"callTracer": {"onlyTopCall": true},
+		"flatCallTracer": {},
+		"prestateTracer": {"diffMode": true},
+		"parityVmTracer": {}
+	}`, config.TracerJsonConfig)
+}
+
+func TestTraceResults(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		contract = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		deleted  = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	)
+
+	var result interface{}
+	err := json.Unmarshal([]byte(`{
+		"callTracer": {"type": "CALL", "output": "0x01"},
+		"flatCallTracer": [{"action": {"from": "`+sender.Hex()+`"}, "type": "call"}],
+		"prestateTracer": {
+			"pre": {
+				"`+sender.Hex()+`": {"balance": "0x10", "nonce": 1},
+				"`+contract.Hex()+`": {"balance": "0x0", "code": "0x00", "storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000001",
+					"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"
+				}},
+				"`+deleted.Hex()+`": {"balance": "0x1"}
+			},
+			"post": {
+				"`+sender.Hex()+`": {"balance": "0x8", "nonce": 2},
+				"`+contract.Hex()+`": {"storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000003",
+					"0x0000000000000000000000000000000000000000000000000000000000000004": "0x0000000000000000000000000000000000000000000000000000000000000004"
+				}}
+			}
+		}
+	}`), &result)
+	require.NoError(t, err)
+
+	results, err := traceResults(result, []string{TraceTypeTrace, TraceTypeStateDiff})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, []byte(results.Output))
+	require.Len(t, results.Trace, 1)
+	require.Nil(t, results.VMTrace)
+
+	bz, err := json.Marshal(results.StateDiff)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"`+sender.Hex()+`": {
+			"balance": {"*": {"from": "0x10", "to": "0x8"}},
+			"code": "=",
+			"nonce": {"*": {"from": "0x1", "to": "0x2"}},
+			"storage": {}
+		},
+		"`+contract.Hex()+`": {
+			"balance": "=",
+			"code": "=",
+			"nonce": "=",
+			"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": {"*": {
+					"from": "0x0000000000000000000000000000000000000000000000000000000000000001",
+					"to": "0x0000000000000000000000000000000000000000000000000000000000000003"
+				}},
+				"0x0000000000000000000000000000000000000000000000000000000000000002": {"*": {
+					"from": "0x0000000000000000000000000000000000000000000000000000000000000002",
+					"to": "0x0000000000000000000000000000000000000000000000000000000000000000"
+				}},
+				"0x0000000000000000000000000000000000000000000000000000000000000004": {
+					"+": "0x0000000000000000000000000000000000000000000000000000000000000004"
+				}
+			}
+		},
+		"`+deleted.Hex()+`": {
+			"balance": {"-": "0x1"},
+			"code": {"-": "0x"},
+			"nonce": {"-": "0x0"},
+			"storage": {}
+		}
+	}`, string(bz))
+}
+
+func TestMatchTrace(t *testing.T) {
+	var (
+		from  = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		to    = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		other = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	)
+
+	call := json.RawMessage(`{"action": {"from": "` + from.Hex() + `", "to": "` + to.Hex() + `"}, "type": "call"}`)
+	create := json.RawMessage(`{"action": {"from": "` + from.Hex() + `"}, "result": {"address": "` + to.Hex() + `"}, "type": "create"}`)
+	suicide := json.RawMessage(`{"action": {"address": "` + from.Hex() + `", "refundAddress": "` + to.Hex() + `"}, "type": "suicide"}`)
+
+	testCases := []struct {
+		name     string
+		trace    json.RawMessage
+		from, to []common.Address
+		expMatch bool
+	}{
+		{"no filter", call, nil, nil, true},
+		{"call sender", call, []common.Address{from}, nil, true},
+		{"call recipient", call, nil, []common.Address{to}, true},
+		{"call other sender", call, []common.Address{other}, nil, false},
+		{"call sender and other recipient", call, []common.Address{from}, []common.Address{other}, false},
+		{"created contract", create, nil, []common.Address{to}, true},
+		{"selfdestruct", suicide, []common.Address{from}, []common.Address{to}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, err := matchTrace(tc.trace, tc.from, tc.to)
+			require.NoError(t, err)
+			require.Equal(t, tc.expMatch, match)
+		})
+	}
+}
+
+func TestResolveBlockNumber(t *testing.T) {
+	const latest = 10
+	blockNr := func(s string) *rpctypes.BlockNumber {
+		var bn rpctypes.BlockNumber
+		require.NoError(t, json.Unmarshal([]byte(`"`+s+`"`), &bn))
+		return &bn
+	}
+	unknown := rpctypes.BlockNumber(-3)
+
+	testCases := []struct {
+		name      string
+		blockNr   *rpctypes.BlockNumber
+		expHeight int64
+		expPass   bool
+	}{
+		{"default", nil, latest, true},
+		{"latest", blockNr(rpctypes.BlockParamLatest), latest, true},
+		{"pending", blockNr(rpctypes.BlockParamPending), latest, true},
+		{"finalized", blockNr(rpctypes.BlockParamFinalized), latest, true},
+		{"safe", blockNr(rpctypes.BlockParamSafe), latest, true},
+		{"earliest", blockNr(rpctypes.BlockParamEarliest), 0, true},
+		{"height", blockNr("0x5"), 5, true},
+		{"unknown tag", &unknown, 0, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			height, err := resolveBlockNumber(tc.blockNr, latest)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expHeight, height)
+		})
+	}
+}
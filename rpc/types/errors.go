@@ -3,3 +3,7 @@ package types
 import "errors"
 
 var ErrProfilingDisabled = errors.New("profiling disabled in the debug namespace")
+
+var ErrAddressIndexDisabled = errors.New("address index not available, the custom EVM tx indexer must be enabled")
+
+var ErrLogIndexDisabled = errors.New("log index not available, the custom EVM tx indexer must be enabled")
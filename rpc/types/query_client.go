@@ -1,8 +1,13 @@
 package types
 
 import (
+	"context"
 	"fmt"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/proto/tendermint/crypto"
 
@@ -27,11 +32,26 @@ type QueryClient struct {
 func NewQueryClient(clientCtx client.Context) *QueryClient {
 	return &QueryClient{
 		ServiceClient: tx.NewServiceClient(clientCtx),
-		QueryClient:   evmtypes.NewQueryClient(clientCtx),
+		QueryClient:   evmtypes.NewQueryClient(streamingConn{clientCtx}),
 		FeeMarket:     feemarkettypes.NewQueryClient(clientCtx),
 	}
 }
 
+// streamingConn is a client connection invoking the unary queries with the
+// client context and opening the streams with its gRPC client, as the client
+// context doesn't support the streaming queries.
+type streamingConn struct {
+	client.Context
+}
+
+// NewStream implements the grpc ClientConn.NewStream method
+func (c streamingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if c.GRPCClient == nil {
+		return nil, status.Error(codes.Unimplemented, "streaming queries require the gRPC client")
+	}
+	return c.GRPCClient.NewStream(ctx, desc, method, opts...)
+}
+
 // GetProof performs an ABCI query with the given key and returns a merkle proof. The desired
 // tendermint height to perform the query should be set in the client context. The query will be
 // performed at one below this height (at the IAVL version) in order to obtain the correct merkle
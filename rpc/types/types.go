@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
 )
 
 // Copied the Account and StorageResult types since they are registered under an
@@ -52,21 +54,71 @@ type RPCTransaction struct {
 	S                *hexutil.Big         `json:"s"`
 }
 
+// AccessListResult is the result of the `eth_createAccessList` RPC call. It
+// contains an error if the transaction itself failed.
+type AccessListResult struct {
+	AccessList *ethtypes.AccessList `json:"accessList"`
+	Error      string               `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64       `json:"gasUsed"`
+}
+
+// BlockRoots holds the Merkle-Patricia roots of the Ethereum transactions and
+// receipts of a block.
+type BlockRoots struct {
+	TxRoot       common.Hash
+	ReceiptsRoot common.Hash
+}
+
+// ReceiptProofResult is the result of the `eth_getReceiptProof` RPC call. It
+// contains the consensus encoding of a receipt and the Merkle-Patricia proof of
+// its inclusion in the receipts root of its block.
+type ReceiptProofResult struct {
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	ReceiptsRoot common.Hash     `json:"receiptsRoot"`
+	Key          hexutil.Bytes   `json:"key"`
+	Receipt      hexutil.Bytes   `json:"receipt"`
+	Proof        []hexutil.Bytes `json:"proof"`
+}
+
 // StateOverride is the collection of overridden accounts.
-type StateOverride map[common.Address]OverrideAccount
+type StateOverride = evmtypes.StateOverride
 
 // OverrideAccount indicates the overriding fields of account during the execution of
 // a message call.
-// Note, state and stateDiff can't be specified at the same time. If state is
-// set, message execution will only use the data in the given state. Otherwise
-// if statDiff is set, all diff will be applied first and then execute the call
-// message.
-type OverrideAccount struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+type OverrideAccount = evmtypes.OverrideAccount
+
+// BlockOverrides is a set of header fields to override in the block context
+// of a message call.
+type BlockOverrides = evmtypes.BlockOverrides
+
+// TraceCallConfig is the config of the `debug_traceCall` RPC call. It extends
+// the TraceConfig with the state and block overrides applied to the call.
+type TraceCallConfig struct {
+	evmtypes.TraceConfig
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+}
+
+// StdTraceConfig is the config of the `debug_standardTraceBlockToFile` RPC
+// call. It extends the TraceConfig with the hash of the single transaction to
+// trace, all the transactions of the block being traced if empty.
+type StdTraceConfig struct {
+	evmtypes.TraceConfig
+	TxHash common.Hash `json:"txHash"`
+}
+
+// StorageRangeResult is the result of a `debug_storageRangeAt` RPC call. The
+// storage entries are keyed by the hash of their key, like in geth.
+type StorageRangeResult struct {
+	Storage map[common.Hash]StorageEntry `json:"storage"`
+	NextKey *common.Hash                 `json:"nextKey"` // nil if Storage includes the last key
+}
+
+// StorageEntry is a storage entry of a StorageRangeResult.
+type StorageEntry struct {
+	Key   *common.Hash `json:"key"`
+	Value common.Hash  `json:"value"`
 }
 
 type FeeHistoryResult struct {
@@ -53,12 +53,17 @@ func RawTxToEthTx(clientCtx client.Context, txBz cmttypes.Tx) ([]*evmtypes.MsgEt
 }
 
 // EthHeaderFromTendermint is an util function that returns an Ethereum Header
-// from a tendermint Header.
-func EthHeaderFromTendermint(header cmttypes.Header, bloom ethtypes.Bloom, baseFee *big.Int) *ethtypes.Header {
+// from a tendermint Header. If the block roots are unknown, the tendermint
+// DataHash is used as the transactions root.
+func EthHeaderFromTendermint(header cmttypes.Header, bloom ethtypes.Bloom, baseFee *big.Int, roots *BlockRoots) *ethtypes.Header {
 	txHash := ethtypes.EmptyRootHash
 	if len(header.DataHash) != 0 {
 		txHash = common.BytesToHash(header.DataHash)
 	}
+	receiptHash := ethtypes.EmptyRootHash
+	if roots != nil {
+		txHash, receiptHash = roots.TxRoot, roots.ReceiptsRoot
+	}
 
 	time := uint64(header.Time.UTC().Unix()) //nolint:gosec // G115 // won't exceed uint64
 	return &ethtypes.Header{
@@ -67,7 +72,7 @@ func EthHeaderFromTendermint(header cmttypes.Header, bloom ethtypes.Bloom, baseF
 		Coinbase:    common.BytesToAddress(header.ProposerAddress),
 		Root:        common.BytesToHash(header.AppHash),
 		TxHash:      txHash,
-		ReceiptHash: ethtypes.EmptyRootHash,
+		ReceiptHash: receiptHash,
 		Bloom:       bloom,
 		Difficulty:  big.NewInt(0),
 		Number:      big.NewInt(header.Height),
@@ -105,11 +110,12 @@ func BlockMaxGasFromConsensusParams(goCtx context.Context, clientCtx client.Cont
 }
 
 // FormatBlock creates an ethereum block from a tendermint header and ethereum-formatted
-// transactions.
+// transactions. If the block roots are unknown, the tendermint DataHash is used as the
+// transactions root.
 func FormatBlock(
 	header cmttypes.Header, size int, gasLimit int64,
 	gasUsed *big.Int, transactions []interface{}, bloom ethtypes.Bloom,
-	validatorAddr common.Address, baseFee *big.Int,
+	validatorAddr common.Address, baseFee *big.Int, roots *BlockRoots,
 ) map[string]interface{} {
 	var transactionsRoot common.Hash
 	if len(transactions) == 0 {
@@ -117,6 +123,10 @@ func FormatBlock(
 	} else {
 		transactionsRoot = common.BytesToHash(header.DataHash)
 	}
+	receiptsRoot := ethtypes.EmptyRootHash
+	if roots != nil {
+		transactionsRoot, receiptsRoot = roots.TxRoot, roots.ReceiptsRoot
+	}
 
 	result := map[string]interface{}{
 		"number":           hexutil.Uint64(header.Height), //nolint:gosec // G115 // won't exceed uint64
@@ -135,7 +145,7 @@ func FormatBlock(
 		"gasUsed":          (*hexutil.Big)(gasUsed),
 		"timestamp":        hexutil.Uint64(header.Time.Unix()), //nolint:gosec // G115 // won't exceed uint64
 		"transactionsRoot": transactionsRoot,
-		"receiptsRoot":     ethtypes.EmptyRootHash,
+		"receiptsRoot":     receiptsRoot,
 
 		"uncles":          []common.Hash{},
 		"transactions":    transactions,
@@ -149,6 +159,67 @@ func FormatBlock(
 	return result
 }
 
+// FormatSimulatedBlock creates an ethereum block, along with the results of its
+// calls, from a block simulated by `eth_simulateV1`.
+func FormatSimulatedBlock(
+	block evmtypes.SimBlockResult, fullTx bool, chainID *big.Int,
+) (map[string]interface{}, error) {
+	header := block.Header
+	blockHash := header.Hash()
+
+	transactions := make([]interface{}, 0, len(block.Transactions))
+	for i, simTx := range block.Transactions {
+		tx := new(ethtypes.Transaction)
+		if err := tx.UnmarshalBinary(simTx.Tx); err != nil {
+			return nil, err
+		}
+
+		if !fullTx {
+			transactions = append(transactions, tx.Hash())
+			continue
+		}
+
+		rpcTx, err := NewRPCTransaction(tx, blockHash, header.Number.Uint64(), uint64(i), header.BaseFee, chainID)
+		if err != nil {
+			return nil, err
+		}
+		// the simulated transactions are not signed
+		rpcTx.From = simTx.From
+		transactions = append(transactions, rpcTx)
+	}
+
+	result := map[string]interface{}{
+		"number":           (*hexutil.Big)(header.Number),
+		"hash":             blockHash,
+		"parentHash":       header.ParentHash,
+		"nonce":            header.Nonce,
+		"sha3Uncles":       header.UncleHash,
+		"logsBloom":        header.Bloom,
+		"stateRoot":        header.Root,
+		"miner":            header.Coinbase,
+		"mixHash":          header.MixDigest,
+		"difficulty":       (*hexutil.Big)(header.Difficulty),
+		"extraData":        hexutil.Bytes(header.Extra),
+		"size":             hexutil.Uint64(header.Size()),
+		"gasLimit":         hexutil.Uint64(header.GasLimit),
+		"gasUsed":          hexutil.Uint64(header.GasUsed),
+		"timestamp":        hexutil.Uint64(header.Time),
+		"transactionsRoot": header.TxHash,
+		"receiptsRoot":     header.ReceiptHash,
+
+		"uncles":          []common.Hash{},
+		"transactions":    transactions,
+		"totalDifficulty": (*hexutil.Big)(big.NewInt(0)),
+		"calls":           block.Calls,
+	}
+
+	if header.BaseFee != nil {
+		result["baseFeePerGas"] = (*hexutil.Big)(header.BaseFee)
+	}
+
+	return result, nil
+}
+
 // NewTransactionFromMsg returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 func NewTransactionFromMsg(
@@ -252,6 +323,43 @@ func BaseFeeFromEvents(events []abci.Event) *big.Int {
 	return nil
 }
 
+// BlockRootsFromEvents parses the Ethereum transactions and receipts roots of a
+// block from cosmos events. It returns nil if the block has no roots event.
+func BlockRootsFromEvents(events []abci.Event) *BlockRoots {
+	for _, event := range events {
+		if event.Type != evmtypes.EventTypeBlockRoots {
+			continue
+		}
+
+		roots := &BlockRoots{
+			TxRoot:       ethtypes.EmptyTxsHash,
+			ReceiptsRoot: ethtypes.EmptyReceiptsHash,
+		}
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case evmtypes.AttributeKeyTxRoot:
+				roots.TxRoot = common.HexToHash(attr.Value)
+			case evmtypes.AttributeKeyReceiptsRoot:
+				roots.ReceiptsRoot = common.HexToHash(attr.Value)
+			}
+		}
+		return roots
+	}
+	return nil
+}
+
+// BlockRootsFromBytes decodes the Ethereum transactions and receipts roots of a
+// block stored by the EVM module. It returns nil if the roots aren't stored.
+func BlockRootsFromBytes(bz []byte) *BlockRoots {
+	if len(bz) != 2*common.HashLength {
+		return nil
+	}
+	return &BlockRoots{
+		TxRoot:       common.BytesToHash(bz[:common.HashLength]),
+		ReceiptsRoot: common.BytesToHash(bz[common.HashLength:]),
+	}
+}
+
 // CheckTxFee is an internal function used to check whether the fee of
 // the given transaction is _reasonable_(under the minimum cap).
 func CheckTxFee(gasPrice *big.Int, gas uint64, minCap float64) error {
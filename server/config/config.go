@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
@@ -86,6 +87,18 @@ const (
 	// DefaultEVMTimeout is the default timeout for eth_call
 	DefaultEVMTimeout = 5 * time.Second
 
+	// DefaultJSTracerStepLimit is the default number of callbacks of a JS tracer during a trace
+	DefaultJSTracerStepLimit uint64 = 10_000_000
+
+	// DefaultTraceBlockConcurrency is the default number of transactions traced concurrently by the block traces
+	DefaultTraceBlockConcurrency = 0
+
+	// DefaultIndexerBackend is the default database of the custom indexer
+	DefaultIndexerBackend = IndexerBackendKV
+
+	// DefaultIndexerSnapshotWindow is the default number of blocks of the custom indexer exported in the state-sync snapshots
+	DefaultIndexerSnapshotWindow int64 = 0
+
 	// DefaultTxFeeCap is the default tx-fee cap for sending a transaction
 	DefaultTxFeeCap float64 = 1.0
 
@@ -115,10 +128,33 @@ const (
 
 	// DefaultEnableProfiling toggles whether profiling is enabled in the `debug` namespace
 	DefaultEnableProfiling = false
+
+	// DefaultGPOBlocks is the default number of blocks sampled by the gas price oracle
+	DefaultGPOBlocks = 20
+
+	// DefaultGPOPercentile is the default percentile of the sampled tips suggested by the gas price oracle
+	DefaultGPOPercentile = 60
+
+	// DefaultGPOMaxPrice is the default cap of the tip suggested by the gas price oracle (500 gwei)
+	DefaultGPOMaxPrice uint64 = 500_000_000_000
+
+	// DefaultGPOIgnorePrice is the default tip below which transactions are ignored by the gas price oracle
+	DefaultGPOIgnorePrice uint64 = 2
 )
 
 var evmTracers = []string{"json", "markdown", "struct", "access_list"}
 
+const (
+	// IndexerBackendKV stores the custom indexer in a KV db next to the app db
+	IndexerBackendKV = "kv"
+	// IndexerBackendSQLite stores the custom indexer in a SQLite db
+	IndexerBackendSQLite = "sqlite"
+	// IndexerBackendPostgres stores the custom indexer in a Postgres db
+	IndexerBackendPostgres = "postgres"
+)
+
+var indexerBackends = []string{IndexerBackendKV, IndexerBackendSQLite, IndexerBackendPostgres}
+
 // Config defines the server's top level configuration. It includes the default app config
 // from the SDK as well as the EVM configuration to enable the JSON-RPC APIs.
 type Config struct {
@@ -140,6 +176,30 @@ type EVMConfig struct {
 	EnablePreimageRecording bool `mapstructure:"cache-preimage"`
 	// EVMChainID defines the EIP-155 replay-protection chain ID.
 	EVMChainID uint64 `mapstructure:"evm-chain-id"`
+	// LiveTracer defines the name of the live tracer invoked for every block executed by the node.
+	LiveTracer string `mapstructure:"live-tracer"`
+	// LiveTracerConfig defines the JSON configuration of the live tracer.
+	LiveTracerConfig string `mapstructure:"live-tracer-config"`
+	// TracerOptions defines the logger options of the tracer.
+	TracerOptions TracerOptionsConfig `mapstructure:"tracer-options"`
+}
+
+// TracerOptionsConfig defines the logger options of the tracer set in the EVM
+// configuration.
+type TracerOptionsConfig struct {
+	// EnableMemory enables the memory capture
+	EnableMemory bool `mapstructure:"enable-memory"`
+	// DisableStack disables the stack capture
+	DisableStack bool `mapstructure:"disable-stack"`
+	// DisableStorage disables the storage capture
+	DisableStorage bool `mapstructure:"disable-storage"`
+	// EnableReturnData enables the return data capture
+	EnableReturnData bool `mapstructure:"enable-return-data"`
+	// Limit defines the maximum size of the output, 0 for no limit
+	Limit int `mapstructure:"limit"`
+	// Output defines the path of the file the json and markdown tracers write to,
+	// stderr and stdout respectively if empty
+	Output string `mapstructure:"output"`
 }
 
 // JSONRPCConfig defines configuration for the EVM RPC server.
@@ -154,8 +214,16 @@ type JSONRPCConfig struct {
 	GasCap uint64 `mapstructure:"gas-cap"`
 	// AllowInsecureUnlock toggles if account unlocking is enabled when account-related RPCs are exposed by http.
 	AllowInsecureUnlock bool `mapstructure:"allow-insecure-unlock"`
-	// EVMTimeout is the global timeout for eth-call.
+	// EVMTimeout is the global timeout for eth-call, also limiting the duration of the JS tracers.
 	EVMTimeout time.Duration `mapstructure:"evm-timeout"`
+	// JSTracerStepLimit is the number of EVM steps, call frames and faults a JS tracer is called back
+	// for during a trace after which the tracer is stopped.
+	JSTracerStepLimit uint64 `mapstructure:"js-tracer-step-limit"`
+	// TraceFileDir is the directory of the trace files written by the debug_*TraceBlockToFile methods.
+	TraceFileDir string `mapstructure:"trace-file-dir"`
+	// TraceBlockConcurrency is the number of transactions traced concurrently by the block traces,
+	// after replaying the block once to capture the state before each transaction. 0 traces them in order.
+	TraceBlockConcurrency int `mapstructure:"trace-block-concurrency"`
 	// TxFeeCap is the global tx-fee cap for send transaction
 	TxFeeCap float64 `mapstructure:"txfee-cap"`
 	// FilterCap is the global cap for total number of filters that can be created.
@@ -184,6 +252,15 @@ type JSONRPCConfig struct {
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
 	// EnableIndexer defines if enable the custom indexer service.
 	EnableIndexer bool `mapstructure:"enable-indexer"`
+	// IndexerBackend defines the database of the custom indexer: kv, sqlite or postgres.
+	IndexerBackend string `mapstructure:"indexer-backend"`
+	// IndexerDSN defines the data source name of the sqlite or postgres indexer database,
+	// the sqlite database defaults to data/evmindexer.sqlite in the home directory.
+	IndexerDSN string `mapstructure:"indexer-dsn"`
+	// IndexerSnapshotWindow defines the number of blocks, up to the snapshot height, whose indexed eth txs
+	// are exported in the state-sync snapshots. It's an opt-in, 0 by default, which doesn't register the snapshot
+	// extension: enabling it makes the snapshots of the node incompatible with the peers which don't register it.
+	IndexerSnapshotWindow int64 `mapstructure:"indexer-snapshot-window"`
 	// MetricsAddress defines the metrics server to listen on
 	MetricsAddress string `mapstructure:"metrics-address"`
 	// FixRevertGasRefundHeight defines the upgrade height for fix of revert gas refund logic when transaction reverted
@@ -192,6 +269,14 @@ type JSONRPCConfig struct {
 	WSOrigins []string `mapstructure:"ws-origins"`
 	// EnableProfiling enables the profiling in the `debug` namespace. SHOULD NOT be used on public tracing nodes
 	EnableProfiling bool `mapstructure:"enable-profiling"`
+	// GPOBlocks is the number of recent blocks sampled by the gas price oracle to suggest a gas tip cap.
+	GPOBlocks int `mapstructure:"gpo-blocks"`
+	// GPOPercentile is the percentile of the sampled tips suggested by the gas price oracle.
+	GPOPercentile int `mapstructure:"gpo-percentile"`
+	// GPOMaxPrice is the maximum gas tip cap suggested by the gas price oracle, in wei (0=no cap).
+	GPOMaxPrice uint64 `mapstructure:"gpo-max-price"`
+	// GPOIgnorePrice is the gas tip below which transactions are not sampled by the gas price oracle, in wei.
+	GPOIgnorePrice uint64 `mapstructure:"gpo-ignore-price"`
 }
 
 // TLSConfig defines the certificate and matching private key for the server.
@@ -212,12 +297,25 @@ func DefaultEVMConfig() *EVMConfig {
 	}
 }
 
-// Validate returns an error if the tracer type is invalid.
+// Validate returns an error if the tracer type, the live tracer config or the tracer options are invalid.
 func (c EVMConfig) Validate() error {
 	if c.Tracer != "" && !strings.StringInSlice(c.Tracer, evmTracers) {
 		return fmt.Errorf("invalid tracer type %s, available types: %v", c.Tracer, evmTracers)
 	}
 
+	// the live tracer replaces the tracer of the executed transactions
+	if c.Tracer != "" && c.LiveTracer != "" {
+		return fmt.Errorf("tracer %s and live tracer %s cannot be both enabled", c.Tracer, c.LiveTracer)
+	}
+
+	if c.LiveTracerConfig != "" && !json.Valid([]byte(c.LiveTracerConfig)) {
+		return fmt.Errorf("invalid live tracer config: %s", c.LiveTracerConfig)
+	}
+
+	if c.TracerOptions.Limit < 0 {
+		return fmt.Errorf("tracer limit cannot be negative: %d", c.TracerOptions.Limit)
+	}
+
 	return nil
 }
 
@@ -228,7 +326,7 @@ func GetDefaultAPINamespaces() []string {
 
 // GetAPINamespaces returns the all the available JSON-RPC API namespaces.
 func GetAPINamespaces() []string {
-	return []string{"web3", "eth", "personal", "net", "txpool", "debug", "miner"}
+	return []string{"web3", "eth", "personal", "net", "txpool", "debug", "trace", "miner"}
 }
 
 // GetDefaultWSOrigins returns the default WebSocket origins.
@@ -246,6 +344,8 @@ func DefaultJSONRPCConfig() *JSONRPCConfig {
 		GasCap:                   DefaultGasCap,
 		AllowInsecureUnlock:      DefaultJSONRPCAllowInsecureUnlock,
 		EVMTimeout:               DefaultEVMTimeout,
+		JSTracerStepLimit:        DefaultJSTracerStepLimit,
+		TraceBlockConcurrency:    DefaultTraceBlockConcurrency,
 		TxFeeCap:                 DefaultTxFeeCap,
 		FilterCap:                DefaultFilterCap,
 		FeeHistoryCap:            DefaultFeeHistoryCap,
@@ -258,10 +358,16 @@ func DefaultJSONRPCConfig() *JSONRPCConfig {
 		BatchResponseMaxSize:     DefaultBatchResponseMaxSize,
 		MaxOpenConnections:       DefaultMaxOpenConnections,
 		EnableIndexer:            false,
+		IndexerBackend:           DefaultIndexerBackend,
+		IndexerSnapshotWindow:    DefaultIndexerSnapshotWindow,
 		MetricsAddress:           DefaultJSONRPCMetricsAddress,
 		FixRevertGasRefundHeight: DefaultFixRevertGasRefundHeight,
 		WSOrigins:                GetDefaultWSOrigins(),
 		EnableProfiling:          DefaultEnableProfiling,
+		GPOBlocks:                DefaultGPOBlocks,
+		GPOPercentile:            DefaultGPOPercentile,
+		GPOMaxPrice:              DefaultGPOMaxPrice,
+		GPOIgnorePrice:           DefaultGPOIgnorePrice,
 	}
 }
 
@@ -287,6 +393,22 @@ func (c JSONRPCConfig) Validate() error {
 		return errors.New("JSON-RPC EVM timeout duration cannot be negative")
 	}
 
+	if c.TraceBlockConcurrency < 0 {
+		return errors.New("JSON-RPC trace block concurrency cannot be negative")
+	}
+
+	if !strings.StringInSlice(c.IndexerBackend, indexerBackends) {
+		return fmt.Errorf("invalid JSON-RPC indexer backend %s, available backends: %v", c.IndexerBackend, indexerBackends)
+	}
+
+	if c.IndexerBackend == IndexerBackendPostgres && c.IndexerDSN == "" {
+		return errors.New("JSON-RPC indexer DSN is required by the postgres indexer backend")
+	}
+
+	if c.IndexerSnapshotWindow < 0 {
+		return errors.New("JSON-RPC indexer snapshot window cannot be negative")
+	}
+
 	if c.LogsCap < 0 {
 		return errors.New("JSON-RPC logs cap cannot be negative")
 	}
@@ -311,6 +433,14 @@ func (c JSONRPCConfig) Validate() error {
 		return errors.New("JSON-RPC batch response max size cannot be negative")
 	}
 
+	if c.GPOBlocks <= 0 {
+		return errors.New("JSON-RPC gas price oracle blocks cannot be negative or 0")
+	}
+
+	if c.GPOPercentile < 0 || c.GPOPercentile > 100 {
+		return errors.New("JSON-RPC gas price oracle percentile must be between 0 and 100")
+	}
+
 	// check for duplicates
 	seenAPIs := make(map[string]bool)
 	for _, api := range c.API {
@@ -55,6 +55,40 @@ func TestGetConfig(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"test unmarshal tracer options",
+			func() *viper.Viper {
+				v := viper.New()
+				v.Set("evm.tracer-options.enable-memory", true)
+				v.Set("evm.tracer-options.limit", 100)
+				v.Set("evm.tracer-options.output", "trace.jsonl")
+				return v
+			},
+			func() serverconfig.Config {
+				cfg := serverconfig.DefaultConfig()
+				cfg.EVM.TracerOptions.EnableMemory = true
+				cfg.EVM.TracerOptions.Limit = 100
+				cfg.EVM.TracerOptions.Output = "trace.jsonl"
+				return *cfg
+			},
+			false,
+		},
+		{
+			"test unmarshal gas price oracle config",
+			func() *viper.Viper {
+				v := viper.New()
+				v.Set("json-rpc.gpo-blocks", 10)
+				v.Set("json-rpc.gpo-percentile", 50)
+				return v
+			},
+			func() serverconfig.Config {
+				cfg := serverconfig.DefaultConfig()
+				cfg.JSONRPC.GPOBlocks = 10
+				cfg.JSONRPC.GPOPercentile = 50
+				return *cfg
+			},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,3 +103,12 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestEVMConfigValidate(t *testing.T) {
+	cfg := serverconfig.DefaultEVMConfig()
+	cfg.LiveTracer = "jsonl"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Tracer = "json"
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,532 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	failoverClientName = "FailoverClient"
+
+	// upstreamResolverScheme is the scheme of the gRPC target resolved to the
+	// current upstream
+	upstreamResolverScheme = "evmupstream"
+)
+
+var _ rpcclient.Client = &failoverClient{}
+
+// upstream is a node the requests of the json-rpc gateway are forwarded to
+type upstream struct {
+	rpcAddr  string
+	grpcAddr string
+	client   rpcclient.Client
+	// dial opens the connections of the websocket clients to the upstream
+	dial func(string, string) (net.Conn, error)
+}
+
+// subscription is an event subscription of the failoverClient, forwarded from
+// the current upstream
+type subscription struct {
+	subscriber string
+	query      string
+	out        chan coretypes.ResultEvent
+	// stop stops the forwarding of the events of the upstream, it's nil while
+	// the subscription isn't made to the current upstream, or once the upstream
+	// closed its events
+	stop chan struct{}
+}
+
+// failoverClient is a CometBFT rpc client forwarding the requests to one of
+// several upstream nodes. It checks the health of the upstreams periodically
+// and switches to the first healthy one once the current one stops responding,
+// catches up or falls behind the others, along with the gRPC connections
+// resolved by its resolver, the event subscriptions and the websocket clients
+// dialing with DialWS.
+type failoverClient struct {
+	service.BaseService
+
+	upstreams []*upstream
+	resolver  *manual.Resolver
+	// interval is the period of the health checks
+	interval time.Duration
+	// maxLag is the number of blocks an upstream can be behind the others
+	maxLag int64
+
+	mtx     sync.RWMutex
+	current int
+
+	subsMtx sync.Mutex
+	subs    map[subscriptionKey]*subscription
+
+	connsMtx sync.Mutex
+	conns    map[*upstreamConn]struct{}
+}
+
+// subscriptionKey identifies the subscriptions of the failoverClient
+type subscriptionKey struct {
+	subscriber string
+	query      string
+}
+
+// upstreamConn is a websocket connection to the current upstream, closed on a
+// switch for the websocket client to reconnect to the new upstream
+type upstreamConn struct {
+	net.Conn
+	c *failoverClient
+}
+
+// Close implements net.Conn by closing the connection and forgetting it.
+func (conn *upstreamConn) Close() error {
+	conn.c.connsMtx.Lock()
+	delete(conn.c.conns, conn)
+	conn.c.connsMtx.Unlock()
+	return conn.Conn.Close()
+}
+
+// newFailoverClient returns a new failoverClient of the upstream nodes with
+// the given CometBFT rpc and gRPC addresses, preferred in order.
+func newFailoverClient(rpcAddrs, grpcAddrs []string, interval time.Duration, maxLag int64) (*failoverClient, error) {
+	if len(rpcAddrs) == 0 {
+		return nil, errors.New("no upstream node")
+	}
+	if len(rpcAddrs) != len(grpcAddrs) {
+		return nil, errors.New("each upstream node requires a CometBFT rpc and a gRPC address")
+	}
+
+	c := &failoverClient{
+		upstreams: make([]*upstream, len(rpcAddrs)),
+		resolver:  manual.NewBuilderWithScheme(upstreamResolverScheme),
+		interval:  interval,
+		maxLag:    maxLag,
+		subs:      make(map[subscriptionKey]*subscription),
+		conns:     make(map[*upstreamConn]struct{}),
+	}
+	for i, rpcAddr := range rpcAddrs {
+		client, err := rpchttp.New(rpcAddr, "/websocket")
+		if err != nil {
+			return nil, err
+		}
+		// the websocket client is only created for its dialer
+		wsClient, err := jsonrpcclient.NewWS(rpcAddr, "/websocket")
+		if err != nil {
+			return nil, err
+		}
+		c.upstreams[i] = &upstream{rpcAddr: rpcAddr, grpcAddr: grpcAddrs[i], client: client, dial: wsClient.Dialer}
+	}
+	c.resolver.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: grpcAddrs[0]}}})
+	c.BaseService = *service.NewBaseService(nil, failoverClientName, c)
+	return c, nil
+}
+
+// DialWS dials the current upstream, to set as the dialer of the websocket
+// clients of the json-rpc servers. Their connections are closed on a switch,
+// so that they reconnect to the new upstream.
+func (c *failoverClient) DialWS(network, addr string) (net.Conn, error) {
+	conn, err := c.upstream().dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamConn := &upstreamConn{Conn: conn, c: c}
+	c.connsMtx.Lock()
+	c.conns[upstreamConn] = struct{}{}
+	c.connsMtx.Unlock()
+	return upstreamConn, nil
+}
+
+// GRPCTarget returns the target of the gRPC connections to the current
+// upstream, to dial with the resolver of the client.
+func (c *failoverClient) GRPCTarget() string {
+	return upstreamResolverScheme + ":///upstream"
+}
+
+// OnStart implements service.Service by selecting a healthy upstream and
+// checking the health of the upstreams periodically.
+func (c *failoverClient) OnStart() error {
+	if !c.checkHealth() {
+		return errors.New("no healthy upstream node")
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.Quit():
+				return
+			case <-ticker.C:
+				c.checkHealth()
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by stopping the event subscriptions.
+func (c *failoverClient) OnStop() {
+	for _, u := range c.upstreams {
+		if u.client.IsRunning() {
+			if err := u.client.Stop(); err != nil {
+				c.Logger.Error("failed to stop upstream client", "upstream", u.rpcAddr, "err", err)
+			}
+		}
+	}
+}
+
+// checkHealth queries the status of the upstreams and switches to the first
+// healthy one if the current one isn't. It returns false if none is healthy.
+func (c *failoverClient) checkHealth() bool {
+	from, to, ok := c.selectUpstream()
+	if from != to {
+		c.closeConns()
+	}
+	if ok {
+		c.resubscribe(from, to)
+	}
+	return ok
+}
+
+// selectUpstream queries the status of the upstreams and selects the first
+// healthy one if the current one isn't. It returns the previous and the
+// current upstreams, and false if none is healthy.
+func (c *failoverClient) selectUpstream() (from, to *upstream, ok bool) {
+	// the height of each upstream, -1 if unhealthy
+	heights := make([]int64, len(c.upstreams))
+	var wg sync.WaitGroup
+	for i, u := range c.upstreams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), c.interval)
+			defer cancel()
+
+			heights[i] = -1
+			status, err := u.client.Status(ctx)
+			switch {
+			case err != nil:
+				c.Logger.Debug("upstream not responding", "upstream", u.rpcAddr, "err", err)
+			case status.SyncInfo.CatchingUp:
+				c.Logger.Debug("upstream catching up", "upstream", u.rpcAddr)
+			default:
+				heights[i] = status.SyncInfo.LatestBlockHeight
+			}
+		}()
+	}
+	wg.Wait()
+
+	var best int64
+	for _, height := range heights {
+		best = max(best, height)
+	}
+	healthy := func(i int) bool {
+		return heights[i] >= 0 && heights[i] >= best-c.maxLag
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	from = c.upstreams[c.current]
+	if healthy(c.current) {
+		return from, from, true
+	}
+	for i := range c.upstreams {
+		if healthy(i) {
+			c.Logger.Info("switching upstream", "from", from.rpcAddr, "to", c.upstreams[i].rpcAddr, "height", heights[i])
+			c.current = i
+			c.resolver.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: c.upstreams[i].grpcAddr}}})
+			return from, c.upstreams[i], true
+		}
+	}
+	c.Logger.Error("no healthy upstream", "current", from.rpcAddr)
+	return from, from, false
+}
+
+// closeConns closes the websocket connections to the previous upstream, for
+// their clients to reconnect to the current one.
+func (c *failoverClient) closeConns() {
+	c.connsMtx.Lock()
+	conns := make([]*upstreamConn, 0, len(c.conns))
+	for conn := range c.conns {
+		conns = append(conns, conn)
+	}
+	c.connsMtx.Unlock()
+
+	for _, conn := range conns {
+		if err := conn.Close(); err != nil {
+			c.Logger.Debug("failed to close websocket connection", "err", err)
+		}
+	}
+}
+
+// resubscribe moves the event subscriptions to the current upstream on a
+// switch, and retries the ones which failed to move or whose events were
+// closed by the upstream.
+func (c *failoverClient) resubscribe(from, to *upstream) {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	for _, sub := range c.subs {
+		if from == to && sub.stop != nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.interval)
+		if sub.stop != nil {
+			close(sub.stop)
+			sub.stop = nil
+			// the previous upstream is likely down, so the error is ignored
+			_ = from.client.Unsubscribe(ctx, sub.subscriber, sub.query)
+		}
+		if err := c.subscribeUpstream(ctx, to, sub); err != nil {
+			c.Logger.Error("failed to move subscription", "upstream", to.rpcAddr, "query", sub.query, "err", err)
+		}
+		cancel()
+	}
+}
+
+// subscribeUpstream subscribes to the events of the upstream, forwarding them
+// to the subscription until it's stopped.
+func (c *failoverClient) subscribeUpstream(ctx context.Context, u *upstream, sub *subscription) error {
+	if !u.client.IsRunning() {
+		// the websocket connection is opened by the first subscription
+		if err := u.client.Start(); err != nil {
+			return err
+		}
+	}
+	events, err := u.client.Subscribe(ctx, sub.subscriber, sub.query, cap(sub.out))
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	sub.stop = stop
+	go func() {
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					// subscribe again on the next health check
+					c.subsMtx.Lock()
+					if sub.stop == stop {
+						sub.stop = nil
+					}
+					c.subsMtx.Unlock()
+					return
+				}
+				select {
+				case sub.out <- event:
+				case <-stop:
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// upstream returns the upstream the requests are forwarded to
+func (c *failoverClient) upstream() *upstream {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.upstreams[c.current]
+}
+
+func (c *failoverClient) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
+	return c.upstream().client.ABCIInfo(ctx)
+}
+
+func (c *failoverClient) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*coretypes.ResultABCIQuery, error) {
+	return c.upstream().client.ABCIQuery(ctx, path, data)
+}
+
+func (c *failoverClient) ABCIQueryWithOptions(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+	opts rpcclient.ABCIQueryOptions,
+) (*coretypes.ResultABCIQuery, error) {
+	return c.upstream().client.ABCIQueryWithOptions(ctx, path, data, opts)
+}
+
+func (c *failoverClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
+	return c.upstream().client.BroadcastTxCommit(ctx, tx)
+}
+
+func (c *failoverClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return c.upstream().client.BroadcastTxAsync(ctx, tx)
+}
+
+func (c *failoverClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return c.upstream().client.BroadcastTxSync(ctx, tx)
+}
+
+// Subscribe implements rpcclient.EventsClient by subscribing to the events of
+// the current upstream, and of the next ones on a switch.
+func (c *failoverClient) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan coretypes.ResultEvent, error) {
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+	sub := &subscription{
+		subscriber: subscriber,
+		query:      query,
+		out:        make(chan coretypes.ResultEvent, outCap),
+	}
+
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	key := subscriptionKey{subscriber: subscriber, query: query}
+	if _, ok := c.subs[key]; ok {
+		return nil, errors.New("already subscribed")
+	}
+	if err := c.subscribeUpstream(ctx, c.upstream(), sub); err != nil {
+		return nil, err
+	}
+	c.subs[key] = sub
+	return sub.out, nil
+}
+
+func (c *failoverClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	c.subsMtx.Lock()
+	key := subscriptionKey{subscriber: subscriber, query: query}
+	if sub, ok := c.subs[key]; ok {
+		if sub.stop != nil {
+			close(sub.stop)
+		}
+		delete(c.subs, key)
+	}
+	c.subsMtx.Unlock()
+	return c.upstream().client.Unsubscribe(ctx, subscriber, query)
+}
+
+func (c *failoverClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	c.subsMtx.Lock()
+	for key, sub := range c.subs {
+		if key.subscriber != subscriber {
+			continue
+		}
+		if sub.stop != nil {
+			close(sub.stop)
+		}
+		delete(c.subs, key)
+	}
+	c.subsMtx.Unlock()
+	return c.upstream().client.UnsubscribeAll(ctx, subscriber)
+}
+
+func (c *failoverClient) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
+	return c.upstream().client.Genesis(ctx)
+}
+
+func (c *failoverClient) GenesisChunked(ctx context.Context, id uint) (*coretypes.ResultGenesisChunk, error) {
+	return c.upstream().client.GenesisChunked(ctx, id)
+}
+
+func (c *failoverClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
+	return c.upstream().client.BlockchainInfo(ctx, minHeight, maxHeight)
+}
+
+func (c *failoverClient) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
+	return c.upstream().client.NetInfo(ctx)
+}
+
+func (c *failoverClient) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
+	return c.upstream().client.DumpConsensusState(ctx)
+}
+
+func (c *failoverClient) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
+	return c.upstream().client.ConsensusState(ctx)
+}
+
+func (c *failoverClient) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
+	return c.upstream().client.ConsensusParams(ctx, height)
+}
+
+func (c *failoverClient) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
+	return c.upstream().client.Health(ctx)
+}
+
+func (c *failoverClient) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return c.upstream().client.Block(ctx, height)
+}
+
+func (c *failoverClient) BlockByHash(ctx context.Context, hash []byte) (*coretypes.ResultBlock, error) {
+	return c.upstream().client.BlockByHash(ctx, hash)
+}
+
+func (c *failoverClient) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return c.upstream().client.BlockResults(ctx, height)
+}
+
+func (c *failoverClient) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	return c.upstream().client.Header(ctx, height)
+}
+
+func (c *failoverClient) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error) {
+	return c.upstream().client.HeaderByHash(ctx, hash)
+}
+
+func (c *failoverClient) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
+	return c.upstream().client.Commit(ctx, height)
+}
+
+func (c *failoverClient) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
+	return c.upstream().client.Validators(ctx, height, page, perPage)
+}
+
+func (c *failoverClient) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.ResultTx, error) {
+	return c.upstream().client.Tx(ctx, hash, prove)
+}
+
+func (c *failoverClient) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page, perPage *int,
+	orderBy string,
+) (*coretypes.ResultTxSearch, error) {
+	return c.upstream().client.TxSearch(ctx, query, prove, page, perPage, orderBy)
+}
+
+func (c *failoverClient) BlockSearch(
+	ctx context.Context,
+	query string,
+	page, perPage *int,
+	orderBy string,
+) (*coretypes.ResultBlockSearch, error) {
+	return c.upstream().client.BlockSearch(ctx, query, page, perPage, orderBy)
+}
+
+func (c *failoverClient) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
+	return c.upstream().client.Status(ctx)
+}
+
+func (c *failoverClient) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
+	return c.upstream().client.BroadcastEvidence(ctx, ev)
+}
+
+func (c *failoverClient) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	return c.upstream().client.UnconfirmedTxs(ctx, limit)
+}
+
+func (c *failoverClient) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	return c.upstream().client.NumUnconfirmedTxs(ctx)
+}
+
+func (c *failoverClient) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultCheckTx, error) {
+	return c.upstream().client.CheckTx(ctx, tx)
+}
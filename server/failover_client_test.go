@@ -0,0 +1,281 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+// upstreamClient is the client of an upstream reporting the given status and
+// serving the event subscriptions
+type upstreamClient struct {
+	rpcclient.Client
+
+	mtx        sync.Mutex
+	height     int64
+	catchingUp bool
+	err        error
+	running    bool
+	events     map[string]chan coretypes.ResultEvent
+}
+
+func newUpstreamClient(height int64) *upstreamClient {
+	return &upstreamClient{height: height, events: make(map[string]chan coretypes.ResultEvent)}
+}
+
+func (c *upstreamClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &coretypes.ResultStatus{
+		SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.height, CatchingUp: c.catchingUp},
+	}, nil
+}
+
+func (c *upstreamClient) IsRunning() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.running
+}
+
+func (c *upstreamClient) Start() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.running = true
+	return nil
+}
+
+func (c *upstreamClient) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan coretypes.ResultEvent, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	events := make(chan coretypes.ResultEvent)
+	c.events[query] = events
+	return events, nil
+}
+
+func (c *upstreamClient) Unsubscribe(_ context.Context, _, query string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.events, query)
+	return nil
+}
+
+func (c *upstreamClient) setStatus(height int64, catchingUp bool, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.height = height
+	c.catchingUp = catchingUp
+	c.err = err
+}
+
+func (c *upstreamClient) eventsOf(query string) chan coretypes.ResultEvent {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.events[query]
+}
+
+// newTestFailoverClient returns a failoverClient of two upstreams, served by
+// the returned clients
+func newTestFailoverClient(t *testing.T) (*failoverClient, []*upstreamClient) {
+	t.Helper()
+	c, err := newFailoverClient(
+		[]string{"tcp://127.0.0.1:26657", "tcp://127.0.0.1:26667"},
+		[]string{"127.0.0.1:9090", "127.0.0.1:9190"},
+		time.Second,
+		5,
+	)
+	require.NoError(t, err)
+
+	clients := []*upstreamClient{newUpstreamClient(100), newUpstreamClient(100)}
+	for i, u := range c.upstreams {
+		u.client = clients[i]
+	}
+	return c, clients
+}
+
+func TestNewFailoverClient(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rpcAddrs  []string
+		grpcAddrs []string
+		expPass   bool
+	}{
+		{
+			"fail - no upstream",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"fail - missing gRPC address",
+			[]string{"tcp://127.0.0.1:26657", "tcp://127.0.0.1:26667"},
+			[]string{"127.0.0.1:9090"},
+			false,
+		},
+		{
+			"fail - missing CometBFT rpc address",
+			[]string{"tcp://127.0.0.1:26657"},
+			[]string{"127.0.0.1:9090", "127.0.0.1:9190"},
+			false,
+		},
+		{
+			"pass",
+			[]string{"tcp://127.0.0.1:26657", "tcp://127.0.0.1:26667"},
+			[]string{"127.0.0.1:9090", "127.0.0.1:9190"},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newFailoverClient(tc.rpcAddrs, tc.grpcAddrs, time.Second, 5)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.upstreams, len(tc.rpcAddrs))
+			require.Equal(t, tc.rpcAddrs[0], c.upstream().rpcAddr)
+			require.Equal(t, tc.grpcAddrs[1], c.upstreams[1].grpcAddr)
+		})
+	}
+}
+
+func TestFailoverClientCheckHealth(t *testing.T) {
+	errNotResponding := errors.New("not responding")
+
+	testCases := []struct {
+		name       string
+		setStatus  func(clients []*upstreamClient)
+		expHealthy bool
+		expCurrent int
+	}{
+		{
+			"current upstream healthy",
+			func([]*upstreamClient) {},
+			true,
+			0,
+		},
+		{
+			"current upstream not responding",
+			func(clients []*upstreamClient) {
+				clients[0].setStatus(0, false, errNotResponding)
+			},
+			true,
+			1,
+		},
+		{
+			"current upstream catching up",
+			func(clients []*upstreamClient) {
+				clients[0].setStatus(100, true, nil)
+			},
+			true,
+			1,
+		},
+		{
+			"current upstream behind the max lag",
+			func(clients []*upstreamClient) {
+				clients[0].setStatus(94, false, nil)
+			},
+			true,
+			1,
+		},
+		{
+			"current upstream within the max lag",
+			func(clients []*upstreamClient) {
+				clients[0].setStatus(95, false, nil)
+			},
+			true,
+			0,
+		},
+		{
+			"no healthy upstream",
+			func(clients []*upstreamClient) {
+				clients[0].setStatus(0, false, errNotResponding)
+				clients[1].setStatus(100, true, nil)
+			},
+			false,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, clients := newTestFailoverClient(t)
+			tc.setStatus(clients)
+			require.Equal(t, tc.expHealthy, c.checkHealth())
+			require.Equal(t, c.upstreams[tc.expCurrent], c.upstream())
+		})
+	}
+}
+
+func TestFailoverClientSwitchUpstream(t *testing.T) {
+	c, clients := newTestFailoverClient(t)
+
+	// the websocket connections are made to the current upstream
+	var remotes []net.Conn
+	for _, u := range c.upstreams {
+		u.dial = func(string, string) (net.Conn, error) {
+			conn, remote := net.Pipe()
+			remotes = append(remotes, remote)
+			return conn, nil
+		}
+	}
+	conn, err := c.DialWS("tcp", "127.0.0.1:26657")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	query := "tm.event='NewBlockHeader'"
+	events, err := c.Subscribe(context.Background(), "test", query, 0)
+	require.NoError(t, err)
+	require.True(t, clients[0].IsRunning())
+	clients[0].eventsOf(query) <- coretypes.ResultEvent{Query: "0"}
+	require.Equal(t, "0", (<-events).Query)
+
+	// the failing upstream is replaced
+	clients[0].setStatus(0, false, errors.New("not responding"))
+	require.True(t, c.checkHealth())
+	require.Equal(t, c.upstreams[1], c.upstream())
+
+	// the websocket connections are closed for their clients to reconnect
+	require.Len(t, remotes, 1)
+	_, err = remotes[0].Read(make([]byte, 1))
+	require.Error(t, err)
+
+	// the subscriptions are moved to the new upstream
+	require.Nil(t, clients[0].eventsOf(query))
+	require.True(t, clients[1].IsRunning())
+	clients[1].eventsOf(query) <- coretypes.ResultEvent{Query: "1"}
+	require.Equal(t, "1", (<-events).Query)
+
+	// the subscriptions closed by the upstream are made again
+	close(clients[1].eventsOf(query))
+	require.Eventually(t, func() bool {
+		c.subsMtx.Lock()
+		defer c.subsMtx.Unlock()
+		return c.subs[subscriptionKey{subscriber: "test", query: query}].stop == nil
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, c.checkHealth())
+	require.Equal(t, c.upstreams[1], c.upstream())
+	clients[1].eventsOf(query) <- coretypes.ResultEvent{Query: "2"}
+	require.Equal(t, "2", (<-events).Query)
+
+	// the subscriptions aren't moved once unsubscribed
+	require.NoError(t, c.Unsubscribe(context.Background(), "test", query))
+	require.Nil(t, clients[1].eventsOf(query))
+	clients[0].setStatus(100, false, nil)
+	clients[1].setStatus(0, false, errors.New("not responding"))
+	require.True(t, c.checkHealth())
+	require.Equal(t, c.upstreams[0], c.upstream())
+	require.Nil(t, clients[0].eventsOf(query))
+}
@@ -45,6 +45,8 @@ const (
 	JSONRPCGasCap               = "json-rpc.gas-cap"
 	JSONRPCAllowInsecureUnlock  = "json-rpc.allow-insecure-unlock"
 	JSONRPCEVMTimeout           = "json-rpc.evm-timeout"
+	JSONRPCJSTracerStepLimit    = "json-rpc.js-tracer-step-limit"
+	JSONRPCTraceFileDir         = "json-rpc.trace-file-dir"
 	JSONRPCTxFeeCap             = "json-rpc.txfee-cap"
 	JSONRPCFilterCap            = "json-rpc.filter-cap"
 	JSONRPCLogsCap              = "json-rpc.logs-cap"
@@ -54,14 +56,22 @@ const (
 	JSONRPCAllowUnprotectedTxs  = "json-rpc.allow-unprotected-txs"
 	JSONRPCMaxOpenConnections   = "json-rpc.max-open-connections"
 	JSONRPCEnableIndexer        = "json-rpc.enable-indexer"
+	JSONRPCIndexerBackend       = "json-rpc.indexer-backend"
+	JSONRPCIndexerDSN           = "json-rpc.indexer-dsn"
 	JSONRPCBatchRequestLimit    = "json-rpc.batch-request-limit"
 	JSONRPCBatchResponseMaxSize = "json-rpc.batch-response-max-size"
 	JSONRPCEnableProfiling      = "json-rpc.enable-profiling"
+	JSONRPCGPOBlocks            = "json-rpc.gpo-blocks"
+	JSONRPCGPOPercentile        = "json-rpc.gpo-percentile"
+	JSONRPCGPOMaxPrice          = "json-rpc.gpo-max-price"
+	JSONRPCGPOIgnorePrice       = "json-rpc.gpo-ignore-price"
 	// JSONRPCEnableMetrics enables EVM RPC metrics server.
 	// Set to `metrics` which is hardcoded flag from go-ethereum.
 	// https://github.com/ethereum/go-ethereum/blob/master/metrics/metrics.go#L35-L55
 	JSONRPCEnableMetrics            = "metrics"
 	JSONRPCFixRevertGasRefundHeight = "json-rpc.fix-revert-gas-refund-height"
+	JSONRPCTraceBlockConcurrency    = "json-rpc.trace-block-concurrency"
+	JSONRPCIndexerSnapshotWindow    = "json-rpc.indexer-snapshot-window"
 )
 
 // EVM flags
@@ -69,6 +79,14 @@ const (
 	EVMTracer                  = "evm.tracer"
 	EVMMaxTxGasWanted          = "evm.max-tx-gas-wanted"
 	EVMEnablePreimageRecording = "evm.cache-preimage"
+	EVMLiveTracer              = "evm.live-tracer"
+	EVMLiveTracerConfig        = "evm.live-tracer-config"
+	EVMTracerEnableMemory      = "evm.tracer-options.enable-memory"
+	EVMTracerDisableStack      = "evm.tracer-options.disable-stack"
+	EVMTracerDisableStorage    = "evm.tracer-options.disable-storage"
+	EVMTracerEnableReturnData  = "evm.tracer-options.enable-return-data"
+	EVMTracerLimit             = "evm.tracer-options.limit"
+	EVMTracerOutput            = "evm.tracer-options.output"
 )
 
 // TLS flags
@@ -9,12 +9,20 @@ import (
 	sm "github.com/cometbft/cometbft/state"
 	cmtstore "github.com/cometbft/cometbft/store"
 
-	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/server/config"
+	cosmosevmtypes "github.com/cosmos/evm/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
+// firstIndexedBlocker is a custom eth tx indexer that reports the first block
+// it indexed, as the KVIndexer and the SQLIndexer do
+type firstIndexedBlocker interface {
+	cosmosevmtypes.EVMTxIndexer
+	FirstIndexedBlock() (int64, error)
+}
+
 // NewIndexTxCmd creates a new Cobra command to index historical Ethereum transactions.
 func NewIndexTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -43,12 +51,19 @@ func NewIndexTxCmd() *cobra.Command {
 			cfg := serverCtx.Config
 			home := cfg.RootDir
 			logger := serverCtx.Logger
-			idxDB, err := OpenIndexerDB(home, server.GetAppDBBackend(serverCtx.Viper))
+			evmCfg, err := config.GetConfig(serverCtx.Viper)
+			if err != nil {
+				return err
+			}
+			evmIdxer, err := OpenEVMIndexer(home, evmCfg.JSONRPC, server.GetAppDBBackend(serverCtx.Viper), logger.With("module", "evmindex"), clientCtx)
 			if err != nil {
 				logger.Error("failed to open evm indexer DB", "error", err.Error())
 				return err
 			}
-			idxer := indexer.NewKVIndexer(idxDB, logger.With("module", "evmindex"), clientCtx)
+			idxer, ok := evmIdxer.(firstIndexedBlocker)
+			if !ok {
+				return fmt.Errorf("the evm indexer can't be traversed backward")
+			}
 
 			// open local tendermint db, because the local rpc won't be available.
 			tmdb, err := cmtconfig.DefaultDBProvider(&cmtconfig.DBContext{ID: "blockstore", Config: cfg})
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtconfig "github.com/cometbft/cometbft/config"
+	sm "github.com/cometbft/cometbft/state"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/evm/client/block"
+	"github.com/cosmos/evm/indexer"
+	"github.com/cosmos/evm/server/config"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+const flagProgressInterval = "progress-interval"
+
+// indexerProgress is the progress of an indexer maintenance command, printed
+// as a JSON line
+type indexerProgress struct {
+	Command  string `json:"command"`
+	From     int64  `json:"from"`
+	To       int64  `json:"to"`
+	Height   int64  `json:"height"`
+	Blocks   int64  `json:"blocks"`
+	Issues   int    `json:"issues"`
+	Finished bool   `json:"finished"`
+}
+
+// indexerMaintenance holds the evm indexer and the local CometBFT stores the
+// maintenance commands run against, the local rpc not being available.
+type indexerMaintenance struct {
+	idxer      cosmosevmtypes.EVMTxIndexer
+	blockStore *block.Store
+	stateStore sm.Store
+	clientCtx  client.Context
+	logger     log.Logger
+}
+
+// NewIndexerCmd creates a new Cobra command to maintain the evm indexer db.
+func NewIndexerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evm-indexer",
+		Short: "Maintain the evm indexer db",
+		Long: `Maintain the evm indexer db against the local CometBFT block store, the node must be stopped.
+The progress is printed as JSON lines.`,
+	}
+	cmd.AddCommand(
+		newReindexCmd(),
+		newVerifyIndexerCmd(),
+		newPruneIndexerCmd(),
+	)
+	cmd.PersistentFlags().Int64(flagProgressInterval, 1000, "Number of blocks between the progress reports")
+	return cmd
+}
+
+func newReindexCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex [from] [to]",
+		Short: "Index again the eth txs of the blocks from height from to height to, included",
+		Long: `Index again the eth txs of the blocks from height from to height to, included.
+The indexed entries of each block are replaced along with indexing it again, so that an interrupted
+reindex leaves the blocks not reached yet indexed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := openIndexerMaintenance(cmd)
+			if err != nil {
+				return err
+			}
+			defer m.close()
+
+			progress, err := m.parseRange("reindex", args)
+			if err != nil {
+				return err
+			}
+			// indexing a block again doesn't delete the entries it no longer
+			// has, unless the indexer replaces them
+			reindex := m.idxer.IndexBlock
+			if idxer, ok := m.idxer.(cosmosevmtypes.EVMPrunableIndexer); ok {
+				reindex = idxer.ReindexBlock
+			}
+			return m.run(cmd, progress, reindex)
+		},
+	}
+}
+
+func newVerifyIndexerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [from] [to]",
+		Short: "Compare the indexed eth txs with the blocks from height from to height to, included",
+		Long: `Compare the indexed eth txs with the blocks from height from to height to, included, defaulting to the blocks of the block store.
+Each missing, extra or wrongly indexed eth tx is printed as a JSON line, and the command fails if any is found.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := openIndexerMaintenance(cmd)
+			if err != nil {
+				return err
+			}
+			defer m.close()
+
+			progress, err := m.parseRange("verify", args)
+			if err != nil {
+				return err
+			}
+			if err := m.run(cmd, progress, func(blk *cmttypes.Block, txResults []*abci.ExecTxResult) error {
+				for _, issue := range indexer.VerifyBlock(m.idxer, m.clientCtx, m.logger, blk, txResults) {
+					progress.Issues++
+					if err := printJSON(cmd, issue); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if progress.Issues > 0 {
+				return fmt.Errorf("found %d evm indexer issues", progress.Issues)
+			}
+			return nil
+		},
+	}
+}
+
+func newPruneIndexerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune [height]",
+		Short: "Delete the indexed eth txs of the blocks lower than height, defaulting to the base of the block store",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := openIndexerMaintenance(cmd)
+			if err != nil {
+				return err
+			}
+			defer m.close()
+
+			idxer, ok := m.idxer.(cosmosevmtypes.EVMPrunableIndexer)
+			if !ok {
+				return fmt.Errorf("the evm indexer can't be pruned")
+			}
+
+			var height int64
+			if len(args) > 0 {
+				if height, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+					return fmt.Errorf("invalid height %s: %w", args[0], err)
+				}
+			} else {
+				state, err := m.blockStore.State()
+				if err != nil {
+					return err
+				}
+				height = state.Base
+			}
+
+			first, err := idxer.FirstIndexedBlock()
+			if err != nil {
+				return err
+			}
+			if err := idxer.PruneBlocks(height); err != nil {
+				return err
+			}
+			return printJSON(cmd, indexerProgress{
+				Command:  "prune",
+				From:     first,
+				To:       height - 1,
+				Height:   height,
+				Finished: true,
+			})
+		},
+	}
+}
+
+// openIndexerMaintenance opens the evm indexer db and the local CometBFT
+// stores of the node.
+func openIndexerMaintenance(cmd *cobra.Command) (*indexerMaintenance, error) {
+	serverCtx := server.GetServerContextFromCmd(cmd)
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := serverCtx.Config
+	logger := serverCtx.Logger.With("module", "evmindex")
+	evmCfg, err := config.GetConfig(serverCtx.Viper)
+	if err != nil {
+		return nil, err
+	}
+	idxer, err := OpenEVMIndexer(cfg.RootDir, evmCfg.JSONRPC, server.GetAppDBBackend(serverCtx.Viper), logger, clientCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockStore, err := block.NewStore(cfg.RootDir, dbm.BackendType(cfg.DBBackend))
+	if err != nil {
+		return nil, err
+	}
+	stateDB, err := cmtconfig.DefaultDBProvider(&cmtconfig.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		blockStore.Close()
+		return nil, err
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: cfg.Storage.DiscardABCIResponses,
+	})
+
+	return &indexerMaintenance{
+		idxer:      idxer,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		clientCtx:  clientCtx,
+		logger:     logger,
+	}, nil
+}
+
+func (m *indexerMaintenance) close() {
+	if closer, ok := m.idxer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			m.logger.Error("failed to close evm indexer", "error", err.Error())
+		}
+	}
+	if err := m.blockStore.Close(); err != nil {
+		m.logger.Error("failed to close block store", "error", err.Error())
+	}
+	if err := m.stateStore.Close(); err != nil {
+		m.logger.Error("failed to close state store", "error", err.Error())
+	}
+}
+
+// parseRange parses the range of blocks of the command, which has to be in
+// the block store and defaults to its blocks
+func (m *indexerMaintenance) parseRange(command string, args []string) (*indexerProgress, error) {
+	state, err := m.blockStore.State()
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &indexerProgress{Command: command, From: state.Base, To: state.Height}
+	if len(args) > 0 {
+		if progress.From, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid from height %s: %w", args[0], err)
+		}
+	}
+	if len(args) > 1 {
+		if progress.To, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid to height %s: %w", args[1], err)
+		}
+	}
+
+	if progress.From > progress.To {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", progress.From, progress.To)
+	}
+	if progress.From < state.Base || progress.To > state.Height {
+		return nil, fmt.Errorf("blocks %d to %d aren't in the block store, which holds blocks %d to %d", progress.From, progress.To, state.Base, state.Height)
+	}
+	return progress, nil
+}
+
+// run calls fn with each block of the range along with its tx results,
+// printing the progress every progress-interval blocks and once done
+func (m *indexerMaintenance) run(cmd *cobra.Command, progress *indexerProgress, fn func(*cmttypes.Block, []*abci.ExecTxResult) error) error {
+	interval, err := cmd.Flags().GetInt64(flagProgressInterval)
+	if err != nil {
+		return err
+	}
+
+	for height := progress.From; height <= progress.To; height++ {
+		blk, err := m.blockStore.Block(height)
+		if err != nil {
+			return err
+		}
+		resBlk, err := m.stateStore.LoadFinalizeBlockResponse(height)
+		if err != nil {
+			return err
+		}
+		if err := fn(blk, resBlk.TxResults); err != nil {
+			return err
+		}
+
+		progress.Height = height
+		progress.Blocks++
+		if interval > 0 && progress.Blocks%interval == 0 && height < progress.To {
+			if err := printJSON(cmd, progress); err != nil {
+				return err
+			}
+		}
+	}
+
+	progress.Finished = true
+	return printJSON(cmd, progress)
+}
+
+func printJSON(cmd *cobra.Command, v any) error {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+	return err
+}
@@ -81,6 +81,7 @@ func (eis *EVMIndexerService) OnStart() error {
 	if lastBlock == -1 {
 		lastBlock = latestBlock
 	}
+	lastBlock = eis.skipUnavailableBlocks(ctx, lastBlock)
 
 	// blockErr indicates an error fetching an expected block or its results
 	var blockErr error
@@ -97,6 +98,10 @@ func (eis *EVMIndexerService) OnStart() error {
 			case <-newBlockSignal:
 			case <-time.After(NewBlockWaitTimeout):
 			}
+			if blockErr != nil {
+				blockErr = nil
+				lastBlock = eis.skipUnavailableBlocks(ctx, lastBlock)
+			}
 			continue
 		}
 		for i := lastBlock + 1; i <= latestBlock; i++ {
@@ -122,3 +127,19 @@ func (eis *EVMIndexerService) OnStart() error {
 		}
 	}
 }
+
+// skipUnavailableBlocks returns the last indexed block, moved up to the block
+// below the earliest block of the block store, as the blocks skipped by state
+// sync or pruned can't be indexed.
+func (eis *EVMIndexerService) skipUnavailableBlocks(ctx context.Context, lastBlock int64) int64 {
+	status, err := eis.client.Status(ctx)
+	if err != nil {
+		eis.Logger.Error("failed to fetch status", "err", err)
+		return lastBlock
+	}
+	if earliest := status.SyncInfo.EarliestBlockHeight; lastBlock < earliest-1 {
+		eis.Logger.Info("skipping the blocks missing from the block store", "from", lastBlock+1, "to", earliest-1)
+		return earliest - 1
+	}
+	return lastBlock
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+
 	"github.com/cosmos/evm/rpc"
+	_ "github.com/cosmos/evm/rpc/namespaces/ethereum/ots" // register the ots namespace
 	serverconfig "github.com/cosmos/evm/server/config"
 	cosmosevmtypes "github.com/cosmos/evm/types"
 
@@ -17,15 +20,17 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
-// StartJSONRPC starts the JSON-RPC server
+// StartJSONRPC starts the JSON-RPC server, applying the given options to its
+// Tendermint WS clients
 func StartJSONRPC(ctx *server.Context,
 	clientCtx client.Context,
 	tmRPCAddr,
 	tmEndpoint string,
 	config *serverconfig.Config,
 	indexer cosmosevmtypes.EVMTxIndexer,
+	wsOptions ...func(*rpcclient.WSClient),
 ) (*http.Server, chan struct{}, error) {
-	tmWsClient := ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
+	tmWsClient := ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger, wsOptions...)
 
 	logger := ctx.Logger.With("module", "geth")
 	// Set Geth's global logger to use this handler
@@ -99,8 +104,21 @@ func StartJSONRPC(ctx *server.Context,
 	ctx.Logger.Info("Starting JSON WebSocket server", "address", config.JSONRPC.WsAddress)
 
 	// allocate separate WS connection to Tendermint
-	tmWsClient = ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
-	wsSrv := rpc.NewWebsocketsServer(clientCtx, ctx.Logger, tmWsClient, config)
+	wsTmWsClient := ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger, wsOptions...)
+	wsSrv := rpc.NewWebsocketsServer(clientCtx, ctx.Logger, wsTmWsClient, config)
 	wsSrv.Start()
+
+	// the WS clients are stopped along with the server, for the event systems
+	// subscribed through them to be dropped
+	httpSrv.RegisterOnShutdown(func() {
+		for _, client := range []*rpcclient.WSClient{tmWsClient, wsTmWsClient} {
+			if client == nil || !client.IsRunning() {
+				continue
+			}
+			if err := client.Stop(); err != nil {
+				ctx.Logger.Error("failed to stop Tendermint WS client", "error", err.Error())
+			}
+		}
+	})
 	return httpSrv, httpSrvDone, nil
 }
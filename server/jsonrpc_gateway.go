@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+
+	cosmosevmserverconfig "github.com/cosmos/evm/server/config"
+	srvflags "github.com/cosmos/evm/server/flags"
+	cosmosevmtypes "github.com/cosmos/evm/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
+	servercmtlog "github.com/cosmos/cosmos-sdk/server/log"
+)
+
+const (
+	flagUpstreamRPC         = "upstream-rpc"
+	flagUpstreamGRPC        = "upstream-grpc"
+	flagHealthCheckInterval = "health-check-interval"
+	flagMaxBlockLag         = "max-block-lag"
+
+	// DefaultHealthCheckInterval is the default period of the health checks of the upstream nodes
+	DefaultHealthCheckInterval = 5 * time.Second
+	// DefaultMaxBlockLag is the default number of blocks an upstream node can be behind the others
+	DefaultMaxBlockLag int64 = 5
+)
+
+// NewJSONRPCGatewayCmd creates a new Cobra command running the JSON-RPC and
+// WebSocket servers against remote nodes.
+func NewJSONRPCGatewayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jsonrpc-gateway",
+		Short: "Run the JSON-RPC and WebSocket servers against remote nodes",
+		Long: `Run the JSON-RPC and WebSocket servers, along with the custom tx indexer if enabled, without node.
+The requests are forwarded over CometBFT RPC and gRPC to one of the upstream nodes, given in order of preference
+as pairs of --upstream-rpc and --upstream-grpc addresses. The upstream in use is replaced by the first healthy one
+once it stops responding, catches up or falls more than --max-block-lag blocks behind the others,
+along with the event subscriptions and the WebSocket connections.
+
+The JSON-RPC servers are configured by the json-rpc section of app.toml in the home directory.`,
+		Example: `evmd jsonrpc-gateway --upstream-rpc tcp://node0:26657,tcp://node1:26657 --upstream-grpc node0:9090,node1:9090`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			rpcAddrs, err := cmd.Flags().GetStringSlice(flagUpstreamRPC)
+			if err != nil {
+				return err
+			}
+			grpcAddrs, err := cmd.Flags().GetStringSlice(flagUpstreamGRPC)
+			if err != nil {
+				return err
+			}
+			interval, err := cmd.Flags().GetDuration(flagHealthCheckInterval)
+			if err != nil {
+				return err
+			}
+			maxLag, err := cmd.Flags().GetInt64(flagMaxBlockLag)
+			if err != nil {
+				return err
+			}
+
+			upstreams, err := newFailoverClient(rpcAddrs, grpcAddrs, interval, maxLag)
+			if err != nil {
+				return err
+			}
+			return startJSONRPCGateway(serverCtx, clientCtx, upstreams)
+		},
+	}
+
+	cmd.Flags().StringSlice(flagUpstreamRPC, nil, "The CometBFT RPC addresses of the upstream nodes, in order of preference")
+	cmd.Flags().StringSlice(flagUpstreamGRPC, nil, "The gRPC addresses of the upstream nodes, in the order of the CometBFT RPC addresses")
+	cmd.Flags().Duration(flagHealthCheckInterval, DefaultHealthCheckInterval, "The period of the health checks of the upstream nodes")
+	cmd.Flags().Int64(flagMaxBlockLag, DefaultMaxBlockLag, "The number of blocks an upstream node can be behind the others before failing over")
+
+	cmd.Flags().StringSlice(srvflags.JSONRPCAPI, cosmosevmserverconfig.GetDefaultAPINamespaces(), "Defines a list of JSON-RPC namespaces that should be enabled")
+	cmd.Flags().String(srvflags.JSONRPCAddress, cosmosevmserverconfig.DefaultJSONRPCAddress, "the JSON-RPC server address to listen on")
+	cmd.Flags().String(srvflags.JSONWsAddress, cosmosevmserverconfig.DefaultJSONRPCWsAddress, "the JSON-RPC WS server address to listen on")
+	cmd.Flags().Bool(srvflags.JSONRPCEnableIndexer, false, "Enable the custom tx indexer for json-rpc")
+
+	_ = cmd.MarkFlagRequired(flagUpstreamRPC)
+	_ = cmd.MarkFlagRequired(flagUpstreamGRPC)
+	return cmd
+}
+
+// startJSONRPCGateway starts the JSON-RPC servers and the custom tx indexer
+// service against the upstream nodes, and blocks until a quit signal.
+func startJSONRPCGateway(svrCtx *server.Context, clientCtx client.Context, upstreams *failoverClient) error {
+	logger := svrCtx.Logger
+	g, ctx := getCtx(svrCtx, true)
+
+	config, err := cosmosevmserverconfig.GetConfig(svrCtx.Viper)
+	if err != nil {
+		logger.Error("failed to get server config", "error", err.Error())
+		return err
+	}
+	if err := config.ValidateBasic(); err != nil {
+		logger.Error("invalid server config", "error", err.Error())
+		return err
+	}
+
+	upstreams.SetLogger(servercmtlog.CometLoggerWrapper{Logger: logger.With("module", "upstream")})
+	if err := upstreams.Start(); err != nil {
+		logger.Error("failed to connect to the upstream nodes", "error", err.Error())
+		return err
+	}
+	defer func() {
+		_ = upstreams.Stop()
+	}()
+
+	maxSendMsgSize := config.GRPC.MaxSendMsgSize
+	if maxSendMsgSize == 0 {
+		maxSendMsgSize = serverconfig.DefaultGRPCMaxSendMsgSize
+	}
+	maxRecvMsgSize := config.GRPC.MaxRecvMsgSize
+	if maxRecvMsgSize == 0 {
+		maxRecvMsgSize = serverconfig.DefaultGRPCMaxRecvMsgSize
+	}
+	// the gRPC connections are resolved to the current upstream
+	grpcClient, err := grpc.NewClient(
+		upstreams.GRPCTarget(),
+		grpc.WithResolvers(upstreams.resolver),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.ForceCodec(codec.NewProtoCodec(clientCtx.InterfaceRegistry).GRPCCodec()),
+			grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(maxSendMsgSize),
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer grpcClient.Close()
+
+	status, err := upstreams.Status(ctx)
+	if err != nil {
+		return err
+	}
+	clientCtx = clientCtx.
+		WithClient(upstreams).
+		WithGRPCClient(grpcClient).
+		WithChainID(status.NodeInfo.Network)
+
+	var idxer cosmosevmtypes.EVMTxIndexer
+	if config.JSONRPC.EnableIndexer {
+		idxLogger := logger.With("indexer", "evm")
+		idxer, err = OpenEVMIndexer(svrCtx.Config.RootDir, config.JSONRPC, server.GetAppDBBackend(svrCtx.Viper), idxLogger, clientCtx)
+		if err != nil {
+			logger.Error("failed to open evm indexer DB", "error", err.Error())
+			return err
+		}
+		indexerService := NewEVMIndexerService(idxer, upstreams)
+		indexerService.SetLogger(servercmtlog.CometLoggerWrapper{Logger: idxLogger})
+
+		// the service never returns, so it isn't waited for on shutdown
+		go func() {
+			if err := indexerService.Start(); err != nil {
+				idxLogger.Error("failed to start evm indexer service", "error", err.Error())
+			}
+		}()
+	}
+
+	// the websocket clients of the servers dial the current upstream, and
+	// reconnect to the new one on a switch
+	dialWS := func(c *rpcclient.WSClient) {
+		c.Dialer = upstreams.DialWS
+	}
+	httpSrv, httpSrvDone, err := StartJSONRPC(svrCtx, clientCtx, upstreams.upstream().rpcAddr, "/websocket", &config, idxer, dialWS)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		shutdownCtx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelFn()
+		if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+			logger.Error("HTTP server shutdown produced a warning", "error", err.Error())
+		} else {
+			logger.Info("HTTP server shut down, waiting 5 sec")
+			select {
+			case <-time.Tick(5 * time.Second):
+			case <-httpSrvDone:
+			}
+		}
+	}()
+
+	// wait for signal capture and gracefully return
+	return g.Wait()
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"net"
@@ -12,10 +13,12 @@ import (
 	"time"
 
 	ethmetricsexp "github.com/ethereum/go-ethereum/metrics/exp"
+	_ "github.com/lib/pq" // postgres driver of the SQL indexer
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "modernc.org/sqlite" // sqlite driver of the SQL indexer
 
 	abciserver "github.com/cometbft/cometbft/abci/server"
 	tcmd "github.com/cometbft/cometbft/cmd/cometbft/commands"
@@ -37,6 +40,7 @@ import (
 	cosmosevmtypes "github.com/cosmos/evm/types"
 
 	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
 	pruningtypes "cosmossdk.io/store/pruning/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -191,7 +195,10 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Bool(srvflags.JSONRPCAllowInsecureUnlock, cosmosevmserverconfig.DefaultJSONRPCAllowInsecureUnlock, "Allow insecure account unlocking when account-related RPCs are exposed by http") //nolint:lll
 	cmd.Flags().Float64(srvflags.JSONRPCTxFeeCap, cosmosevmserverconfig.DefaultTxFeeCap, "Sets a cap on transaction fee that can be sent via the RPC APIs (1 = default 1 evmos)")                    //nolint:lll
 	cmd.Flags().Int32(srvflags.JSONRPCFilterCap, cosmosevmserverconfig.DefaultFilterCap, "Sets the global cap for total number of filters that can be created")
-	cmd.Flags().Duration(srvflags.JSONRPCEVMTimeout, cosmosevmserverconfig.DefaultEVMTimeout, "Sets a timeout used for eth_call (0=infinite)")
+	cmd.Flags().Duration(srvflags.JSONRPCEVMTimeout, cosmosevmserverconfig.DefaultEVMTimeout, "Sets a timeout used for eth_call and the JS tracers (0=infinite)")
+	cmd.Flags().String(srvflags.JSONRPCTraceFileDir, "", "Sets the directory of the trace files written by the debug_*TraceBlockToFile methods (default the temporary directory)")
+	cmd.Flags().Int(srvflags.JSONRPCTraceBlockConcurrency, cosmosevmserverconfig.DefaultTraceBlockConcurrency, "Sets the number of transactions traced concurrently by the block traces (0=in order)")
+	cmd.Flags().Uint64(srvflags.JSONRPCJSTracerStepLimit, cosmosevmserverconfig.DefaultJSTracerStepLimit, "Sets the number of EVM steps, call frames and faults a JS tracer is called back for during a trace after which the tracer is stopped (0=unlimited)")
 	cmd.Flags().Duration(srvflags.JSONRPCHTTPTimeout, cosmosevmserverconfig.DefaultHTTPTimeout, "Sets a read/write timeout for json-rpc http server (0=infinite)")
 	cmd.Flags().Duration(srvflags.JSONRPCHTTPIdleTimeout, cosmosevmserverconfig.DefaultHTTPIdleTimeout, "Sets a idle timeout for json-rpc http server (0=infinite)")
 	cmd.Flags().Bool(srvflags.JSONRPCAllowUnprotectedTxs, cosmosevmserverconfig.DefaultAllowUnprotectedTxs, "Allow for unprotected (non EIP155 signed) transactions to be submitted via the node's RPC when the global parameter is disabled") //nolint:lll
@@ -201,12 +208,27 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Int32(srvflags.JSONRPCBlockRangeCap, cosmosevmserverconfig.DefaultBlockRangeCap, "Sets the max block range allowed for `eth_getLogs` query")
 	cmd.Flags().Int(srvflags.JSONRPCMaxOpenConnections, cosmosevmserverconfig.DefaultMaxOpenConnections, "Sets the maximum number of simultaneous connections for the server listener") //nolint:lll
 	cmd.Flags().Bool(srvflags.JSONRPCEnableIndexer, false, "Enable the custom tx indexer for json-rpc")
+	cmd.Flags().String(srvflags.JSONRPCIndexerBackend, cosmosevmserverconfig.DefaultIndexerBackend, "Sets the database of the custom tx indexer (kv|sqlite|postgres)")
+	cmd.Flags().String(srvflags.JSONRPCIndexerDSN, "", "Sets the data source name of the sqlite or postgres indexer database (default sqlite database data/evmindexer.sqlite)")
+	cmd.Flags().Int64(srvflags.JSONRPCIndexerSnapshotWindow, cosmosevmserverconfig.DefaultIndexerSnapshotWindow, "Sets the number of blocks whose indexed eth txs are exported in the state-sync snapshots (0=disabled); a positive window makes the snapshots incompatible with the peers not enabling it")
 	cmd.Flags().Bool(srvflags.JSONRPCEnableMetrics, false, "Define if EVM rpc metrics server should be enabled")
 	cmd.Flags().Bool(srvflags.JSONRPCEnableProfiling, false, "Enables the profiling in the debug namespace")
+	cmd.Flags().Int(srvflags.JSONRPCGPOBlocks, cosmosevmserverconfig.DefaultGPOBlocks, "Number of recent blocks sampled by the gas price oracle")
+	cmd.Flags().Int(srvflags.JSONRPCGPOPercentile, cosmosevmserverconfig.DefaultGPOPercentile, "Percentile of the sampled tips suggested by the gas price oracle")
+	cmd.Flags().Uint64(srvflags.JSONRPCGPOMaxPrice, cosmosevmserverconfig.DefaultGPOMaxPrice, "Maximum gas tip cap suggested by the gas price oracle, in wei (0=no cap)")
+	cmd.Flags().Uint64(srvflags.JSONRPCGPOIgnorePrice, cosmosevmserverconfig.DefaultGPOIgnorePrice, "Gas tip below which transactions are ignored by the gas price oracle, in wei")
 
 	cmd.Flags().String(srvflags.EVMTracer, cosmosevmserverconfig.DefaultEVMTracer, "the EVM tracer type to collect execution traces from the EVM transaction execution (json|struct|access_list|markdown)") //nolint:lll
 	cmd.Flags().Uint64(srvflags.EVMMaxTxGasWanted, cosmosevmserverconfig.DefaultMaxTxGasWanted, "the gas wanted for each eth tx returned in ante handler in check tx mode")                                 //nolint:lll
-	cmd.Flags().Bool(srvflags.EVMEnablePreimageRecording, cosmosevmserverconfig.DefaultEnablePreimageRecording, "Enables tracking of SHA3 preimages in the EVM (not implemented yet)")                      //nolint:lll
+	cmd.Flags().Bool(srvflags.EVMEnablePreimageRecording, cosmosevmserverconfig.DefaultEnablePreimageRecording, "Enables tracking of SHA3 preimages in the EVM, stored in the node data directory")         //nolint:lll
+	cmd.Flags().String(srvflags.EVMLiveTracer, "", "the live tracer invoked for every block executed by the node (jsonl)")
+	cmd.Flags().String(srvflags.EVMLiveTracerConfig, "", "the JSON configuration of the live tracer")
+	cmd.Flags().Bool(srvflags.EVMTracerEnableMemory, false, "enables the memory capture of the EVM tracer")
+	cmd.Flags().Bool(srvflags.EVMTracerDisableStack, false, "disables the stack capture of the EVM tracer")
+	cmd.Flags().Bool(srvflags.EVMTracerDisableStorage, false, "disables the storage capture of the EVM tracer")
+	cmd.Flags().Bool(srvflags.EVMTracerEnableReturnData, false, "enables the return data capture of the EVM tracer")
+	cmd.Flags().Int(srvflags.EVMTracerLimit, 0, "the maximum size of the EVM tracer output, 0 for no limit")
+	cmd.Flags().String(srvflags.EVMTracerOutput, "", "the path of the file the json and markdown EVM tracers write to (default stderr and stdout respectively)")
 
 	cmd.Flags().String(srvflags.TLSCertPath, "", "the cert.pem file path for the server TLS configuration")
 	cmd.Flags().String(srvflags.TLSKeyPath, "", "the key.pem file path for the server TLS configuration")
@@ -362,8 +384,10 @@ func startInProcess(svrCtx *server.Context, clientCtx client.Context, opts Start
 	genDocProvider := GenDocProvider(cfg)
 
 	var (
-		tmNode   *node.Node
-		gRPCOnly = svrCtx.Viper.GetBool(srvflags.GRPCOnly)
+		tmNode    *node.Node
+		gRPCOnly  = svrCtx.Viper.GetBool(srvflags.GRPCOnly)
+		idxer     cosmosevmtypes.EVMTxIndexer
+		idxLogger = svrCtx.Logger.With("indexer", "evm")
 	)
 
 	if gRPCOnly {
@@ -389,6 +413,32 @@ func startInProcess(svrCtx *server.Context, clientCtx client.Context, opts Start
 			return err
 		}
 
+		// the evm indexer is opened before the node starts, to restore the state-sync snapshots
+		if config.JSONRPC.EnableIndexer {
+			idxer, err = OpenEVMIndexer(home, config.JSONRPC, server.GetAppDBBackend(svrCtx.Viper), idxLogger, clientCtx)
+			if err != nil {
+				logger.Error("failed to open evm indexer DB", "error", err.Error())
+				return err
+			}
+		}
+		if idxer != nil && config.JSONRPC.IndexerSnapshotWindow > 0 {
+			genDoc, err := genDocProvider()
+			if err != nil {
+				return err
+			}
+			snapshotter := indexer.NewSnapshotter(
+				idxer,
+				local.New(tmNode),
+				indexer.NewLightClientVerifier(genDoc.ChainID, *cfg.StateSync),
+				config.JSONRPC.IndexerSnapshotWindow,
+				idxLogger,
+			)
+			if err := registerIndexerSnapshotter(app, snapshotter); err != nil {
+				logger.Error("failed to register evm indexer snapshot extension", "error", err.Error())
+				return err
+			}
+		}
+
 		if err := tmNode.Start(); err != nil {
 			logger.Error("failed start CometBFT server", "error", err.Error())
 			return err
@@ -423,16 +473,7 @@ func startInProcess(svrCtx *server.Context, clientCtx client.Context, opts Start
 		ethmetricsexp.Setup(config.JSONRPC.MetricsAddress)
 	}
 
-	var idxer cosmosevmtypes.EVMTxIndexer
-	if config.JSONRPC.EnableIndexer {
-		idxDB, err := OpenIndexerDB(home, server.GetAppDBBackend(svrCtx.Viper))
-		if err != nil {
-			logger.Error("failed to open evm indexer DB", "error", err.Error())
-			return err
-		}
-
-		idxLogger := svrCtx.Logger.With("indexer", "evm")
-		idxer = indexer.NewKVIndexer(idxDB, idxLogger, clientCtx)
+	if idxer != nil {
 		indexerService := NewEVMIndexerService(idxer, clientCtx.Client.(rpcclient.Client))
 		indexerService.SetLogger(servercmtlog.CometLoggerWrapper{Logger: idxLogger})
 
@@ -496,12 +537,64 @@ func startInProcess(svrCtx *server.Context, clientCtx client.Context, opts Start
 	return g.Wait()
 }
 
+// registerIndexerSnapshotter registers the evm indexer extension on the
+// snapshot manager of the app, if snapshots are enabled. The snapshots holding
+// the extension can only be restored by the nodes registering it, the other
+// ones failing with an unknown extension error.
+func registerIndexerSnapshotter(app types.Application, snapshotter *indexer.Snapshotter) error {
+	manager := app.SnapshotManager()
+	if manager == nil {
+		return nil
+	}
+	return manager.RegisterExtensions(snapshotter)
+}
+
 // OpenIndexerDB opens the custom eth indexer db, using the same db backend as the main app
 func OpenIndexerDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
 	dataDir := filepath.Join(rootDir, "data")
 	return dbm.NewDB("evmindexer", backendType, dataDir)
 }
 
+// OpenEVMIndexer opens the custom eth indexer on the database selected by the
+// json-rpc config: the KV db opened by OpenIndexerDB, or a SQLite or Postgres db
+func OpenEVMIndexer(
+	rootDir string,
+	cfg cosmosevmserverconfig.JSONRPCConfig,
+	backendType dbm.BackendType,
+	logger log.Logger,
+	clientCtx client.Context,
+) (cosmosevmtypes.EVMTxIndexer, error) {
+	var (
+		driver = cfg.IndexerBackend
+		dsn    = cfg.IndexerDSN
+	)
+	switch cfg.IndexerBackend {
+	case cosmosevmserverconfig.IndexerBackendSQLite:
+		if dsn == "" {
+			// the indexer service writes while the json-rpc server reads
+			dsn = "file:" + filepath.Join(rootDir, "data", "evmindexer.sqlite") + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+		}
+	case cosmosevmserverconfig.IndexerBackendPostgres:
+	default:
+		idxDB, err := OpenIndexerDB(rootDir, backendType)
+		if err != nil {
+			return nil, err
+		}
+		return indexer.NewKVIndexer(idxDB, logger, clientCtx), nil
+	}
+
+	sqlDB, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	idxer, err := indexer.NewSQLIndexer(sqlDB, logger, clientCtx)
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return idxer, nil
+}
+
 // openTraceWriter opens a trace writer if a trace store file is specified.
 // Parameters:
 // - traceWriterFile: The path to the trace store file. If this is an empty string, no file will be opened.
@@ -13,6 +13,7 @@ import (
 	tmcmd "github.com/cometbft/cometbft/cmd/cometbft/commands"
 	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 
+	"github.com/cosmos/evm/rpc/namespaces/ethereum/eth/filters"
 	"github.com/cosmos/evm/server/config"
 
 	"cosmossdk.io/log"
@@ -57,6 +58,10 @@ func AddCommands(
 
 		// custom tx indexer command
 		NewIndexTxCmd(),
+		NewIndexerCmd(),
+
+		// standalone json-rpc servers
+		NewJSONRPCGatewayCmd(),
 	)
 }
 
@@ -65,16 +70,22 @@ func AddCommands(
 // - tmRPCAddr: The RPC address of the Tendermint server.
 // - tmEndpoint: The WebSocket endpoint on the Tendermint server.
 // - logger: A logger instance used to log debug and error messages.
-func ConnectTmWS(tmRPCAddr, tmEndpoint string, logger log.Logger) *rpcclient.WSClient {
-	tmWsClient, err := rpcclient.NewWS(tmRPCAddr, tmEndpoint,
+// - options: Options applied to the WS client before it starts.
+//
+// The event subscriptions of the client are made again once it reconnects.
+func ConnectTmWS(tmRPCAddr, tmEndpoint string, logger log.Logger, options ...func(*rpcclient.WSClient)) *rpcclient.WSClient {
+	var tmWsClient *rpcclient.WSClient
+	options = append([]func(*rpcclient.WSClient){
 		rpcclient.MaxReconnectAttempts(256),
-		rpcclient.ReadWait(120*time.Second),
-		rpcclient.WriteWait(120*time.Second),
-		rpcclient.PingPeriod(50*time.Second),
+		rpcclient.ReadWait(120 * time.Second),
+		rpcclient.WriteWait(120 * time.Second),
+		rpcclient.PingPeriod(50 * time.Second),
 		rpcclient.OnReconnect(func() {
 			logger.Debug("EVM RPC reconnects to Tendermint WS", "address", tmRPCAddr+tmEndpoint)
+			filters.Resubscribe(tmWsClient)
 		}),
-	)
+	}, options...)
+	tmWsClient, err := rpcclient.NewWS(tmRPCAddr, tmEndpoint, options...)
 
 	if err != nil {
 		logger.Error(
@@ -82,7 +93,7 @@ func ConnectTmWS(tmRPCAddr, tmEndpoint string, logger log.Logger) *rpcclient.WSC
 			"address", tmRPCAddr+tmEndpoint,
 			"error", err,
 		)
-	} else if err := tmWsClient.OnStart(); err != nil {
+	} else if err := tmWsClient.Start(); err != nil {
 		logger.Error(
 			"Tendermint WS client could not start",
 			"address", tmRPCAddr+tmEndpoint,
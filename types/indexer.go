@@ -18,3 +18,69 @@ type EVMTxIndexer interface {
 	// GetByBlockAndIndex returns nil if tx not found.
 	GetByBlockAndIndex(int64, int32) (*TxResult, error)
 }
+
+// EVMAddressIndexer defines the interface of a custom eth tx indexer that also
+// indexes the txs by address, as required by the `ots` namespace. The address
+// indexes are only written for the blocks indexed once the indexer supports
+// them: the blocks indexed before have to be indexed again, with the
+// `evm-indexer reindex` command, to be found by address.
+type EVMAddressIndexer interface {
+	EVMTxIndexer
+
+	// GetByAddress returns the hashes of the txs sent from or to the address,
+	// or deploying a contract at it. Only the top-level sender, recipient and
+	// deployed contract of the txs are indexed: the addresses reached by
+	// internal calls, transfers and creations aren't. The txs are iterated from the given block,
+	// included, towards the older blocks if reverse is true and the newer ones
+	// otherwise; a negative block starts from the latest or first block.
+	// Whole blocks are collected until at least pageSize txs are found, the
+	// returned flag reports if txs are left in the following blocks.
+	GetByAddress(address common.Address, fromBlock int64, reverse bool, pageSize int) ([]common.Hash, bool, error)
+	// GetBySenderAndNonce returns nil if tx not found.
+	GetBySenderAndNonce(sender common.Address, nonce uint64) (*common.Hash, error)
+	// GetContractCreationTx returns nil if the contract wasn't deployed by a tx.
+	// The contracts created by other contracts, with CREATE or CREATE2, aren't
+	// indexed.
+	GetContractCreationTx(contract common.Address) (*common.Hash, error)
+}
+
+// EVMLogIndexer defines the interface of a custom eth tx indexer that also
+// indexes the logs by address and topics, so that the log filters don't have
+// to go through every block of their range.
+type EVMLogIndexer interface {
+	EVMTxIndexer
+
+	// LogIndexRange returns the first and last blocks of the latest range of
+	// consecutive blocks whose logs are indexed, -1 and -1 if none.
+	LogIndexRange() (int64, int64, error)
+	// GetLogLocations returns the locations of the logs emitted from fromBlock
+	// to toBlock, included, matching the addresses and topics like the
+	// eth_getLogs criteria, sorted by block and log index. At least one address
+	// or topic is required.
+	GetLogLocations(addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock int64) ([]LogLocation, error)
+}
+
+// LogLocation is the location of an eth log: its block, and its index in the
+// block.
+type LogLocation struct {
+	Height   int64
+	LogIndex uint
+}
+
+// EVMPrunableIndexer defines the interface of a custom eth tx indexer whose
+// entries can be deleted below a height, to follow the pruning of the blocks,
+// or over a range of blocks, to index them again.
+type EVMPrunableIndexer interface {
+	EVMTxIndexer
+
+	// FirstIndexedBlock returns -1 if indexer db is empty
+	FirstIndexedBlock() (int64, error)
+	// PruneBlocks deletes the entries of the blocks lower than height.
+	PruneBlocks(height int64) error
+	// DeleteBlocks deletes the entries of the blocks from fromBlock to
+	// toBlock, included, so that they can be indexed again.
+	DeleteBlocks(fromBlock, toBlock int64) error
+	// ReindexBlock indexes the block again, replacing the entries it was
+	// indexed with atomically.
+	ReindexBlock(block *cmttypes.Block, txResults []*abci.ExecTxResult) error
+}
@@ -1,8 +1,12 @@
 package keeper
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/tracing"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
+	cosmosevmtypes "github.com/cosmos/evm/types"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
 	storetypes "cosmossdk.io/store/types"
@@ -10,10 +14,21 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// BeginBlock emits a base fee event which will be adjusted to the evm decimals
+// BeginBlock emits a base fee event which will be adjusted to the evm decimals.
+// It also starts the block on the live tracer, if any.
 func (k *Keeper) BeginBlock(ctx sdk.Context) error {
 	logger := ctx.Logger().With("begin_block", "evm")
 
+	if tracer := k.liveTracing(ctx); tracer != nil && tracer.OnBlockStart != nil {
+		tracer.OnBlockStart(tracing.BlockEvent{
+			Block: ethtypes.NewBlockWithHeader(&ethtypes.Header{
+				Number:   big.NewInt(ctx.BlockHeight()),
+				Time:     uint64(ctx.BlockTime().Unix()), //#nosec G115 -- int overflow is not a concern here
+				GasLimit: cosmosevmtypes.BlockGasLimit(ctx),
+			}),
+		})
+	}
+
 	// Base fee is already set on FeeMarket BeginBlock
 	// that runs before this one
 	// We emit this event on the EVM and FeeMarket modules
@@ -35,8 +50,11 @@ func (k *Keeper) BeginBlock(ctx sdk.Context) error {
 }
 
 // EndBlock also retrieves the bloom filter value from the transient store and commits it to the
-// KVStore. The EVM end block logic doesn't update the validator set, thus it returns
-// an empty slice.
+// KVStore. If the block contains Ethereum transactions, it also derives their transactions and
+// receipts roots, stores them for the current height and emits them. The roots of the blocks
+// older than BlockRootsHistory are pruned. The EVM end block logic
+// doesn't update the validator set, thus it returns an empty slice. The block is
+// ended on the live tracer, if any.
 func (k *Keeper) EndBlock(ctx sdk.Context) error {
 	// Gas costs are handled within msg handler so costs should be ignored
 	infCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
@@ -44,5 +62,19 @@ func (k *Keeper) EndBlock(ctx sdk.Context) error {
 	bloom := ethtypes.BytesToBloom(k.GetBlockBloomTransient(infCtx).Bytes())
 	k.EmitBlockBloomEvent(infCtx, bloom)
 
+	txRoot, receiptsRoot, found, err := k.DeriveBlockRoots(infCtx)
+	if err != nil {
+		return err
+	}
+	if found {
+		k.SetBlockRoots(infCtx, ctx.BlockHeight(), txRoot, receiptsRoot)
+		k.EmitBlockRootsEvent(infCtx, txRoot, receiptsRoot)
+	}
+	k.PruneBlockRoots(infCtx, ctx.BlockHeight())
+
+	if tracer := k.liveTracing(ctx); tracer != nil && tracer.OnBlockEnd != nil {
+		tracer.OnBlockEnd(nil)
+	}
+
 	return nil
 }
@@ -1,6 +1,12 @@
 package keeper_test
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+
 	testkeyring "github.com/cosmos/evm/testutil/integration/os/keyring"
 	"github.com/cosmos/evm/testutil/integration/os/network"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
@@ -22,4 +28,82 @@ func (suite *KeeperTestSuite) TestEndBlock() {
 	// should emit 1 EventTypeBlockBloom event on EndBlock
 	suite.Require().Equal(1, len(postEventManager.Events()))
 	suite.Require().Equal(evmtypes.EventTypeBlockBloom, postEventManager.Events()[0].Type)
+
+	// no roots are stored for a block without Ethereum transactions
+	_, _, found := unitNetwork.App.EVMKeeper.GetBlockRoots(ctx, ctx.BlockHeight())
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestEndBlockRoots() {
+	suite.SetupTest()
+	ctx := suite.network.GetContext()
+
+	var (
+		txs      ethtypes.Transactions
+		receipts ethtypes.Receipts
+		gasUsed  uint64
+	)
+	for i := range 2 {
+		to := suite.keyring.GetAddr(1 - i)
+		tx, err := suite.factory.GenerateSignedEthTx(suite.keyring.GetPrivKey(i), evmtypes.EvmTxArgs{
+			To:       &to,
+			Amount:   big.NewInt(1),
+			GasPrice: big.NewInt(0),
+		})
+		suite.Require().NoError(err)
+
+		ethTx := tx.GetMsgs()[0].(*evmtypes.MsgEthereumTx).AsTransaction()
+		res, err := suite.network.App.EVMKeeper.ApplyTransaction(ctx, ethTx)
+		suite.Require().NoError(err)
+		suite.Require().False(res.Failed())
+
+		gasUsed += res.GasUsed
+		receipt := &ethtypes.Receipt{
+			Type:              ethTx.Type(),
+			Status:            ethtypes.ReceiptStatusSuccessful,
+			CumulativeGasUsed: gasUsed,
+			Logs:              []*ethtypes.Log{},
+		}
+		receipt.Bloom = ethtypes.CreateBloom(receipt)
+		txs = append(txs, ethTx)
+		receipts = append(receipts, receipt)
+	}
+
+	err := suite.network.App.EVMKeeper.EndBlock(ctx)
+	suite.Require().NoError(err)
+
+	expTxRoot := ethtypes.DeriveSha(txs, trie.NewStackTrie(nil))
+	expReceiptsRoot := ethtypes.DeriveSha(receipts, trie.NewStackTrie(nil))
+	suite.Require().NotEqual(ethtypes.EmptyReceiptsHash, expReceiptsRoot)
+
+	txRoot, receiptsRoot, found := suite.network.App.EVMKeeper.GetBlockRoots(ctx, ctx.BlockHeight())
+	suite.Require().True(found)
+	suite.Require().Equal(expTxRoot, txRoot)
+	suite.Require().Equal(expReceiptsRoot, receiptsRoot)
+
+	events := ctx.EventManager().Events()
+	event := events[len(events)-1]
+	suite.Require().Equal(evmtypes.EventTypeBlockRoots, event.Type)
+	suite.Require().Equal(expTxRoot.Hex(), event.Attributes[0].Value)
+	suite.Require().Equal(expReceiptsRoot.Hex(), event.Attributes[1].Value)
+}
+
+func (suite *KeeperTestSuite) TestEndBlockPruneRoots() {
+	suite.SetupTest()
+	ctx := suite.network.GetContext()
+	keeper := suite.network.App.EVMKeeper
+
+	txRoot, receiptsRoot := common.HexToHash("0x01"), common.HexToHash("0x02")
+	keeper.SetBlockRoots(ctx, 1, txRoot, receiptsRoot)
+	keeper.SetBlockRoots(ctx, 2, txRoot, receiptsRoot)
+
+	// the roots are kept for the last BlockRootsHistory blocks
+	err := keeper.EndBlock(ctx.WithBlockHeight(evmtypes.BlockRootsHistory + 1))
+	suite.Require().NoError(err)
+	_, _, found := keeper.GetBlockRoots(ctx, 1)
+	suite.Require().False(found)
+	storedTxRoot, storedReceiptsRoot, found := keeper.GetBlockRoots(ctx, 2)
+	suite.Require().True(found)
+	suite.Require().Equal(txRoot, storedTxRoot)
+	suite.Require().Equal(receiptsRoot, storedReceiptsRoot)
 }
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/cosmos/evm/x/vm/types"
+
+	"cosmossdk.io/store/prefix"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ----------------------------------------------------------------------------
+// Block Roots
+// Required by Web3 API.
+// ----------------------------------------------------------------------------
+
+// SetTransientReceipt stores the Ethereum transaction with the given index on
+// the current block along with the consensus fields of its receipt, so that
+// the transactions and receipts roots of the block can be derived at EndBlock.
+// The cumulative gas used only accounts for the EVM transactions of the block.
+func (k Keeper) SetTransientReceipt(ctx sdk.Context, txIndex uint64, tx *ethtypes.Transaction, res *types.MsgEthereumTxResponse) error {
+	cumulativeGasUsed := res.GasUsed
+	if txIndex > 0 {
+		prev, err := k.GetTransientReceipt(ctx, txIndex-1)
+		if err != nil {
+			return err
+		}
+		if prev != nil {
+			cumulativeGasUsed += prev.CumulativeGasUsed
+		}
+	}
+
+	receipt := &ethtypes.Receipt{
+		Type:              tx.Type(),
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: cumulativeGasUsed,
+		Logs:              types.LogsToEthereum(res.Logs),
+	}
+	if res.Failed() {
+		receipt.Status = ethtypes.ReceiptStatusFailed
+	}
+	receipt.Bloom = ethtypes.CreateBloom(receipt)
+
+	txBz, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	receiptBz, err := receipt.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	key := sdk.Uint64ToBigEndian(txIndex)
+	prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientTx).Set(key, txBz)
+	prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientReceipt).Set(key, receiptBz)
+	return nil
+}
+
+// GetTransientReceipt returns the receipt of the Ethereum transaction with the
+// given index on the current block, or nil if it hasn't been executed.
+func (k Keeper) GetTransientReceipt(ctx sdk.Context, txIndex uint64) (*ethtypes.Receipt, error) {
+	store := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientReceipt)
+	bz := store.Get(sdk.Uint64ToBigEndian(txIndex))
+	if len(bz) == 0 {
+		return nil, nil
+	}
+
+	receipt := new(ethtypes.Receipt)
+	if err := receipt.UnmarshalBinary(bz); err != nil {
+		return nil, fmt.Errorf("failed to decode receipt %d: %w", txIndex, err)
+	}
+	return receipt, nil
+}
+
+// DeriveBlockRoots computes the Merkle-Patricia transactions and receipts roots
+// of the Ethereum transactions executed on the current block. It returns false
+// if no Ethereum transaction has been executed.
+func (k Keeper) DeriveBlockRoots(ctx sdk.Context) (txRoot, receiptsRoot common.Hash, found bool, err error) {
+	var (
+		txs      ethtypes.Transactions
+		receipts ethtypes.Receipts
+	)
+
+	txIterator := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientTx).Iterator(nil, nil)
+	defer txIterator.Close()
+	for ; txIterator.Valid(); txIterator.Next() {
+		tx := new(ethtypes.Transaction)
+		if err := tx.UnmarshalBinary(txIterator.Value()); err != nil {
+			return common.Hash{}, common.Hash{}, false, fmt.Errorf("failed to decode transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	receiptIterator := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientReceipt).Iterator(nil, nil)
+	defer receiptIterator.Close()
+	for ; receiptIterator.Valid(); receiptIterator.Next() {
+		receipt := new(ethtypes.Receipt)
+		if err := receipt.UnmarshalBinary(receiptIterator.Value()); err != nil {
+			return common.Hash{}, common.Hash{}, false, fmt.Errorf("failed to decode receipt: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if len(txs) == 0 {
+		return ethtypes.EmptyTxsHash, ethtypes.EmptyReceiptsHash, false, nil
+	}
+
+	txRoot = ethtypes.DeriveSha(txs, trie.NewStackTrie(nil))
+	receiptsRoot = ethtypes.DeriveSha(receipts, trie.NewStackTrie(nil))
+	return txRoot, receiptsRoot, true, nil
+}
+
+// SetBlockRoots stores the transactions and receipts roots of the block at the
+// given height, so that they are still served once the FinalizeBlock events
+// are pruned or discarded.
+func (k Keeper) SetBlockRoots(ctx sdk.Context, height int64, txRoot, receiptsRoot common.Hash) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.BlockRootsKey(uint64(height)), append(txRoot.Bytes(), receiptsRoot.Bytes()...)) //#nosec G115 -- block heights are positive
+}
+
+// GetBlockRoots returns the transactions and receipts roots of the block at the
+// given height. It returns false if the block didn't contain any Ethereum
+// transaction or if its roots have been pruned.
+func (k Keeper) GetBlockRoots(ctx sdk.Context, height int64) (txRoot, receiptsRoot common.Hash, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.BlockRootsKey(uint64(height))) //#nosec G115 -- block heights are positive
+	if len(bz) != 2*common.HashLength {
+		return ethtypes.EmptyTxsHash, ethtypes.EmptyReceiptsHash, false
+	}
+	return common.BytesToHash(bz[:common.HashLength]), common.BytesToHash(bz[common.HashLength:]), true
+}
+
+// PruneBlockRoots deletes the roots of the block falling out of the last
+// BlockRootsHistory blocks at the given height.
+func (k Keeper) PruneBlockRoots(ctx sdk.Context, height int64) {
+	if height <= types.BlockRootsHistory {
+		return
+	}
+	ctx.KVStore(k.storeKey).Delete(types.BlockRootsKey(uint64(height - types.BlockRootsHistory))) //#nosec G115 -- checked for negative value above
+}
+
+// EmitBlockRootsEvent emits the transactions and receipts roots of the block.
+func (k Keeper) EmitBlockRootsEvent(ctx sdk.Context, txRoot, receiptsRoot common.Hash) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeBlockRoots,
+			sdk.NewAttribute(types.AttributeKeyTxRoot, txRoot.Hex()),
+			sdk.NewAttribute(types.AttributeKeyReceiptsRoot, receiptsRoot.Hex()),
+		),
+	)
+}
@@ -26,9 +26,10 @@ func (k *Keeper) EVMConfig(ctx sdk.Context, proposerAddress sdk.ConsAddress) (*s
 
 	baseFee := k.GetBaseFee(ctx)
 	return &statedb.EVMConfig{
-		Params:   params,
-		CoinBase: coinbase,
-		BaseFee:  baseFee,
+		Params:                  params,
+		CoinBase:                coinbase,
+		BaseFee:                 baseFee,
+		EnablePreimageRecording: k.recordsPreimages(ctx),
 	}, nil
 }
 
@@ -3,9 +3,13 @@ package keeper
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/cosmos/evm/x/vm/statedb"
 	"github.com/cosmos/evm/x/vm/types"
 
 	errorsmod "cosmossdk.io/errors"
@@ -76,3 +80,47 @@ func GasToRefund(availableRefund, gasConsumed, refundQuotient uint64) uint64 {
 	}
 	return refund
 }
+
+// callGasTracer records the gas consumed by the top level call frame and the
+// refund counter at its end, from which the gas used by the EVM execution can
+// be computed. Unlike the gas used returned by ApplyMessageWithConfig, it is not
+// adjusted by the minimum gas multiplier, so it does not depend on the gas limit
+// of the message.
+type callGasTracer struct {
+	stateDB tracing.StateDB
+	gasUsed uint64
+	refund  uint64
+}
+
+func (t *callGasTracer) onTxStart(vmCtx *tracing.VMContext, _ *ethtypes.Transaction, _ common.Address) {
+	t.stateDB = vmCtx.StateDB
+}
+
+func (t *callGasTracer) onExit(depth int, _ []byte, gasUsed uint64, _ error, _ bool) {
+	if depth == 0 {
+		t.gasUsed = gasUsed
+		t.refund = t.stateDB.GetRefund()
+	}
+}
+
+// evmGasUsed returns the gas used by the EVM execution of the message recorded
+// by the given tracer, including the intrinsic gas and the gas refund.
+func (k *Keeper) evmGasUsed(ctx sdk.Context, msg core.Message, cfg *statedb.EVMConfig, tracer *callGasTracer) (uint64, error) {
+	ethCfg := types.GetEthChainConfig()
+	intrinsicGas, err := k.GetEthIntrinsicGas(ctx, msg, ethCfg, msg.To == nil)
+	if err != nil {
+		return 0, err
+	}
+
+	blockNumber := big.NewInt(ctx.BlockHeight())
+	if cfg.BlockOverrides != nil && cfg.BlockOverrides.Number != nil {
+		blockNumber = cfg.BlockOverrides.Number.ToInt()
+	}
+	refundQuotient := params.RefundQuotient
+	if ethCfg.IsLondon(blockNumber) {
+		refundQuotient = params.RefundQuotientEIP3529
+	}
+
+	gasUsed := intrinsicGas + tracer.gasUsed
+	return gasUsed - GasToRefund(tracer.refund, gasUsed, refundQuotient), nil
+}
@@ -1,11 +1,13 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -13,10 +15,12 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	ethparams "github.com/ethereum/go-ethereum/params"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -27,9 +31,13 @@ import (
 	"github.com/cosmos/evm/x/vm/types"
 
 	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
 	storetypes "cosmossdk.io/store/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
 var _ types.QueryServer = Keeper{}
@@ -41,6 +49,13 @@ const (
 	maxTracePredecessors = 10_000
 
 	maxPredecessorGas = uint64(50_000_000)
+
+	// maxAccountRangeResults is the maximum amount of accounts returned by an
+	// account range request, as each of them may include its whole storage.
+	maxAccountRangeResults = uint64(256)
+	// maxStorageRangeResults is the maximum amount of storage entries returned
+	// by a storage range request.
+	maxStorageRangeResults = uint64(1024)
 )
 
 // Account implements the Query/Account gRPC method. The method returns the
@@ -237,11 +252,20 @@ func (k Keeper) EthCall(c context.Context, req *types.EthCallRequest) (*types.Ms
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	ctx, err = k.applyStateOverrides(ctx, req.Overrides)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if err := applyBlockOverrides(cfg, req.BlockOverrides); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// ApplyMessageWithConfig expect correct nonce set in msg
 	nonce := k.GetNonce(ctx, args.GetFrom())
 	args.Nonce = (*hexutil.Uint64)(&nonce)
@@ -289,6 +313,11 @@ func (k Keeper) EstimateGasInternal(c context.Context, req *types.EthCallRequest
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	ctx, err = k.applyStateOverrides(ctx, req.Overrides)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo     = ethparams.TxGas - 1
@@ -320,6 +349,10 @@ func (k Keeper) EstimateGasInternal(c context.Context, req *types.EthCallRequest
 		return nil, status.Error(codes.Internal, "failed to load evm config")
 	}
 
+	if err := applyBlockOverrides(cfg, req.BlockOverrides); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// ApplyMessageWithConfig expect correct nonce set in msg
 	nonce := k.GetNonce(ctx, args.GetFrom())
 	args.Nonce = (*hexutil.Uint64)(&nonce)
@@ -445,6 +478,157 @@ func (k Keeper) EstimateGasInternal(c context.Context, req *types.EthCallRequest
 	return &types.EstimateGasResponse{Gas: hi}, nil
 }
 
+// CreateAccessList implements eth_createAccessList rpc api. It executes the call
+// repeatedly with the access list tracer, using the access list generated by
+// the previous execution, until it doesn't change anymore.
+func (k Keeper) CreateAccessList(c context.Context, req *types.EthCallRequest) (*types.QueryCreateAccessListResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var args types.TransactionArgs
+	err := json.Unmarshal(req.Args, &args)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, err = k.applyStateOverrides(ctx, req.Overrides)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := applyBlockOverrides(cfg, req.BlockOverrides); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// ApplyMessageWithConfig expect correct nonce set in msg
+	from := args.GetFrom()
+	if args.Nonce == nil {
+		nonce := k.GetNonce(ctx, from)
+		args.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+
+	to := crypto.CreateAddress(from, uint64(*args.Nonce))
+	if args.To != nil {
+		to = *args.To
+	}
+
+	// the sender, the recipient and the precompiles are always warm, so they
+	// are excluded from the access list
+	excluded := map[common.Address]struct{}{from: {}, to: {}}
+	ethCfg := types.GetEthChainConfig()
+	rules := ethCfg.Rules(big.NewInt(ctx.BlockHeight()), true, uint64(ctx.BlockTime().Unix())) //#nosec G115 -- int overflow is not a concern here
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		excluded[addr] = struct{}{}
+	}
+	for _, addr := range cfg.Params.GetActiveStaticPrecompilesAddrs() {
+		excluded[addr] = struct{}{}
+	}
+
+	var accessList ethtypes.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+
+	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash()))
+	prevTracer := logger.NewAccessListTracer(accessList, excluded)
+	for {
+		accessList = prevTracer.AccessList()
+		args.AccessList = &accessList
+
+		msg, err := args.ToMessage(req.GasCap, cfg.BaseFee, false, false)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		tracer := logger.NewAccessListTracer(accessList, excluded)
+		gasTracer := &callGasTracer{}
+		hooks := tracer.Hooks()
+		hooks.OnTxStart = gasTracer.onTxStart
+		hooks.OnExit = gasTracer.onExit
+
+		// pass false to not commit StateDB
+		res, err := k.ApplyMessageWithConfig(ctx, msg, hooks, false, cfg, txConfig, false)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		// the ERC-20 precompiles are registered dynamically, so they are only
+		// excluded once they are found in the access list
+		if k.excludeERC20Precompiles(ctx, tracer.AccessList(), excluded) {
+			tracer = logger.NewAccessListTracer(tracer.AccessList(), excluded)
+		}
+
+		if tracer.Equal(prevTracer) {
+			gasUsed, err := k.evmGasUsed(ctx, msg, cfg, gasTracer)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+
+			return &types.QueryCreateAccessListResponse{
+				AccessList: types.NewAccessList(&accessList),
+				GasUsed:    gasUsed,
+				VmError:    res.VmError,
+			}, nil
+		}
+		prevTracer = tracer
+	}
+}
+
+// excludeERC20Precompiles adds the ERC-20 precompiles found in the access list
+// to the excluded addresses. It returns true if any new address was excluded.
+func (k Keeper) excludeERC20Precompiles(ctx sdk.Context, accessList ethtypes.AccessList, excluded map[common.Address]struct{}) bool {
+	found := false
+	for _, tuple := range accessList {
+		if _, ok := excluded[tuple.Address]; ok {
+			continue
+		}
+		if _, isPrecompile, err := k.erc20Keeper.GetERC20PrecompileInstance(ctx, tuple.Address); err == nil && isPrecompile {
+			excluded[tuple.Address] = struct{}{}
+			found = true
+		}
+	}
+	return found
+}
+
+// SimulateV1 implements eth_simulateV1 rpc api.
+func (k Keeper) SimulateV1(c context.Context, req *types.QuerySimulateV1Request) (*types.QuerySimulateV1Response, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var opts types.SimOpts
+	if err := json.Unmarshal(req.Opts, &opts); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load evm config")
+	}
+
+	results, err := k.simulate(ctx, cfg, opts, req.GasCap)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QuerySimulateV1Response{Data: data}, nil
+}
+
 // TraceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer-dependent.
@@ -556,10 +740,308 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
 
+	results := make([]*types.TxTraceResult, 0, len(req.Txs))
+	err := k.traceBlock(sdk.UnwrapSDKContext(c), req, func(_ int, _ common.Hash, result *types.TxTraceResult) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := json.Marshal(results)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryTraceBlockResponse{
+		Data: resultData,
+	}, nil
+}
+
+// TraceBlockStream is like TraceBlock, but sends the trace of each transaction
+// of the block as soon as it finishes, so that the traces of large blocks don't
+// have to fit in a single message.
+func (k Keeper) TraceBlockStream(req *types.QueryTraceBlockRequest, stream types.Query_TraceBlockStreamServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx, err := k.streamQueryContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return k.traceBlock(ctx, req, func(txIndex int, txHash common.Hash, result *types.TxTraceResult) error {
+		res := &types.QueryTraceBlockStreamResponse{
+			TxIndex: uint64(txIndex), //#nosec G115 -- int overflow is not a concern here
+			TxHash:  txHash.Hex(),
+			Error:   result.Error,
+		}
+		if result.Error == "" {
+			if res.Data, err = json.Marshal(result.Result); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		return stream.Send(res)
+	})
+}
+
+// IntermediateRoots replays the transactions of the queried block and returns,
+// after each of them, a commitment to the state modified so far, along with
+// the accounts modified by the block. As there is no state root per
+// transaction in Cosmos, the commitments allow to find the first transaction
+// whose execution diverges between nodes. Like the traces, the replay only
+// covers the EVM execution of the transactions: the fees and nonce increments
+// of the ante handler aren't replayed.
+func (k Keeper) IntermediateRoots(c context.Context, req *types.QueryTraceBlockRequest) (*types.QueryIntermediateRootsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx, cfg, signer, txConfig, err := k.blockReplayContext(sdk.UnwrapSDKContext(c), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var root common.Hash
+	blockChanges := newStateChanges()
+	roots := make([]string, 0, len(req.Txs))
+	for i, tx := range req.Txs {
+		ethTx := tx.AsTransaction()
+		txConfig.TxHash = ethTx.Hash()
+		txConfig.TxIndex = uint(i) //nolint:gosec // G115 // won't exceed uint64
+
+		// failed transactions are still committed to, as their state changes
+		// are recorded until they fail
+		changes := newStateChanges()
+		if logIndex, err := k.replayTx(ctx, cfg, txConfig, signer, ethTx, changes); err == nil {
+			txConfig.LogIndex = logIndex
+		}
+
+		root, _ = changes.commit(&k, ctx, root)
+		roots = append(roots, root.Hex())
+		blockChanges.merge(changes)
+	}
+
+	_, modified := blockChanges.commit(&k, ctx, common.Hash{})
+	modifiedAccounts := make([]string, len(modified))
+	for i, addr := range modified {
+		modifiedAccounts[i] = addr.Hex()
+	}
+
+	return &types.QueryIntermediateRootsResponse{
+		Roots:            roots,
+		ModifiedAccounts: modifiedAccounts,
+	}, nil
+}
+
+// StorageRange returns the storage entries of the contract sorted by key, after
+// replaying the given transactions of the block if any.
+func (k Keeper) StorageRange(c context.Context, req *types.QueryStorageRangeRequest) (*types.QueryStorageRangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if err := cosmosevmtypes.ValidateAddress(req.Address); err != nil {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			types.ErrZeroAddress.Error(),
+		)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	if req.Replay != nil {
+		if err := k.replayBlock(ctx, req.Replay); err != nil {
+			return nil, err
+		}
+	}
+
+	// the results are capped, and not counted as the whole storage would be
+	// iterated
+	pagination := &query.PageRequest{}
+	if req.Pagination != nil {
+		pagination = &query.PageRequest{
+			Key:     req.Pagination.Key,
+			Offset:  req.Pagination.Offset,
+			Limit:   req.Pagination.Limit,
+			Reverse: req.Pagination.Reverse,
+		}
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = query.DefaultLimit
+	}
+	pagination.Limit = min(pagination.Limit, maxStorageRangeResults)
+
+	address := common.HexToAddress(req.Address)
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(address))
+
+	storage := types.Storage{}
+	pageRes, err := query.Paginate(store, pagination, func(key, value []byte) error {
+		storage = append(storage, types.NewState(common.BytesToHash(key), common.BytesToHash(value)))
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryStorageRangeResponse{
+		Storage:    storage,
+		Pagination: pageRes,
+	}, nil
+}
+
+// AccountRange returns the Ethereum accounts sorted by address, with their
+// code and storage unless excluded. The accounts are iterated from the start
+// key in the account store of the auth module, only the pagination by key is
+// supported.
+func (k Keeper) AccountRange(c context.Context, req *types.QueryAccountRangeRequest) (*types.QueryAccountRangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	var (
+		start []byte
+		limit = uint64(query.DefaultLimit)
+	)
+	if req.Pagination != nil {
+		if req.Pagination.Offset > 0 {
+			return nil, status.Error(codes.InvalidArgument, "offset pagination is not supported")
+		}
+		start = req.Pagination.Key
+		if req.Pagination.Limit > 0 {
+			limit = req.Pagination.Limit
+		}
+	}
+	limit = min(limit, maxAccountRangeResults)
+
+	authKey, found := k.storeKeys[authtypes.StoreKey]
+	if !found {
+		return nil, status.Error(codes.Unavailable, "the account store is not available")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := prefix.NewStore(ctx.KVStore(authKey), authtypes.AddressStoreKeyPrefix.Bytes())
+	iterator := store.Iterator(start, nil)
+	defer iterator.Close()
+
+	accounts := make([]types.DumpAccount, 0)
+	var nextKey []byte
+	for ; iterator.Valid(); iterator.Next() {
+		addr := iterator.Key()
+		// skip the accounts without an Ethereum address
+		if len(addr) != common.AddressLength {
+			continue
+		}
+		if uint64(len(accounts)) == limit {
+			nextKey = bytes.Clone(addr)
+			break
+		}
+		accounts = append(accounts, k.dumpAccount(ctx, common.BytesToAddress(addr), req.NoCode, req.NoStorage))
+	}
+
+	return &types.QueryAccountRangeResponse{
+		Accounts:   accounts,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
+// Preimage returns the SHA3 preimage of the hash recorded by the node. The
+// preimages are stored in a node-local database, they are only available if
+// their recording is enabled and since it has been.
+func (k Keeper) Preimage(_ context.Context, req *types.QueryPreimageRequest) (*types.QueryPreimageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	hash, err := hexutil.Decode(req.Hash)
+	if err != nil || len(hash) != common.HashLength {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hash %s", req.Hash)
+	}
+
+	if k.preimages == nil {
+		return nil, status.Error(codes.Unavailable, "preimage recording is disabled")
+	}
+
+	preimage, err := k.GetPreimage(common.BytesToHash(hash))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if preimage == nil {
+		return nil, status.Errorf(codes.NotFound, "preimage of %s not found", req.Hash)
+	}
+
+	return &types.QueryPreimageResponse{Preimage: preimage}, nil
+}
+
+// dumpAccount returns the dump of the Ethereum account, with its code and
+// storage unless excluded.
+func (k Keeper) dumpAccount(ctx sdk.Context, addr common.Address, noCode, noStorage bool) types.DumpAccount {
+	acct := k.GetAccountOrEmpty(ctx, addr)
+	account := types.DumpAccount{
+		Address:  addr.Hex(),
+		Balance:  acct.Balance.String(),
+		Nonce:    acct.Nonce,
+		CodeHash: common.BytesToHash(acct.CodeHash).Hex(),
+		Storage:  types.Storage{},
+	}
+	if !noCode && acct.IsContract() {
+		account.Code = k.GetCode(ctx, common.BytesToHash(acct.CodeHash))
+	}
+	if !noStorage {
+		account.Storage = k.GetAccountStorage(ctx, addr)
+	}
+	return account
+}
+
+// traceBlock traces the transactions of the queried block, calling fn with the
+// trace result of each transaction in order as soon as it's available. The
+// transactions are traced one after another, unless the block trace
+// concurrency of the keeper allows tracing them in parallel.
+func (k Keeper) traceBlock(
+	ctx sdk.Context,
+	req *types.QueryTraceBlockRequest,
+	fn func(txIndex int, txHash common.Hash, result *types.TxTraceResult) error,
+) error {
 	if req.TraceConfig != nil && req.TraceConfig.Limit < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
+		return status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
+	}
+
+	ctx, cfg, signer, txConfig, err := k.blockReplayContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if k.traceBlockConcurrency > 1 && len(req.Txs) > 1 {
+		return k.traceBlockParallel(ctx, cfg, signer, txConfig, req, fn)
+	}
+
+	for i, tx := range req.Txs {
+		result := types.TxTraceResult{}
+		ethTx := tx.AsTransaction()
+		txConfig.TxHash = ethTx.Hash()
+		txConfig.TxIndex = uint(i) //nolint:gosec // G115 // won't exceed uint64
+		traceResult, logIndex, err := k.traceTx(ctx, cfg, txConfig, signer, ethTx, req.TraceConfig, true)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			txConfig.LogIndex = logIndex
+			result.Result = traceResult
+		}
+		if err := fn(i, txConfig.TxHash, &result); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// blockReplayContext returns the context, EVM config, signer and transaction
+// config of the beginning of the queried block, to replay its transactions.
+func (k Keeper) blockReplayContext(
+	ctx sdk.Context,
+	req *types.QueryTraceBlockRequest,
+) (sdk.Context, *statedb.EVMConfig, ethtypes.Signer, statedb.TxConfig, error) {
 	// get the context of block beginning
 	contextHeight := req.BlockNumber
 	if contextHeight < 1 {
@@ -567,7 +1049,6 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 		contextHeight = 1
 	}
 
-	ctx := sdk.UnwrapSDKContext(c)
 	ctx = ctx.WithBlockHeight(contextHeight)
 	ctx = ctx.WithBlockTime(req.BlockTime)
 	ctx = ctx.WithHeaderHash(common.Hex2Bytes(req.BlockHash))
@@ -579,7 +1060,7 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 
 	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to load evm config")
+		return sdk.Context{}, nil, nil, statedb.TxConfig{}, status.Error(codes.Internal, "failed to load evm config")
 	}
 
 	// compute and use base fee of height that is being traced
@@ -589,34 +1070,158 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 	}
 
 	signer := ethtypes.MakeSigner(types.GetEthChainConfig(), big.NewInt(ctx.BlockHeight()), uint64(ctx.BlockTime().Unix())) //#nosec G115 -- int overflow is not a concern here
-	txsLength := len(req.Txs)
-	results := make([]*types.TxTraceResult, 0, txsLength)
 
 	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash()))
 
+	return ctx, cfg, signer, txConfig, nil
+}
+
+// streamQueryContext returns the query context of a streaming query. Unlike
+// the unary queries, the streaming queries aren't intercepted by the gRPC
+// server of the app, so the context is created from the height header of the
+// request with the query context creator.
+func (k Keeper) streamQueryContext(grpcCtx context.Context) (sdk.Context, error) {
+	if ctx, ok := grpcCtx.Value(sdk.SdkContextKey).(sdk.Context); ok {
+		return ctx, nil
+	}
+	if k.queryContextCreator == nil {
+		return sdk.Context{}, status.Error(codes.Unimplemented, "streaming queries are not supported by the node")
+	}
+
+	var height int64
+	if md, ok := metadata.FromIncomingContext(grpcCtx); ok {
+		if headers := md.Get(grpctypes.GRPCBlockHeightHeader); len(headers) == 1 {
+			var err error
+			if height, err = strconv.ParseInt(headers[0], 10, 64); err != nil || height < 0 {
+				return sdk.Context{}, status.Errorf(codes.InvalidArgument, "invalid height header %q", headers[0])
+			}
+		}
+	}
+
+	ctx, err := k.queryContextCreator(height, false)
+	if err != nil {
+		return sdk.Context{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return ctx.WithContext(grpcCtx), nil
+}
+
+// TraceCall configures a new tracer according to the provided configuration, and
+// executes the given call on top of the state of the queried block, with the
+// state and block overrides applied. The return value will be tracer dependent.
+func (k Keeper) TraceCall(c context.Context, req *types.QueryTraceCallRequest) (*types.QueryTraceCallResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.TraceConfig != nil && req.TraceConfig.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var args types.TransactionArgs
+	err := json.Unmarshal(req.Args, &args)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, err = k.applyStateOverrides(ctx, req.Overrides)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := applyBlockOverrides(cfg, req.BlockOverrides); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// ApplyMessageWithConfig expect correct nonce set in msg
+	nonce := k.GetNonce(ctx, args.GetFrom())
+	args.Nonce = (*hexutil.Uint64)(&nonce)
+
+	msg, err := args.ToMessage(req.GasCap, cfg.BaseFee, false, false)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash()))
+
+	// pass false to not commit StateDB
+	result, _, err := k.traceMsg(ctx, cfg, txConfig, &msg, req.TraceConfig, false)
+	if err != nil {
+		// error will be returned with detail status from traceMsg
+		return nil, err
+	}
+
+	resultData, err := json.Marshal(result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryTraceCallResponse{
+		Data: resultData,
+	}, nil
+}
+
+// replayBlock applies the transactions of the queried block in order, on top of
+// the state of the beginning of the block.
+func (k Keeper) replayBlock(ctx sdk.Context, req *types.QueryTraceBlockRequest) error {
+	ctx, cfg, signer, txConfig, err := k.blockReplayContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
 	for i, tx := range req.Txs {
-		result := types.TxTraceResult{}
 		ethTx := tx.AsTransaction()
 		txConfig.TxHash = ethTx.Hash()
 		txConfig.TxIndex = uint(i) //nolint:gosec // G115 // won't exceed uint64
-		traceResult, logIndex, err := k.traceTx(ctx, cfg, txConfig, signer, ethTx, req.TraceConfig, true)
-		if err != nil {
-			result.Error = err.Error()
-		} else {
+		// like the traces, the failed transactions are skipped
+		if logIndex, err := k.replayTx(ctx, cfg, txConfig, signer, ethTx, nil); err == nil {
 			txConfig.LogIndex = logIndex
-			result.Result = traceResult
 		}
-		results = append(results, &result)
 	}
 
-	resultData, err := json.Marshal(results)
+	return nil
+}
+
+// replayTx applies the transaction and commits its state changes, recording
+// them in the given changes if any. It returns the next log index.
+func (k *Keeper) replayTx(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	txConfig statedb.TxConfig,
+	signer ethtypes.Signer,
+	tx *ethtypes.Transaction,
+	changes *stateChanges,
+) (uint, error) {
+	msg, err := core.TransactionToMessage(tx, signer, cfg.BaseFee)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return 0, err
 	}
 
-	return &types.QueryTraceBlockResponse{
-		Data: resultData,
-	}, nil
+	ctx = buildTraceCtx(ctx, msg.GasLimit).WithEventManager(sdk.NewEventManager())
+	stateDB := statedb.New(ctx, k, txConfig)
+	res, err := k.applyMessageWithStateDB(ctx, stateDB, *msg, nil, false, cfg, txConfig, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// the values before the transaction are recorded from the journal, before
+	// the state changes are committed
+	if changes != nil {
+		changes.record(k, ctx, stateDB)
+	}
+	if err := stateDB.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit stateDB: %w", err)
+	}
+	if changes != nil {
+		changes.addBankEvents(ctx.EventManager().Events())
+	}
+	return txConfig.LogIndex + uint(len(res.Logs)), nil
 }
 
 // traceTx do trace on one transaction, it returns a tuple: (traceResult, nextLogIndex, error).
@@ -628,6 +1233,23 @@ func (k *Keeper) traceTx(
 	tx *ethtypes.Transaction,
 	traceConfig *types.TraceConfig,
 	commitMessage bool,
+) (*any, uint, error) {
+	msg, err := core.TransactionToMessage(tx, signer, cfg.BaseFee)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+
+	return k.traceMsg(ctx, cfg, txConfig, msg, traceConfig, commitMessage)
+}
+
+// traceMsg do trace on one message, it returns a tuple: (traceResult, nextLogIndex, error).
+func (k *Keeper) traceMsg(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	txConfig statedb.TxConfig,
+	msg *core.Message,
+	traceConfig *types.TraceConfig,
+	commitMessage bool,
 ) (*any, uint, error) {
 	// Assemble the structured logger or the JavaScript tracer
 	var (
@@ -637,10 +1259,6 @@ func (k *Keeper) traceTx(
 		err              error
 		timeout          = defaultTraceTimeout
 	)
-	msg, err := core.TransactionToMessage(tx, signer, cfg.BaseFee)
-	if err != nil {
-		return nil, 0, status.Error(codes.Internal, err.Error())
-	}
 
 	if traceConfig == nil {
 		traceConfig = &types.TraceConfig{}
@@ -672,9 +1290,13 @@ func (k *Keeper) traceTx(
 	}
 
 	tCtx := &tracers.Context{
-		BlockHash: txConfig.BlockHash,
-		TxIndex:   int(txConfig.TxIndex), //#nosec G115 -- int overflow is not a concern here
-		TxHash:    txConfig.TxHash,
+		BlockHash:   txConfig.BlockHash,
+		BlockNumber: big.NewInt(ctx.BlockHeight()),
+		TxIndex:     int(txConfig.TxIndex), //#nosec G115 -- int overflow is not a concern here
+		TxHash:      txConfig.TxHash,
+	}
+	if cfg.BlockOverrides != nil && cfg.BlockOverrides.Number != nil {
+		tCtx.BlockNumber = cfg.BlockOverrides.Number.ToInt()
 	}
 
 	if traceConfig.Tracer != "" {
@@ -690,6 +1312,7 @@ func (k *Keeper) traceTx(
 			return nil, 0, status.Errorf(codes.InvalidArgument, "timeout value: %s", err.Error())
 		}
 	}
+	timeout = k.traceTimeout(traceConfig.Tracer, timeout)
 
 	// Handle timeouts and RPC cancellations
 	deadlineCtx, cancel := context.WithTimeout(ctx.Context(), timeout)
@@ -704,7 +1327,7 @@ func (k *Keeper) traceTx(
 
 	// Build EVM execution context
 	ctx = buildTraceCtx(ctx, msg.GasLimit)
-	res, err := k.ApplyMessageWithConfig(ctx, *msg, tracer.Hooks, commitMessage, cfg, txConfig, false)
+	res, err := k.ApplyMessageWithConfig(ctx, *msg, k.jsTracerHooks(traceConfig.Tracer, tracer), commitMessage, cfg, txConfig, false)
 	if err != nil {
 		return nil, 0, status.Error(codes.Internal, err.Error())
 	}
@@ -749,6 +1372,68 @@ func (k Keeper) Config(_ context.Context, _ *types.QueryConfigRequest) (*types.Q
 	return &types.QueryConfigResponse{Config: config}, nil
 }
 
+// applyStateOverrides applies the json-encoded state overrides on top of a
+// cached copy of the given context and returns it. The original context is
+// returned untouched when no overrides are provided.
+func (k *Keeper) applyStateOverrides(ctx sdk.Context, bz []byte) (sdk.Context, error) {
+	if len(bz) == 0 {
+		return ctx, nil
+	}
+
+	var overrides types.StateOverride
+	if err := json.Unmarshal(bz, &overrides); err != nil {
+		return ctx, fmt.Errorf("invalid state overrides: %w", err)
+	}
+
+	// the overrides are only committed to the cached context, which is
+	// discarded once the query returns
+	ctx, _ = ctx.CacheContext()
+	if err := k.overrideState(ctx, overrides); err != nil {
+		return ctx, err
+	}
+
+	return ctx, nil
+}
+
+// overrideState applies and commits the state overrides to the given context.
+func (k *Keeper) overrideState(ctx sdk.Context, overrides types.StateOverride) error {
+	stateDB := statedb.New(ctx, k, statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash())))
+	if err := overrides.Apply(stateDB); err != nil {
+		return err
+	}
+
+	if err := stateDB.Commit(); err != nil {
+		return fmt.Errorf("failed to apply state overrides: %w", err)
+	}
+
+	return nil
+}
+
+// applyBlockOverrides decodes the json-encoded block overrides and sets them
+// on the EVM config, so that they are applied to the block context of the
+// EVM. The base fee and coinbase of the config are updated accordingly to
+// keep the message fields consistent with the overridden block.
+func applyBlockOverrides(cfg *statedb.EVMConfig, bz []byte) error {
+	if len(bz) == 0 {
+		return nil
+	}
+
+	var overrides types.BlockOverrides
+	if err := json.Unmarshal(bz, &overrides); err != nil {
+		return fmt.Errorf("invalid block overrides: %w", err)
+	}
+
+	if overrides.BaseFeePerGas != nil {
+		cfg.BaseFee = overrides.BaseFeePerGas.ToInt()
+	}
+	if overrides.FeeRecipient != nil {
+		cfg.CoinBase = *overrides.FeeRecipient
+	}
+	cfg.BlockOverrides = &overrides
+
+	return nil
+}
+
 // buildTraceCtx builds a context for simulating or tracing transactions by:
 // 1. assigning a new infinite gas meter with the provided gasLimit
 // 2. calling BuildEvmExecutionCtx to set up gas configs consistent with Ethereum transaction execution.
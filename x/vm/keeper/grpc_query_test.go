@@ -1,21 +1,28 @@
 package keeper_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	ethlogger "github.com/ethereum/go-ethereum/eth/tracers/logger"
 	ethparams "github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/evm/server/config"
 	testconstants "github.com/cosmos/evm/testutil/constants"
 	"github.com/cosmos/evm/testutil/integration/os/factory"
@@ -31,6 +38,8 @@ import (
 	sdkmath "cosmossdk.io/math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 )
@@ -1313,6 +1322,77 @@ func (suite *KeeperTestSuite) TestTraceTx() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestTraceTxJSTracerLimits() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	testCases := []struct {
+		msg         string
+		tracer      string
+		timeout     time.Duration
+		stepLimit   uint64
+		expPass     bool
+		errContains string
+	}{
+		{
+			msg:       "pass - javascript tracer within the limits",
+			tracer:    "{data: [], fault: function(log) {}, step: function(log) {}, result: function() { return this.data; }}",
+			timeout:   time.Minute,
+			stepLimit: 1_000_000,
+			expPass:   true,
+		},
+		{
+			msg:         "fail - javascript tracer exceeding the timeout",
+			tracer:      "{fault: function(log) {}, step: function(log) { while (true) {} }, result: function() { return null; }}",
+			timeout:     100 * time.Millisecond,
+			errContains: "execution timeout",
+		},
+		{
+			msg:         "fail - javascript tracer exceeding the step limit",
+			tracer:      "{data: [], fault: function(log) {}, step: function(log) { this.data.push(log.op.toString()); }, result: function() { return this.data; }}",
+			stepLimit:   10,
+			errContains: "js tracer step limit exceeded",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.network.App.EVMKeeper.SetJSTracerLimits(tc.timeout, tc.stepLimit)
+			defer suite.network.App.EVMKeeper.SetJSTracerLimits(0, 0)
+
+			senderKey := suite.keyring.GetKey(0)
+			contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+			suite.Require().NoError(err)
+			suite.Require().NoError(suite.network.NextBlock())
+
+			msgToTrace, err := executeTransferCall(
+				transferParams{
+					senderKey:     senderKey,
+					contractAddr:  contractAddr,
+					recipientAddr: common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101"),
+				},
+				suite.factory,
+			)
+			suite.Require().NoError(err)
+			suite.Require().NoError(suite.network.NextBlock())
+
+			traceReq := getDefaultTraceTxRequest(suite.network)
+			traceReq.TraceConfig = &types.TraceConfig{Tracer: tc.tracer}
+			traceReq.Msg = msgToTrace
+
+			res, err := suite.network.GetEvmClient().TraceTx(suite.network.GetContext(), &traceReq)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal("[]", string(res.Data))
+			} else {
+				suite.Require().Error(err)
+				suite.Require().Contains(err.Error(), tc.errContains)
+			}
+		})
+	}
+}
+
 func (suite *KeeperTestSuite) TestTraceBlock() {
 	suite.enableFeemarket = true
 	defer func() { suite.enableFeemarket = false }()
@@ -1418,76 +1498,779 @@ func (suite *KeeperTestSuite) TestTraceBlock() {
 			getAdditionalTxs: func() []*types.MsgEthereumTx {
 				return nil
 			},
-			expPass: false,
+			expPass: false,
+		},
+		{
+			msg: "invalid trace config - Invalid Tracer",
+			getRequest: func() types.QueryTraceBlockRequest {
+				defaultReq := getDefaultTraceBlockRequest(suite.network)
+				defaultReq.TraceConfig = &types.TraceConfig{
+					Tracer: "invalid_tracer",
+				}
+				return defaultReq
+			},
+			getAdditionalTxs: func() []*types.MsgEthereumTx {
+				return nil
+			},
+			expPass: true,
+			traceResponse: "[{\"error\":\"rpc error: code = Internal desc = ReferenceError: invalid_tracer is not" +
+				" defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			// Start from fresh block
+			suite.Require().NoError(suite.network.NextBlock())
+
+			// ----- Contract Deployment -----
+			senderKey := suite.keyring.GetKey(0)
+			contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+			suite.Require().NoError(err)
+
+			err = suite.network.NextBlock()
+			suite.Require().NoError(err)
+
+			// --- Add predecessor ---
+			txs := tc.getAdditionalTxs()
+
+			// --- Contract Call ---
+			msgToTrace, err := executeTransferCall(
+				transferParams{
+					senderKey:     senderKey,
+					contractAddr:  contractAddr,
+					recipientAddr: hardcodedTransferRecipient,
+				},
+				suite.factory,
+			)
+			suite.Require().NoError(err)
+			txs = append(txs, msgToTrace)
+
+			suite.Require().NoError(suite.network.NextBlock())
+
+			// Get the trace request
+			traceReq := tc.getRequest()
+			// Add txs to trace request
+			traceReq.Txs = txs
+
+			res, err := suite.network.GetEvmClient().TraceBlock(suite.network.GetContext(), &traceReq)
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				// if data is too big, slice the result
+				if len(res.Data) > 200 {
+					suite.Require().Contains(string(res.Data[:200]), tc.traceResponse)
+				} else {
+					suite.Require().Contains(string(res.Data), tc.traceResponse)
+				}
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTraceBlockParallel() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+	defer suite.network.App.EVMKeeper.SetTraceBlockConcurrency(0)
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	transferMsg, err := executeTransferCall(
+		transferParams{
+			senderKey:     senderKey,
+			contractAddr:  contractAddr,
+			recipientAddr: common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101"),
+		},
+		suite.factory,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	// the transfers after the first one find the recipient balance already
+	// set, so their traces depend on the state left by the previous ones
+	txs := []*types.MsgEthereumTx{transferMsg, transferMsg, transferMsg, transferMsg, transferMsg}
+	traceBlock := func(concurrency int) []byte {
+		suite.network.App.EVMKeeper.SetTraceBlockConcurrency(concurrency)
+		req := getDefaultTraceBlockRequest(suite.network)
+		req.Txs = txs
+		res, err := suite.network.GetEvmClient().TraceBlock(suite.network.GetContext(), &req)
+		suite.Require().NoError(err)
+		return res.Data
+	}
+
+	expected := traceBlock(0)
+	var results []*types.TxTraceResult
+	suite.Require().NoError(json.Unmarshal(expected, &results))
+	suite.Require().Len(results, len(txs))
+	suite.Require().NotEqual(results[0].Result, results[1].Result)
+
+	for _, concurrency := range []int{2, 3, 10} {
+		suite.Run(fmt.Sprintf("Case concurrency %d", concurrency), func() {
+			suite.Require().Equal(string(expected), string(traceBlock(concurrency)))
+		})
+	}
+}
+
+// traceBlockStreamServer is a TraceBlockStream server collecting the sent responses
+type traceBlockStreamServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*types.QueryTraceBlockStreamResponse
+}
+
+func (s *traceBlockStreamServer) Context() context.Context { return s.ctx }
+
+func (s *traceBlockStreamServer) Send(res *types.QueryTraceBlockStreamResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+func (suite *KeeperTestSuite) TestTraceBlockStream() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	msgToTrace, err := executeTransferCall(
+		transferParams{
+			senderKey:     senderKey,
+			contractAddr:  contractAddr,
+			recipientAddr: common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101"),
+		},
+		suite.factory,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	testCases := []struct {
+		msg       string
+		getStream func() *traceBlockStreamServer
+		expPass   bool
+	}{
+		{
+			"pass - stream with the query context",
+			func() *traceBlockStreamServer {
+				return &traceBlockStreamServer{ctx: suite.network.GetContext()}
+			},
+			true,
+		},
+		{
+			"pass - stream with the query context creator",
+			func() *traceBlockStreamServer {
+				suite.network.App.EVMKeeper.SetQueryContextCreator(func(height int64, _ bool) (sdk.Context, error) {
+					suite.Require().Equal(int64(1), height)
+					return suite.network.GetContext(), nil
+				})
+				md := metadata.Pairs(grpctypes.GRPCBlockHeightHeader, "1")
+				return &traceBlockStreamServer{ctx: metadata.NewIncomingContext(context.Background(), md)}
+			},
+			true,
+		},
+		{
+			"fail - stream without query context creator",
+			func() *traceBlockStreamServer {
+				suite.network.App.EVMKeeper.SetQueryContextCreator(nil)
+				return &traceBlockStreamServer{ctx: context.Background()}
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			traceReq := getDefaultTraceBlockRequest(suite.network)
+			traceReq.Txs = []*types.MsgEthereumTx{msgToTrace}
+
+			stream := tc.getStream()
+			err := suite.network.App.EVMKeeper.TraceBlockStream(&traceReq, stream)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(stream.responses, 1)
+			suite.Require().Equal(uint64(0), stream.responses[0].TxIndex)
+			suite.Require().Equal(msgToTrace.AsTransaction().Hash().Hex(), stream.responses[0].TxHash)
+			suite.Require().Empty(stream.responses[0].Error)
+
+			var result ethlogger.ExecutionResult
+			suite.Require().NoError(json.Unmarshal(stream.responses[0].Data, &result))
+			suite.Require().Positive(result.Gas)
+			suite.Require().NotEmpty(result.StructLogs)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestIntermediateRoots() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	transferMsg, err := executeTransferCall(
+		transferParams{
+			senderKey:     senderKey,
+			contractAddr:  contractAddr,
+			recipientAddr: common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101"),
+		},
+		suite.factory,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	getRoots := func(txs ...*types.MsgEthereumTx) *types.QueryIntermediateRootsResponse {
+		req := getDefaultTraceBlockRequest(suite.network)
+		req.Txs = txs
+		res, err := suite.network.GetEvmClient().IntermediateRoots(suite.network.GetContext(), &req)
+		suite.Require().NoError(err)
+		return res
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func(res *types.QueryIntermediateRootsResponse)
+	}{
+		{
+			"pass - one root per transaction and modified contract storage",
+			func(res *types.QueryIntermediateRootsResponse) {
+				suite.Require().Len(res.Roots, 1)
+				suite.Require().NotEqual(common.Hash{}.Hex(), res.Roots[0])
+				suite.Require().Equal([]string{contractAddr.Hex()}, res.ModifiedAccounts)
+			},
+		},
+		{
+			"pass - deterministic roots",
+			func(res *types.QueryIntermediateRootsResponse) {
+				suite.Require().Equal(res.Roots, getRoots(transferMsg).Roots)
+			},
+		},
+		{
+			"pass - roots chained to the previous transactions",
+			func(res *types.QueryIntermediateRootsResponse) {
+				chained := getRoots(transferMsg, transferMsg)
+				suite.Require().Len(chained.Roots, 2)
+				suite.Require().Equal(res.Roots[0], chained.Roots[0])
+				suite.Require().NotEqual(chained.Roots[0], chained.Roots[1])
+				suite.Require().Equal(res.ModifiedAccounts, chained.ModifiedAccounts)
+			},
+		},
+		{
+			"pass - no transactions",
+			func(*types.QueryIntermediateRootsResponse) {
+				res := getRoots()
+				suite.Require().Empty(res.Roots)
+				suite.Require().Empty(res.ModifiedAccounts)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			tc.malleate(getRoots(transferMsg))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestStorageRange() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	transferMsg, err := executeTransferCall(
+		transferParams{
+			senderKey:     senderKey,
+			contractAddr:  contractAddr,
+			recipientAddr: common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101"),
+		},
+		suite.factory,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	addr := tx.GenerateAddress()
+	keys := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")}
+	ctx := suite.network.GetContext()
+	for i, key := range keys {
+		suite.network.App.EVMKeeper.SetState(ctx, addr, key, common.BigToHash(big.NewInt(int64(i+1))).Bytes())
+	}
+
+	storageRange := func(req *types.QueryStorageRangeRequest) (*types.QueryStorageRangeResponse, error) {
+		return suite.network.App.EVMKeeper.StorageRange(ctx, req)
+	}
+
+	testCases := []struct {
+		msg        string
+		req        *types.QueryStorageRangeRequest
+		expKeys    []common.Hash
+		expNextKey []byte
+		expPass    bool
+	}{
+		{
+			"fail - empty request",
+			nil,
+			nil,
+			nil,
+			false,
+		},
+		{
+			"fail - invalid address",
+			&types.QueryStorageRangeRequest{Address: "0x0"},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"pass - whole storage",
+			&types.QueryStorageRangeRequest{Address: addr.Hex()},
+			keys,
+			nil,
+			true,
+		},
+		{
+			"pass - first page",
+			&types.QueryStorageRangeRequest{Address: addr.Hex(), Pagination: &query.PageRequest{Limit: 2}},
+			keys[:2],
+			keys[2].Bytes(),
+			true,
+		},
+		{
+			"pass - from a start key",
+			&types.QueryStorageRangeRequest{Address: addr.Hex(), Pagination: &query.PageRequest{Key: keys[1].Bytes()}},
+			keys[1:],
+			nil,
+			true,
+		},
+		{
+			"pass - empty storage",
+			&types.QueryStorageRangeRequest{Address: tx.GenerateAddress().Hex()},
+			[]common.Hash{},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			res, err := storageRange(tc.req)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			resKeys := make([]common.Hash, len(res.Storage))
+			for i, state := range res.Storage {
+				resKeys[i] = common.HexToHash(state.Key)
+			}
+			suite.Require().Equal(tc.expKeys, resKeys)
+			suite.Require().Equal(tc.expNextKey, res.Pagination.NextKey)
+		})
+	}
+
+	suite.Run("Case pass - storage after the replayed transactions", func() {
+		before, err := storageRange(&types.QueryStorageRangeRequest{Address: contractAddr.Hex()})
+		suite.Require().NoError(err)
+
+		replay := getDefaultTraceBlockRequest(suite.network)
+		replay.Txs = []*types.MsgEthereumTx{transferMsg}
+		after, err := storageRange(&types.QueryStorageRangeRequest{Address: contractAddr.Hex(), Replay: &replay})
+		suite.Require().NoError(err)
+
+		// the replayed transfer moved the tokens again
+		suite.Require().Len(after.Storage, len(before.Storage))
+		suite.Require().NotEqual(before.Storage, after.Storage)
+	})
+}
+
+func (suite *KeeperTestSuite) TestPreimage() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	// the preimages are only recorded from the blocks executed once enabled
+	preimageDB := dbm.NewMemDB()
+	suite.network.App.EVMKeeper.SetPreimageStore(preimageDB)
+	defer suite.network.App.EVMKeeper.SetPreimageStore(nil)
+
+	recipient := common.HexToAddress("0xC6Fe5D33615a1C52c08018c47E8Bc53646A0E101")
+	_, err = executeTransferCall(
+		transferParams{
+			senderKey:     senderKey,
+			contractAddr:  contractAddr,
+			recipientAddr: recipient,
+		},
+		suite.factory,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	// the balances mapping slots of the sender and the recipient are hashed
+	var recipientSlot common.Hash
+	iterator, err := preimageDB.Iterator(nil, nil)
+	suite.Require().NoError(err)
+	for ; iterator.Valid(); iterator.Next() {
+		suite.Require().Equal(crypto.Keccak256(iterator.Value()), iterator.Key())
+		if bytes.HasPrefix(iterator.Value(), common.LeftPadBytes(recipient.Bytes(), 32)) {
+			recipientSlot = common.BytesToHash(iterator.Key())
+		}
+	}
+	suite.Require().NoError(iterator.Close())
+	suite.Require().NotEqual(common.Hash{}, recipientSlot)
+
+	ctx := suite.network.GetContext()
+	testCases := []struct {
+		msg         string
+		malleate    func() *types.QueryPreimageRequest
+		expPreimage []byte
+		expPass     bool
+	}{
+		{
+			"fail - empty request",
+			func() *types.QueryPreimageRequest { return nil },
+			nil,
+			false,
+		},
+		{
+			"fail - invalid hash",
+			func() *types.QueryPreimageRequest {
+				return &types.QueryPreimageRequest{Hash: "0x01"}
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - unknown preimage",
+			func() *types.QueryPreimageRequest {
+				return &types.QueryPreimageRequest{Hash: common.HexToHash("0x01").Hex()}
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - recording disabled",
+			func() *types.QueryPreimageRequest {
+				suite.network.App.EVMKeeper.SetPreimageStore(nil)
+				return &types.QueryPreimageRequest{Hash: recipientSlot.Hex()}
+			},
+			nil,
+			false,
+		},
+		{
+			"pass - recorded preimage",
+			func() *types.QueryPreimageRequest {
+				return &types.QueryPreimageRequest{Hash: recipientSlot.Hex()}
+			},
+			append(common.LeftPadBytes(recipient.Bytes(), 32), make([]byte, 32)...),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.network.App.EVMKeeper.SetPreimageStore(preimageDB)
+			req := tc.malleate()
+
+			res, err := suite.network.App.EVMKeeper.Preimage(ctx, req)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPreimage, res.Preimage)
+		})
+	}
+
+	suite.Run("Case pass - preimages not recorded by queries", func() {
+		suite.network.App.EVMKeeper.SetPreimageStore(preimageDB)
+		preimage := append(common.LeftPadBytes(tx.GenerateAddress().Bytes(), 32), make([]byte, 32)...)
+
+		erc20Contract, err := testdata.LoadERC20Contract()
+		suite.Require().NoError(err)
+		data, err := erc20Contract.ABI.Pack("balanceOf", common.BytesToAddress(preimage[12:32]))
+		suite.Require().NoError(err)
+		args, err := json.Marshal(&types.TransactionArgs{To: &contractAddr, Data: (*hexutil.Bytes)(&data)})
+		suite.Require().NoError(err)
+		_, err = suite.network.App.EVMKeeper.EthCall(ctx, &types.EthCallRequest{Args: args, ChainId: suite.network.GetEIP155ChainID().Int64()})
+		suite.Require().NoError(err)
+
+		found, err := preimageDB.Has(crypto.Keccak256(preimage))
+		suite.Require().NoError(err)
+		suite.Require().False(found)
+	})
+}
+
+func (suite *KeeperTestSuite) TestAccountRange() {
+	suite.enableFeemarket = true
+	defer func() { suite.enableFeemarket = false }()
+	suite.SetupTest()
+
+	senderKey := suite.keyring.GetKey(0)
+	contractAddr, err := deployErc20Contract(senderKey, suite.factory)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.network.NextBlock())
+
+	ctx := suite.network.GetContext()
+	accountRange := func(req *types.QueryAccountRangeRequest) []types.DumpAccount {
+		res, err := suite.network.App.EVMKeeper.AccountRange(ctx, req)
+		suite.Require().NoError(err)
+		return res.Accounts
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+	}{
+		{
+			"pass - accounts sorted by address",
+			func() {
+				accounts := accountRange(&types.QueryAccountRangeRequest{})
+				suite.Require().NotEmpty(accounts)
+				for i := 1; i < len(accounts); i++ {
+					suite.Require().Negative(bytes.Compare(common.HexToAddress(accounts[i-1].Address).Bytes(), common.HexToAddress(accounts[i].Address).Bytes()))
+				}
+			},
+		},
+		{
+			"pass - pages following each other",
+			func() {
+				res, err := suite.network.App.EVMKeeper.AccountRange(ctx, &types.QueryAccountRangeRequest{Pagination: &query.PageRequest{Limit: 1}})
+				suite.Require().NoError(err)
+				suite.Require().Len(res.Accounts, 1)
+				suite.Require().NotEmpty(res.Pagination.NextKey)
+
+				next := accountRange(&types.QueryAccountRangeRequest{Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: 1}})
+				suite.Require().Len(next, 1)
+				suite.Require().Equal(common.BytesToAddress(res.Pagination.NextKey).Hex(), next[0].Address)
+			},
+		},
+		{
+			"pass - contract with its code and storage",
+			func() {
+				accounts := accountRange(&types.QueryAccountRangeRequest{Pagination: &query.PageRequest{Key: contractAddr.Bytes(), Limit: 1}})
+				suite.Require().Len(accounts, 1)
+				suite.Require().Equal(contractAddr.Hex(), accounts[0].Address)
+				suite.Require().NotEmpty(accounts[0].Code)
+				suite.Require().NotEmpty(accounts[0].Storage)
+			},
+		},
+		{
+			"pass - contract without its code and storage",
+			func() {
+				accounts := accountRange(&types.QueryAccountRangeRequest{
+					Pagination: &query.PageRequest{Key: contractAddr.Bytes(), Limit: 1},
+					NoCode:     true,
+					NoStorage:  true,
+				})
+				suite.Require().Len(accounts, 1)
+				suite.Require().Empty(accounts[0].Code)
+				suite.Require().Empty(accounts[0].Storage)
+				suite.Require().NotEqual(common.BytesToHash(types.EmptyCodeHash).Hex(), accounts[0].CodeHash)
+			},
+		},
+		{
+			"fail - offset pagination",
+			func() {
+				_, err := suite.network.App.EVMKeeper.AccountRange(ctx, &types.QueryAccountRangeRequest{Pagination: &query.PageRequest{Offset: 1}})
+				suite.Require().Error(err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			tc.malleate()
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTraceCall() {
+	suite.SetupTest()
+
+	sender := suite.keyring.GetAddr(0)
+	recipient := suite.keyring.GetAddr(1)
+	value := (*hexutil.Big)(big.NewInt(1000))
+	gas := hexutil.Uint64(ethparams.TxGas)
+
+	// PUSH1 0x2a PUSH1 0x00 MSTORE STOP
+	initCode := hexutil.Bytes{byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x00, byte(vm.MSTORE), byte(vm.STOP)}
+
+	// NUMBER PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	contract := tx.GenerateAddress()
+	contractCode := hexutil.Bytes{byte(vm.NUMBER), byte(vm.PUSH1), 0x00, byte(vm.MSTORE), byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x00, byte(vm.RETURN)}
+	overridesBz, err := json.Marshal(types.StateOverride{contract: {Code: &contractCode}})
+	suite.Require().NoError(err)
+
+	blockNumber := big.NewInt(1_000_000)
+	blockOverridesBz, err := json.Marshal(types.BlockOverrides{Number: (*hexutil.Big)(blockNumber)})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name           string
+		args           types.TransactionArgs
+		traceConfig    *types.TraceConfig
+		overrides      []byte
+		blockOverrides []byte
+		expPass        bool
+		checkResult    func(data []byte)
+	}{
+		{
+			"fail - negative limit",
+			types.TransactionArgs{From: &sender, To: &recipient},
+			&types.TraceConfig{Limit: -1},
+			nil,
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail - invalid state overrides",
+			types.TransactionArgs{From: &sender, To: &recipient},
+			&types.TraceConfig{Tracer: "callTracer"},
+			[]byte("invalid"),
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail - invalid block overrides",
+			types.TransactionArgs{From: &sender, To: &recipient},
+			&types.TraceConfig{Tracer: "callTracer"},
+			nil,
+			[]byte("invalid"),
+			false,
+			nil,
+		},
+		{
+			"pass - transfer with call tracer",
+			types.TransactionArgs{From: &sender, To: &recipient, Value: value, Gas: &gas},
+			&types.TraceConfig{Tracer: "callTracer"},
+			nil,
+			nil,
+			true,
+			func(data []byte) {
+				var call struct {
+					Type    string         `json:"type"`
+					From    common.Address `json:"from"`
+					To      common.Address `json:"to"`
+					Value   *hexutil.Big   `json:"value"`
+					GasUsed hexutil.Uint64 `json:"gasUsed"`
+				}
+				suite.Require().NoError(json.Unmarshal(data, &call))
+				suite.Require().Equal("CALL", call.Type)
+				suite.Require().Equal(sender, call.From)
+				suite.Require().Equal(recipient, call.To)
+				suite.Require().Equal(value.ToInt(), call.Value.ToInt())
+				suite.Require().Equal(hexutil.Uint64(ethparams.TxGas), call.GasUsed)
+			},
 		},
 		{
-			msg: "invalid trace config - Invalid Tracer",
-			getRequest: func() types.QueryTraceBlockRequest {
-				defaultReq := getDefaultTraceBlockRequest(suite.network)
-				defaultReq.TraceConfig = &types.TraceConfig{
-					Tracer: "invalid_tracer",
+			"pass - contract creation with parity vm tracer",
+			types.TransactionArgs{From: &sender, Data: &initCode},
+			&types.TraceConfig{Tracer: "parityVmTracer"},
+			nil,
+			nil,
+			true,
+			func(data []byte) {
+				var trace struct {
+					Code hexutil.Bytes `json:"code"`
+					Ops  []struct {
+						Cost uint64 `json:"cost"`
+						PC   uint64 `json:"pc"`
+						Ex   struct {
+							Mem *struct {
+								Off  uint64        `json:"off"`
+								Data hexutil.Bytes `json:"data"`
+							} `json:"mem"`
+							Push []*hexutil.Big `json:"push"`
+							Used uint64         `json:"used"`
+						} `json:"ex"`
+					} `json:"ops"`
+				}
+				suite.Require().NoError(json.Unmarshal(data, &trace))
+				suite.Require().Equal(initCode, trace.Code)
+				suite.Require().Len(trace.Ops, 4)
+
+				suite.Require().Equal([]uint64{0, 2, 4, 5}, []uint64{trace.Ops[0].PC, trace.Ops[1].PC, trace.Ops[2].PC, trace.Ops[3].PC})
+				suite.Require().Equal(big.NewInt(0x2a), trace.Ops[0].Ex.Push[0].ToInt())
+				suite.Require().Zero(trace.Ops[1].Ex.Push[0].ToInt().Sign())
+				suite.Require().Empty(trace.Ops[2].Ex.Push)
+				suite.Require().NotNil(trace.Ops[2].Ex.Mem)
+				suite.Require().Equal(common.BigToHash(big.NewInt(0x2a)).Bytes(), []byte(trace.Ops[2].Ex.Mem.Data))
+				for i := 1; i < len(trace.Ops); i++ {
+					suite.Require().Equal(trace.Ops[i-1].Ex.Used-trace.Ops[i].Cost, trace.Ops[i].Ex.Used)
 				}
-				return defaultReq
 			},
-			getAdditionalTxs: func() []*types.MsgEthereumTx {
-				return nil
+		},
+		{
+			"pass - call with state and block overrides",
+			types.TransactionArgs{From: &sender, To: &contract},
+			&types.TraceConfig{Tracer: "callTracer"},
+			overridesBz,
+			blockOverridesBz,
+			true,
+			func(data []byte) {
+				var call struct {
+					Output hexutil.Bytes `json:"output"`
+				}
+				suite.Require().NoError(json.Unmarshal(data, &call))
+				suite.Require().Equal(common.BigToHash(blockNumber).Bytes(), []byte(call.Output))
 			},
-			expPass: true,
-			traceResponse: "[{\"error\":\"rpc error: code = Internal desc = ReferenceError: invalid_tracer is not" +
-				" defined",
 		},
 	}
-
 	for _, tc := range testCases {
-		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
-			// Start from fresh block
-			suite.Require().NoError(suite.network.NextBlock())
-
-			// ----- Contract Deployment -----
-			senderKey := suite.keyring.GetKey(0)
-			contractAddr, err := deployErc20Contract(senderKey, suite.factory)
-			suite.Require().NoError(err)
-
-			err = suite.network.NextBlock()
-			suite.Require().NoError(err)
-
-			// --- Add predecessor ---
-			txs := tc.getAdditionalTxs()
-
-			// --- Contract Call ---
-			msgToTrace, err := executeTransferCall(
-				transferParams{
-					senderKey:     senderKey,
-					contractAddr:  contractAddr,
-					recipientAddr: hardcodedTransferRecipient,
-				},
-				suite.factory,
-			)
+		suite.Run(tc.name, func() {
+			args, err := json.Marshal(&tc.args)
 			suite.Require().NoError(err)
-			txs = append(txs, msgToTrace)
-
-			suite.Require().NoError(suite.network.NextBlock())
-
-			// Get the trace request
-			traceReq := tc.getRequest()
-			// Add txs to trace request
-			traceReq.Txs = txs
-
-			res, err := suite.network.GetEvmClient().TraceBlock(suite.network.GetContext(), &traceReq)
 
-			if tc.expPass {
-				suite.Require().NoError(err)
-				// if data is too big, slice the result
-				if len(res.Data) > 200 {
-					suite.Require().Contains(string(res.Data[:200]), tc.traceResponse)
-				} else {
-					suite.Require().Contains(string(res.Data), tc.traceResponse)
-				}
-			} else {
+			req := &types.QueryTraceCallRequest{
+				Args:           args,
+				GasCap:         config.DefaultGasCap,
+				TraceConfig:    tc.traceConfig,
+				ChainId:        suite.network.GetEIP155ChainID().Int64(),
+				Overrides:      tc.overrides,
+				BlockOverrides: tc.blockOverrides,
+			}
+			res, err := suite.network.GetEvmClient().TraceCall(suite.network.GetContext(), req)
+			if !tc.expPass {
 				suite.Require().Error(err)
+				return
 			}
+			suite.Require().NoError(err)
+			tc.checkResult(res.Data)
 		})
 	}
+
+	suite.Run("fail - invalid args", func() {
+		req := &types.QueryTraceCallRequest{Args: []byte("invalid"), GasCap: config.DefaultGasCap}
+		_, err := suite.network.GetEvmClient().TraceCall(suite.network.GetContext(), req)
+		suite.Require().Error(err)
+	})
 }
 
 func (suite *KeeperTestSuite) TestNonceInQuery() {
@@ -1765,6 +2548,466 @@ func (suite *KeeperTestSuite) TestEthCall() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestEthCallWithStateOverrides() {
+	suite.SetupTest()
+
+	// PUSH1 0x00 SLOAD PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	// returns the value stored in the slot 0 of the contract
+	code := hexutil.Bytes(common.FromHex("0x60005460005260206000f3"))
+	contract := tx.GenerateAddress()
+	sender := tx.GenerateAddress()
+	value := common.BigToHash(big.NewInt(42))
+	balance := (*hexutil.Big)(big.NewInt(1e18))
+
+	testCases := []struct {
+		name      string
+		overrides types.StateOverride
+		expPass   bool
+		expRet    []byte
+	}{
+		{
+			"pass - no overrides",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"pass - code, state and sender balance overrides",
+			types.StateOverride{
+				contract: {
+					Code:  &code,
+					State: &map[common.Hash]common.Hash{{}: value},
+				},
+				sender: {Balance: &balance},
+			},
+			true,
+			value.Bytes(),
+		},
+		{
+			"pass - code, state diff and sender balance overrides",
+			types.StateOverride{
+				contract: {
+					Code:      &code,
+					StateDiff: &map[common.Hash]common.Hash{{}: value},
+				},
+				sender: {Balance: &balance},
+			},
+			true,
+			value.Bytes(),
+		},
+		{
+			"fail - state and state diff set at the same time",
+			types.StateOverride{
+				contract: {
+					Code:      &code,
+					State:     &map[common.Hash]common.Hash{{}: value},
+					StateDiff: &map[common.Hash]common.Hash{{}: value},
+				},
+			},
+			false,
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			args, err := json.Marshal(&types.TransactionArgs{
+				From:  &sender,
+				To:    &contract,
+				Value: (*hexutil.Big)(big.NewInt(100)),
+			})
+			suite.Require().NoError(err)
+
+			req := &types.EthCallRequest{Args: args, GasCap: config.DefaultGasCap}
+			if tc.overrides != nil {
+				req.Overrides, err = json.Marshal(tc.overrides)
+				suite.Require().NoError(err)
+			}
+
+			res, err := suite.network.GetEvmClient().EthCall(suite.network.GetContext(), req)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			if tc.overrides == nil {
+				// the sender has no funds to transfer the value
+				suite.Require().NotEmpty(res.VmError)
+				return
+			}
+			suite.Require().Empty(res.VmError)
+			suite.Require().Equal(tc.expRet, res.Ret)
+
+			// the overrides must not be persisted
+			suite.Require().Empty(suite.network.App.EVMKeeper.GetCode(suite.network.GetContext(), crypto.Keccak256Hash(code)))
+			suite.Require().Equal(common.Hash{}, suite.network.App.EVMKeeper.GetState(suite.network.GetContext(), contract, common.Hash{}))
+
+			gasRes, err := suite.network.GetEvmClient().EstimateGas(suite.network.GetContext(), req)
+			suite.Require().NoError(err)
+			suite.Require().Greater(gasRes.Gas, ethparams.TxGas)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestEthCallWithBlockOverrides() {
+	suite.SetupTest()
+
+	number := (*hexutil.Big)(big.NewInt(1_000_000))
+	blockTime := hexutil.Uint64(4_102_444_800)
+	gasLimit := hexutil.Uint64(12_345_678)
+	feeRecipient := tx.GenerateAddress()
+	prevRandao := common.BigToHash(big.NewInt(7))
+	baseFee := (*hexutil.Big)(big.NewInt(1_000))
+
+	testCases := []struct {
+		name      string
+		opcode    vm.OpCode
+		overrides *types.BlockOverrides
+		expRet    []byte
+	}{
+		{"block number", vm.NUMBER, &types.BlockOverrides{Number: number}, common.BigToHash(number.ToInt()).Bytes()},
+		{"timestamp", vm.TIMESTAMP, &types.BlockOverrides{Time: &blockTime}, common.BigToHash(new(big.Int).SetUint64(uint64(blockTime))).Bytes()},
+		{"gas limit", vm.GASLIMIT, &types.BlockOverrides{GasLimit: &gasLimit}, common.BigToHash(new(big.Int).SetUint64(uint64(gasLimit))).Bytes()},
+		{"coinbase", vm.COINBASE, &types.BlockOverrides{FeeRecipient: &feeRecipient}, common.BytesToHash(feeRecipient.Bytes()).Bytes()},
+		{"prevrandao", vm.PREVRANDAO, &types.BlockOverrides{PrevRandao: &prevRandao}, prevRandao.Bytes()},
+		{"base fee", vm.BASEFEE, &types.BlockOverrides{BaseFeePerGas: baseFee}, common.BigToHash(baseFee.ToInt()).Bytes()},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			// <OPCODE> PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+			code := hexutil.Bytes(append([]byte{byte(tc.opcode)}, common.FromHex("0x60005260206000f3")...))
+			contract := tx.GenerateAddress()
+
+			args, err := json.Marshal(&types.TransactionArgs{To: &contract})
+			suite.Require().NoError(err)
+			overridesBz, err := json.Marshal(types.StateOverride{contract: {Code: &code}})
+			suite.Require().NoError(err)
+
+			req := &types.EthCallRequest{Args: args, GasCap: config.DefaultGasCap, Overrides: overridesBz}
+
+			// without block overrides the current block context is used
+			res, err := suite.network.GetEvmClient().EthCall(suite.network.GetContext(), req)
+			suite.Require().NoError(err)
+			suite.Require().Empty(res.VmError)
+			suite.Require().NotEqual(tc.expRet, res.Ret)
+
+			req.BlockOverrides, err = json.Marshal(tc.overrides)
+			suite.Require().NoError(err)
+
+			res, err = suite.network.GetEvmClient().EthCall(suite.network.GetContext(), req)
+			suite.Require().NoError(err)
+			suite.Require().Empty(res.VmError)
+			suite.Require().Equal(tc.expRet, res.Ret)
+		})
+	}
+
+	suite.Run("fail - invalid block overrides", func() {
+		args, err := json.Marshal(&types.TransactionArgs{To: &feeRecipient})
+		suite.Require().NoError(err)
+
+		req := &types.EthCallRequest{Args: args, GasCap: config.DefaultGasCap, BlockOverrides: []byte("{\"time\": 1}")}
+		_, err = suite.network.GetEvmClient().EthCall(suite.network.GetContext(), req)
+		suite.Require().Error(err)
+	})
+}
+
+func (suite *KeeperTestSuite) TestCreateAccessList() {
+	suite.SetupTest()
+
+	sender := tx.GenerateAddress()
+	contract := tx.GenerateAddress()
+	other := tx.GenerateAddress()
+	precompile := common.HexToAddress(types.BankPrecompileAddress)
+
+	// PUSH20 <other> BALANCE POP PUSH20 <precompile> BALANCE POP PUSH1 0x01 SLOAD POP STOP
+	code := []byte{byte(vm.PUSH20)}
+	code = append(code, other.Bytes()...)
+	code = append(code, byte(vm.BALANCE), byte(vm.POP), byte(vm.PUSH20))
+	code = append(code, precompile.Bytes()...)
+	code = append(code, byte(vm.BALANCE), byte(vm.POP), byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP))
+	contractCode := hexutil.Bytes(code)
+
+	overridesBz, err := json.Marshal(types.StateOverride{contract: {Code: &contractCode}})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name    string
+		args    types.TransactionArgs
+		expPass bool
+		expList ethtypes.AccessList
+	}{
+		{
+			"pass - transfer without storage access",
+			types.TransactionArgs{From: &sender, To: &other},
+			true,
+			ethtypes.AccessList{},
+		},
+		{
+			"pass - accessed accounts and slots",
+			types.TransactionArgs{From: &sender, To: &contract},
+			true,
+			ethtypes.AccessList{
+				{Address: contract, StorageKeys: []common.Hash{common.BigToHash(big.NewInt(1))}},
+				{Address: other, StorageKeys: []common.Hash{}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			args, err := json.Marshal(&tc.args)
+			suite.Require().NoError(err)
+
+			req := &types.EthCallRequest{Args: args, GasCap: config.DefaultGasCap, Overrides: overridesBz}
+			res, err := suite.network.GetEvmClient().CreateAccessList(suite.network.GetContext(), req)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Empty(res.VmError)
+			suite.Require().ElementsMatch(tc.expList, *res.AccessList.ToEthAccessList())
+
+			// the reported gas is the one used with the access list applied
+			accessList := res.AccessList.ToEthAccessList()
+			tc.args.AccessList = accessList
+			args, err = json.Marshal(&tc.args)
+			suite.Require().NoError(err)
+
+			req.Args = args
+			withList, err := suite.network.GetEvmClient().CreateAccessList(suite.network.GetContext(), req)
+			suite.Require().NoError(err)
+			suite.Require().Equal(res.GasUsed, withList.GasUsed)
+			suite.Require().GreaterOrEqual(res.GasUsed, ethparams.TxGas)
+		})
+	}
+
+	suite.Run("fail - invalid args", func() {
+		req := &types.EthCallRequest{Args: []byte("invalid"), GasCap: config.DefaultGasCap}
+		_, err := suite.network.GetEvmClient().CreateAccessList(suite.network.GetContext(), req)
+		suite.Require().Error(err)
+	})
+}
+
+func (suite *KeeperTestSuite) TestSimulateV1() {
+	suite.SetupTest()
+
+	// PUSH1 0x00 SLOAD PUSH1 0x01 ADD DUP1 PUSH1 0x00 SSTORE PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	// increments the value stored in the slot 0 of the contract and returns it
+	counterCode := hexutil.Bytes(common.FromHex("0x6000546001018060005560005260206000f3"))
+	// PUSH1 0x00 PUSH1 0x00 REVERT
+	revertCode := hexutil.Bytes(common.FromHex("0x60006000fd"))
+	// COINBASE BALANCE PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	// returns the balance of the block coinbase
+	coinbaseBalanceCode := hexutil.Bytes(common.FromHex("0x413160005260206000f3"))
+	// NUMBER PUSH1 0x01 SWAP1 SUB BLOCKHASH PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	// returns the hash of the parent block
+	parentHashCode := hexutil.Bytes(common.FromHex("0x43600190034060005260206000f3"))
+
+	sender := tx.GenerateAddress()
+	recipient := tx.GenerateAddress()
+	counter := tx.GenerateAddress()
+	reverter := tx.GenerateAddress()
+	coinbase := tx.GenerateAddress()
+	balanceGetter := tx.GenerateAddress()
+	parentHashGetter := tx.GenerateAddress()
+	balance := (*hexutil.Big)(big.NewInt(1e18))
+	gasPrice := (*hexutil.Big)(big.NewInt(1e9))
+	transferGas := hexutil.Uint64(ethparams.TxGas)
+	value := (*hexutil.Big)(big.NewInt(1000))
+	blockTime := hexutil.Uint64(4_102_444_800)
+
+	ctx := suite.network.GetContext()
+	height := uint64(ctx.BlockHeight())        //nolint:gosec // G115
+	baseTime := uint64(ctx.BlockTime().Unix()) //nolint:gosec // G115
+
+	testCases := []struct {
+		name     string
+		opts     types.SimOpts
+		expPass  bool
+		malleate func(blocks []types.SimBlockResult)
+	}{
+		{
+			"fail - empty input",
+			types.SimOpts{},
+			false,
+			nil,
+		},
+		{
+			"fail - block numbers not in order",
+			types.SimOpts{
+				BlockStateCalls: []types.SimBlock{
+					{BlockOverrides: &types.BlockOverrides{Number: (*hexutil.Big)(new(big.Int).SetUint64(height))}},
+				},
+			},
+			false,
+			nil,
+		},
+		{
+			"pass - state is carried over between calls and blocks",
+			types.SimOpts{
+				BlockStateCalls: []types.SimBlock{
+					{
+						StateOverrides: &types.StateOverride{counter: {Code: &counterCode}},
+						Calls: []types.TransactionArgs{
+							{From: &sender, To: &counter},
+							{From: &sender, To: &counter},
+						},
+					},
+					{
+						BlockOverrides: &types.BlockOverrides{Time: &blockTime},
+						Calls:          []types.TransactionArgs{{From: &sender, To: &counter}},
+					},
+				},
+			},
+			true,
+			func(blocks []types.SimBlockResult) {
+				suite.Require().Len(blocks, 2)
+
+				suite.Require().Equal(height+1, blocks[0].Header.Number.Uint64())
+				suite.Require().Equal(baseTime+types.SimulateTimestampIncrement, blocks[0].Header.Time)
+				suite.Require().Equal(height+2, blocks[1].Header.Number.Uint64())
+				suite.Require().Equal(uint64(blockTime), blocks[1].Header.Time)
+				suite.Require().Equal(blocks[0].Header.Hash(), blocks[1].Header.ParentHash)
+
+				suite.Require().Len(blocks[0].Calls, 2)
+				suite.Require().Len(blocks[0].Transactions, 2)
+				suite.Require().Equal(common.BigToHash(big.NewInt(1)).Bytes(), []byte(blocks[0].Calls[0].ReturnValue))
+				suite.Require().Equal(common.BigToHash(big.NewInt(2)).Bytes(), []byte(blocks[0].Calls[1].ReturnValue))
+				suite.Require().Len(blocks[1].Calls, 1)
+				suite.Require().Equal(common.BigToHash(big.NewInt(3)).Bytes(), []byte(blocks[1].Calls[0].ReturnValue))
+
+				var gasUsed uint64
+				for _, call := range blocks[0].Calls {
+					suite.Require().Equal(hexutil.Uint64(ethtypes.ReceiptStatusSuccessful), call.Status)
+					suite.Require().Nil(call.Error)
+					suite.Require().Greater(uint64(call.GasUsed), ethparams.TxGas)
+					gasUsed += uint64(call.GasUsed)
+				}
+				suite.Require().Equal(gasUsed, blocks[0].Header.GasUsed)
+
+				// the sender nonce is increased on every call
+				for i, simTx := range append(blocks[0].Transactions, blocks[1].Transactions...) {
+					ethTx := new(ethtypes.Transaction)
+					suite.Require().NoError(ethTx.UnmarshalBinary(simTx.Tx))
+					suite.Require().Equal(uint64(i), ethTx.Nonce())
+					suite.Require().Equal(sender, simTx.From)
+				}
+			},
+		},
+		{
+			"pass - trace transfers and reverted calls",
+			types.SimOpts{
+				TraceTransfers: true,
+				BlockStateCalls: []types.SimBlock{
+					{
+						StateOverrides: &types.StateOverride{
+							sender:   {Balance: &balance},
+							reverter: {Code: &revertCode},
+						},
+						Calls: []types.TransactionArgs{
+							{From: &sender, To: &recipient, Value: value},
+							{From: &sender, To: &reverter, Value: value},
+						},
+					},
+				},
+			},
+			true,
+			func(blocks []types.SimBlockResult) {
+				suite.Require().Len(blocks, 1)
+				suite.Require().Len(blocks[0].Calls, 2)
+
+				transfer := blocks[0].Calls[0]
+				suite.Require().Equal(hexutil.Uint64(ethtypes.ReceiptStatusSuccessful), transfer.Status)
+				suite.Require().Equal(ethparams.TxGas, uint64(transfer.GasUsed))
+				suite.Require().Len(transfer.Logs, 1)
+				suite.Require().Equal(types.SimTransferAddress, transfer.Logs[0].Address)
+				suite.Require().Equal([]common.Hash{
+					types.SimTransferTopic,
+					common.BytesToHash(sender.Bytes()),
+					common.BytesToHash(recipient.Bytes()),
+				}, transfer.Logs[0].Topics)
+				suite.Require().Equal(common.BigToHash(value.ToInt()).Bytes(), transfer.Logs[0].Data)
+				suite.Require().Equal(blocks[0].Header.Hash(), transfer.Logs[0].BlockHash)
+
+				// the transfer of a reverted call is discarded
+				reverted := blocks[0].Calls[1]
+				suite.Require().Equal(hexutil.Uint64(ethtypes.ReceiptStatusFailed), reverted.Status)
+				suite.Require().NotNil(reverted.Error)
+				suite.Require().Equal(types.SimErrCodeReverted, reverted.Error.Code)
+				suite.Require().Empty(reverted.Logs)
+			},
+		},
+		{
+			"pass - gas fees are credited to the coinbase",
+			types.SimOpts{
+				BlockStateCalls: []types.SimBlock{
+					{
+						BlockOverrides: &types.BlockOverrides{FeeRecipient: &coinbase},
+						StateOverrides: &types.StateOverride{
+							sender:        {Balance: &balance},
+							balanceGetter: {Code: &coinbaseBalanceCode},
+						},
+						Calls: []types.TransactionArgs{
+							{From: &sender, To: &recipient, Gas: &transferGas, GasPrice: gasPrice},
+							{From: &sender, To: &balanceGetter},
+						},
+					},
+				},
+			},
+			true,
+			func(blocks []types.SimBlockResult) {
+				suite.Require().Len(blocks, 1)
+				suite.Require().Len(blocks[0].Calls, 2)
+				suite.Require().Equal(coinbase, blocks[0].Header.Coinbase)
+
+				fees := new(big.Int).Mul(big.NewInt(int64(ethparams.TxGas)), gasPrice.ToInt())
+				suite.Require().Equal(common.BigToHash(fees).Bytes(), []byte(blocks[0].Calls[1].ReturnValue))
+			},
+		},
+		{
+			"pass - simulated block hashes are known to BLOCKHASH",
+			types.SimOpts{
+				BlockStateCalls: []types.SimBlock{
+					{},
+					{
+						StateOverrides: &types.StateOverride{parentHashGetter: {Code: &parentHashCode}},
+						Calls:          []types.TransactionArgs{{From: &sender, To: &parentHashGetter}},
+					},
+				},
+			},
+			true,
+			func(blocks []types.SimBlockResult) {
+				suite.Require().Len(blocks, 2)
+				suite.Require().Len(blocks[1].Calls, 1)
+				suite.Require().Equal(blocks[0].Header.Hash().Bytes(), []byte(blocks[1].Calls[0].ReturnValue))
+			},
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			opts, err := json.Marshal(tc.opts)
+			suite.Require().NoError(err)
+
+			req := &types.QuerySimulateV1Request{Opts: opts, GasCap: config.DefaultGasCap}
+			res, err := suite.network.GetEvmClient().SimulateV1(suite.network.GetContext(), req)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			var blocks []types.SimBlockResult
+			suite.Require().NoError(json.Unmarshal(res.Data, &blocks))
+			tc.malleate(blocks)
+
+			// the simulated state must not be persisted
+			ctx := suite.network.GetContext()
+			suite.Require().Equal(common.Hash{}, suite.network.App.EVMKeeper.GetState(ctx, counter, common.Hash{}))
+			suite.Require().Zero(suite.network.App.EVMKeeper.GetNonce(ctx, sender))
+		})
+	}
+}
+
 func (suite *KeeperTestSuite) TestBalance() {
 	testCases := []struct {
 		name        string
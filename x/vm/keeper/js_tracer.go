@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/js" // register the JS tracer engine
+)
+
+// SetJSTracerLimits sets the limits of the JS tracers run by the trace queries.
+// A JS tracer is stopped once it runs for longer than the timeout, or once it
+// is called back for more than the step limit of EVM steps, call frames and
+// faults during the trace. The step limit bounds the work of each trace, and
+// so the data it accumulates, independently of the other traces run by the
+// node. Zero values disable the limits.
+func (k *Keeper) SetJSTracerLimits(timeout time.Duration, stepLimit uint64) *Keeper {
+	k.jsTracerTimeout = timeout
+	k.jsTracerStepLimit = stepLimit
+	return k
+}
+
+// isJSTracer returns true if the tracer evaluates user-provided JS code.
+func isJSTracer(tracer string) bool {
+	return tracer != "" && tracers.DefaultDirectory.IsJS(tracer)
+}
+
+// traceTimeout returns the timeout of the trace, capped by the JS tracer
+// timeout for JS tracers.
+func (k Keeper) traceTimeout(tracer string, timeout time.Duration) time.Duration {
+	if !isJSTracer(tracer) || k.jsTracerTimeout <= 0 || timeout <= k.jsTracerTimeout {
+		return timeout
+	}
+	return k.jsTracerTimeout
+}
+
+// jsTracerHooks returns the hooks of the tracer, counting the callbacks of the
+// JS tracers: the JS VM of the tracer is interrupted once the step limit is
+// exceeded, which fails the trace.
+func (k Keeper) jsTracerHooks(tracer string, t *tracers.Tracer) *tracing.Hooks {
+	if !isJSTracer(tracer) || k.jsTracerStepLimit == 0 {
+		return t.Hooks
+	}
+
+	var steps uint64
+	step := func() {
+		steps++
+		if steps == k.jsTracerStepLimit+1 {
+			t.Stop(fmt.Errorf("js tracer step limit exceeded: %d steps", k.jsTracerStepLimit))
+		}
+	}
+
+	hooks := *t.Hooks
+	if onOpcode := t.OnOpcode; onOpcode != nil {
+		hooks.OnOpcode = func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+			step()
+			onOpcode(pc, op, gas, cost, scope, rData, depth, err)
+		}
+	}
+	if onFault := t.OnFault; onFault != nil {
+		hooks.OnFault = func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+			step()
+			onFault(pc, op, gas, cost, scope, depth, err)
+		}
+	}
+	if onEnter := t.OnEnter; onEnter != nil {
+		hooks.OnEnter = func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+			step()
+			onEnter(depth, typ, from, to, input, gas, value)
+		}
+	}
+	if onExit := t.OnExit; onExit != nil {
+		hooks.OnExit = func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+			step()
+			onExit(depth, output, gasUsed, err, reverted)
+		}
+	}
+	return &hooks
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -11,8 +12,11 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/evm/utils"
 	"github.com/cosmos/evm/x/vm/statedb"
+	_ "github.com/cosmos/evm/x/vm/tracers/live"   // register the live tracers of the EVM module
+	_ "github.com/cosmos/evm/x/vm/tracers/native" // register the native tracers of the EVM module
 	"github.com/cosmos/evm/x/vm/types"
 	"github.com/cosmos/evm/x/vm/wrappers"
 
@@ -67,6 +71,22 @@ type Keeper struct {
 
 	// Tracer used to collect execution traces from the EVM transaction execution
 	tracer string
+	// tracerConfig is the logger configuration of the tracer
+	tracerConfig types.TracerConfig
+	// jsTracerTimeout is the maximum duration of a JS tracer trace
+	jsTracerTimeout time.Duration
+	// jsTracerStepLimit is the maximum number of callbacks of a JS tracer during a trace
+	jsTracerStepLimit uint64
+	// traceBlockConcurrency is the number of transactions traced concurrently
+	// by the block traces, which trace them in order if not greater than one
+	traceBlockConcurrency int
+	// queryContextCreator creates the contexts of the streaming queries
+	queryContextCreator QueryContextCreator
+	// liveTracer is invoked for every block and transaction executed by the node
+	liveTracer *tracing.Hooks
+	// preimages is the node-local database of the SHA3 preimages seen by the EVM,
+	// only set if their recording is enabled
+	preimages dbm.DB
 
 	hooks types.EvmHooks
 	// EVM Hooks for tx post-processing
@@ -77,6 +97,10 @@ type Keeper struct {
 	precompiles map[common.Address]vm.PrecompiledContract
 }
 
+// QueryContextCreator creates the context of a query at the given height,
+// the latest height if zero.
+type QueryContextCreator func(height int64, prove bool) (sdk.Context, error)
+
 // NewKeeper generates new evm module keeper
 func NewKeeper(
 	cdc codec.BinaryCodec,
@@ -248,9 +272,46 @@ func (k Keeper) GetAccountStorage(ctx sdk.Context, address common.Address) types
 // Account
 // ----------------------------------------------------------------------------
 
+// SetQueryContextCreator sets the creator of the contexts of the streaming
+// queries, which aren't provided by the gRPC server of the app.
+func (k *Keeper) SetQueryContextCreator(creator QueryContextCreator) *Keeper {
+	k.queryContextCreator = creator
+	return k
+}
+
+// SetTracerConfig sets the logger configuration of the tracer used to collect
+// execution traces from the EVM transaction execution.
+func (k *Keeper) SetTracerConfig(cfg types.TracerConfig) *Keeper {
+	k.tracerConfig = cfg
+	return k
+}
+
+// SetLiveTracer sets the live tracer invoked for every block and transaction
+// executed by the node. It must be set once the EVM chain config is configured.
+func (k *Keeper) SetLiveTracer(tracer *tracing.Hooks) *Keeper {
+	if tracer != nil && tracer.OnBlockchainInit != nil {
+		tracer.OnBlockchainInit(types.GetEthChainConfig())
+	}
+	k.liveTracer = tracer
+	return k
+}
+
+// LiveTracer returns the live tracer of the node, if any.
+func (k Keeper) LiveTracer() *tracing.Hooks {
+	return k.liveTracer
+}
+
+// liveTracing returns the live tracer if the context is executing a block.
+func (k Keeper) liveTracing(ctx sdk.Context) *tracing.Hooks {
+	if k.liveTracer == nil || ctx.ExecMode() != sdk.ExecModeFinalize {
+		return nil
+	}
+	return k.liveTracer
+}
+
 // Tracer return a default vm.Tracer based on current keeper state
 func (k Keeper) Tracer(ctx sdk.Context, msg core.Message, ethCfg *params.ChainConfig) *tracing.Hooks {
-	return types.NewTracer(k.tracer, msg, ethCfg, ctx.BlockHeight(), uint64(ctx.BlockTime().Unix())) //#nosec G115 -- int overflow is not a concern here
+	return types.NewTracer(k.tracer, k.tracerConfig, msg, ethCfg, ctx.BlockHeight(), uint64(ctx.BlockTime().Unix())) //#nosec G115 -- int overflow is not a concern here
 }
 
 // GetAccountWithoutBalance load nonce and codehash without balance,
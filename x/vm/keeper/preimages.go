@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetPreimageStore sets the node-local database the SHA3 preimages seen by the
+// EVM are persisted in. The preimages are only recorded if the store is set.
+func (k *Keeper) SetPreimageStore(db dbm.DB) *Keeper {
+	k.preimages = db
+	return k
+}
+
+// PreimageStore returns the node-local database of the SHA3 preimages, if any.
+func (k Keeper) PreimageStore() dbm.DB {
+	return k.preimages
+}
+
+// GetPreimage returns the SHA3 preimage of the hash recorded by the node, or
+// nil if it isn't found or the preimages aren't recorded.
+func (k Keeper) GetPreimage(hash common.Hash) ([]byte, error) {
+	if k.preimages == nil {
+		return nil, nil
+	}
+	return k.preimages.Get(hash.Bytes())
+}
+
+// recordsPreimages returns true if the SHA3 preimages seen by the EVM must be
+// recorded in the given context. They are only recorded when executing a
+// block, so that queries such as `eth_call` can't fill the node database.
+func (k Keeper) recordsPreimages(ctx sdk.Context) bool {
+	return k.preimages != nil && ctx.ExecMode() == sdk.ExecModeFinalize
+}
+
+// storePreimages persists the SHA3 preimages in the node-local database. As
+// the database isn't part of the consensus state, a failure is only logged.
+func (k Keeper) storePreimages(ctx sdk.Context, preimages map[common.Hash][]byte) {
+	if k.preimages == nil || len(preimages) == 0 {
+		return
+	}
+
+	batch := k.preimages.NewBatch()
+	defer batch.Close()
+	for hash, preimage := range preimages {
+		if err := batch.Set(hash.Bytes(), preimage); err != nil {
+			k.Logger(ctx).Error("failed to store preimage", "hash", hash.Hex(), "error", err.Error())
+			return
+		}
+	}
+	if err := batch.Write(); err != nil {
+		k.Logger(ctx).Error("failed to store preimages", "error", err.Error())
+	}
+}
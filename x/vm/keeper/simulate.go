@@ -0,0 +1,427 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+
+	cosmosevmtypes "github.com/cosmos/evm/types"
+	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// simulate executes the blocks of an `eth_simulateV1` request on top of the
+// state of the given context. The state changes of every call are carried over
+// to the following calls and blocks, but they are only committed to a cached
+// context that is discarded once the simulation ends.
+func (k *Keeper) simulate(ctx sdk.Context, cfg *statedb.EVMConfig, opts types.SimOpts, gasCap uint64) ([]types.SimBlockResult, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, errors.New("empty input")
+	}
+	if len(opts.BlockStateCalls) > types.MaxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks: %d > %d", len(opts.BlockStateCalls), types.MaxSimulateBlocks)
+	}
+
+	ctx, _ = k.SetConsensusParamsInCtx(ctx).CacheContext()
+
+	blockGasLimit := cosmosevmtypes.BlockGasLimit(ctx)
+	if blockGasLimit == 0 {
+		blockGasLimit = math.MaxUint64
+	}
+	if gasCap == 0 {
+		gasCap = math.MaxUint64
+	}
+
+	parent := &ethtypes.Header{
+		Number:   big.NewInt(ctx.BlockHeight()),
+		Time:     uint64(ctx.BlockTime().Unix()), //#nosec G115 -- int overflow is not a concern here
+		GasLimit: blockGasLimit,
+	}
+	getHash := k.GetHashFn(ctx)
+	parentHash := getHash(parent.Number.Uint64())
+
+	// the hashes of the simulated blocks are looked up by BLOCKHASH in the
+	// following blocks, the other ones are the hashes of the chain
+	baseHeight := parent.Number.Uint64()
+	simulatedHashes := make(map[uint64]common.Hash, len(opts.BlockStateCalls))
+	simulatedGetHash := func(height uint64) common.Hash {
+		if height > baseHeight {
+			return simulatedHashes[height]
+		}
+		return getHash(height)
+	}
+
+	results := make([]types.SimBlockResult, 0, len(opts.BlockStateCalls))
+	for i, block := range opts.BlockStateCalls {
+		header, err := simulatedHeader(parent, parentHash, block.BlockOverrides, cfg, opts.Validation)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		if block.StateOverrides != nil {
+			if err := k.overrideState(ctx, *block.StateOverrides); err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+		}
+
+		// every call of the block is executed in the context of the simulated header
+		blockCfg := *cfg
+		blockCfg.CoinBase = header.Coinbase
+		blockCfg.BaseFee = header.BaseFee
+		blockCfg.GetHash = simulatedGetHash
+		blockCfg.BlockOverrides = &types.BlockOverrides{
+			Number:        (*hexutil.Big)(header.Number),
+			Time:          (*hexutil.Uint64)(&header.Time),
+			GasLimit:      (*hexutil.Uint64)(&header.GasLimit),
+			FeeRecipient:  &header.Coinbase,
+			BaseFeePerGas: (*hexutil.Big)(header.BaseFee),
+		}
+		if block.BlockOverrides != nil && block.BlockOverrides.PrevRandao != nil {
+			blockCfg.BlockOverrides.PrevRandao = &header.MixDigest
+		}
+
+		result := types.SimBlockResult{
+			Transactions: make([]types.SimTransaction, 0, len(block.Calls)),
+			Calls:        make([]types.SimCallResult, 0, len(block.Calls)),
+		}
+		var (
+			txs      = make(ethtypes.Transactions, 0, len(block.Calls))
+			receipts = make(ethtypes.Receipts, 0, len(block.Calls))
+			logs     []*ethtypes.Log
+		)
+		for j, args := range block.Calls {
+			available := min(header.GasLimit-header.GasUsed, gasCap)
+			callResult, tx, err := k.simulateCall(ctx, &blockCfg, args, available, opts)
+			if err != nil {
+				return nil, fmt.Errorf("block %d, call %d: %w", i, j, err)
+			}
+
+			header.GasUsed += uint64(callResult.GasUsed)
+			gasCap -= uint64(callResult.GasUsed)
+
+			for _, log := range callResult.Logs {
+				log.TxIndex = uint(j)
+				log.Index = uint(len(logs))
+				logs = append(logs, log)
+			}
+
+			txBz, err := tx.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			result.Transactions = append(result.Transactions, types.SimTransaction{
+				From: args.GetFrom(),
+				Tx:   txBz,
+			})
+			result.Calls = append(result.Calls, callResult)
+			txs = append(txs, tx)
+
+			receipt := &ethtypes.Receipt{
+				Type:              tx.Type(),
+				Status:            uint64(callResult.Status),
+				CumulativeGasUsed: header.GasUsed,
+				Logs:              callResult.Logs,
+			}
+			receipt.Bloom = ethtypes.CreateBloom(receipt)
+			receipts = append(receipts, receipt)
+		}
+
+		header.TxHash = ethtypes.DeriveSha(txs, trie.NewStackTrie(nil))
+		header.ReceiptHash = ethtypes.DeriveSha(receipts, trie.NewStackTrie(nil))
+		header.Bloom = ethtypes.CreateBloom(&ethtypes.Receipt{Logs: logs})
+
+		// the block hash is only known once all the calls are executed
+		blockHash := header.Hash()
+		for j, callResult := range result.Calls {
+			for _, log := range callResult.Logs {
+				log.BlockNumber = header.Number.Uint64()
+				log.BlockHash = blockHash
+				log.TxHash = txs[j].Hash()
+			}
+		}
+
+		result.Header = header
+		results = append(results, result)
+
+		simulatedHashes[header.Number.Uint64()] = blockHash
+		parent, parentHash = header, blockHash
+	}
+
+	return results, nil
+}
+
+// simulatedHeader returns the header of a simulated block built on top of the
+// given parent, with the provided overrides applied.
+func simulatedHeader(
+	parent *ethtypes.Header,
+	parentHash common.Hash,
+	overrides *types.BlockOverrides,
+	cfg *statedb.EVMConfig,
+	validation bool,
+) (*ethtypes.Header, error) {
+	if overrides == nil {
+		overrides = &types.BlockOverrides{}
+	}
+
+	header := &ethtypes.Header{
+		ParentHash: parentHash,
+		UncleHash:  ethtypes.EmptyUncleHash,
+		Coinbase:   cfg.CoinBase,
+		Root:       common.Hash{},
+		Difficulty: big.NewInt(0),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + types.SimulateTimestampIncrement,
+	}
+
+	if overrides.Number != nil {
+		if overrides.Number.ToInt().Cmp(parent.Number) <= 0 {
+			return nil, fmt.Errorf("block numbers must be in order: %s <= %s", overrides.Number.ToInt(), parent.Number)
+		}
+		header.Number = overrides.Number.ToInt()
+	}
+	if overrides.Time != nil {
+		if uint64(*overrides.Time) <= parent.Time {
+			return nil, fmt.Errorf("block timestamps must be in order: %d <= %d", uint64(*overrides.Time), parent.Time)
+		}
+		header.Time = uint64(*overrides.Time)
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.FeeRecipient != nil {
+		header.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.PrevRandao != nil {
+		header.MixDigest = *overrides.PrevRandao
+	}
+
+	// Without validation the base fee defaults to zero, so that calls without
+	// gas prices can be simulated.
+	switch {
+	case overrides.BaseFeePerGas != nil:
+		header.BaseFee = overrides.BaseFeePerGas.ToInt()
+	case validation && cfg.BaseFee != nil:
+		header.BaseFee = new(big.Int).Set(cfg.BaseFee)
+	case cfg.BaseFee != nil:
+		header.BaseFee = big.NewInt(0)
+	}
+
+	return header, nil
+}
+
+// simulateCall executes a single call of a simulated block and commits its
+// state changes to the given context. Calls failing validation or exceeding
+// the available gas return an error, while EVM execution errors are reported
+// in the call result.
+func (k *Keeper) simulateCall(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	args types.TransactionArgs,
+	available uint64,
+	opts types.SimOpts,
+) (types.SimCallResult, *ethtypes.Transaction, error) {
+	ethCfg := types.GetEthChainConfig()
+	from := args.GetFrom()
+	acct := k.GetAccountOrEmpty(ctx, from)
+
+	// fill in the defaults of the call
+	if args.Nonce == nil {
+		nonce := hexutil.Uint64(acct.Nonce)
+		args.Nonce = &nonce
+	} else if opts.Validation && uint64(*args.Nonce) != acct.Nonce {
+		return types.SimCallResult{}, nil, fmt.Errorf("invalid nonce for %s: expected %d, got %d", from, acct.Nonce, uint64(*args.Nonce))
+	}
+	if args.Gas == nil {
+		gas := hexutil.Uint64(available)
+		args.Gas = &gas
+	} else if uint64(*args.Gas) > available {
+		return types.SimCallResult{}, nil, fmt.Errorf("block gas limit reached: %d > %d", uint64(*args.Gas), available)
+	}
+	if args.ChainID == nil {
+		args.ChainID = (*hexutil.Big)(ethCfg.ChainID)
+	}
+
+	msg, err := args.ToMessage(available, cfg.BaseFee, true, true)
+	if err != nil {
+		return types.SimCallResult{}, nil, err
+	}
+
+	if opts.Validation && cfg.BaseFee != nil && msg.GasFeeCap.Cmp(cfg.BaseFee) < 0 {
+		return types.SimCallResult{}, nil, fmt.Errorf("max fee per gas less than block base fee: %s < %s", msg.GasFeeCap, cfg.BaseFee)
+	}
+
+	// the sender must be able to pay for the gas and the transferred value
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(msg.GasLimit), msg.GasFeeCap)
+	cost.Add(cost, msg.Value)
+	if acct.Balance.ToBig().Cmp(cost) < 0 {
+		return types.SimCallResult{}, nil, fmt.Errorf("insufficient funds for gas * price + value: address %s have %s want %s", from, acct.Balance, cost)
+	}
+
+	// the sender nonce is increased before the execution, like the ante handler does
+	acct.Nonce = msg.Nonce + 1
+	if err := k.SetAccount(ctx, from, acct); err != nil {
+		return types.SimCallResult{}, nil, err
+	}
+
+	tracer := newSimTracer(opts.TraceTransfers)
+	txConfig := statedb.NewEmptyTxConfig(common.Hash{})
+	res, err := k.ApplyMessageWithConfig(ctx, msg, tracer.hooks(), true, cfg, txConfig, false)
+	if err != nil {
+		return types.SimCallResult{}, nil, err
+	}
+
+	// The gas used is computed from the EVM execution, since the one returned
+	// by ApplyMessageWithConfig depends on the gas limit of the call.
+	gasUsed, err := k.evmGasUsed(ctx, msg, cfg, &tracer.callGasTracer)
+	if err != nil {
+		return types.SimCallResult{}, nil, err
+	}
+
+	// Charge the gas fees to the sender and credit them to the block coinbase.
+	// The base fee isn't burned on this chain, so the whole fees are credited.
+	if fees := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), msg.GasPrice); fees.Sign() > 0 {
+		sender := k.GetAccountOrEmpty(ctx, from)
+		if sender.Balance.ToBig().Cmp(fees) < 0 {
+			return types.SimCallResult{}, nil, fmt.Errorf("insufficient funds for gas fees: address %s have %s want %s", from, sender.Balance, fees)
+		}
+		sender.Balance = new(uint256.Int).Sub(sender.Balance, uint256.MustFromBig(fees))
+		if err := k.SetAccount(ctx, from, sender); err != nil {
+			return types.SimCallResult{}, nil, err
+		}
+
+		coinbase := k.GetAccountOrEmpty(ctx, cfg.CoinBase)
+		coinbase.Balance = new(uint256.Int).Add(coinbase.Balance, uint256.MustFromBig(fees))
+		if err := k.SetAccount(ctx, cfg.CoinBase, coinbase); err != nil {
+			return types.SimCallResult{}, nil, err
+		}
+	}
+
+	result := types.SimCallResult{
+		ReturnValue: res.Ret,
+		Logs:        tracer.logs(types.LogsToEthereum(res.Logs)),
+		GasUsed:     hexutil.Uint64(gasUsed),
+		Status:      hexutil.Uint64(ethtypes.ReceiptStatusSuccessful),
+	}
+	if result.Logs == nil {
+		result.Logs = []*ethtypes.Log{}
+	}
+	if res.Failed() {
+		result.Status = hexutil.Uint64(ethtypes.ReceiptStatusFailed)
+		result.Error = &types.SimCallError{Message: res.VmError, Code: types.SimErrCodeVMError}
+		if res.VmError == vm.ErrExecutionReverted.Error() {
+			result.Error.Code = types.SimErrCodeReverted
+			result.Error.Message = types.NewExecErrorWithReason(res.Revert()).Error()
+			result.Error.Data = hexutil.Encode(res.Ret)
+		}
+	}
+
+	return result, args.ToTransaction().AsTransaction(), nil
+}
+
+// simTracer collects the information of a simulated call that is not part of
+// the response of ApplyMessageWithConfig: the gas used by the EVM execution and
+// the native value transfers, recorded as synthetic ERC-20 transfer logs.
+type simTracer struct {
+	callGasTracer
+
+	traceTransfers bool
+
+	// frames holds the transfers of every open call frame, so that they can be
+	// discarded when the frame reverts
+	frames    [][]simTransfer
+	transfers []simTransfer
+}
+
+// simTransfer is a transfer log, along with the number of logs emitted before
+// it, so that it can be placed among the logs of the call.
+type simTransfer struct {
+	logIndex int
+	log      *ethtypes.Log
+}
+
+func newSimTracer(traceTransfers bool) *simTracer {
+	return &simTracer{traceTransfers: traceTransfers}
+}
+
+func (t *simTracer) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart: t.onTxStart,
+		OnEnter:   t.onEnter,
+		OnExit:    t.onExit,
+	}
+}
+
+func (t *simTracer) onEnter(_ int, typ byte, from, to common.Address, _ []byte, _ uint64, value *big.Int) {
+	t.frames = append(t.frames, nil)
+	if !t.traceTransfers || vm.OpCode(typ) == vm.DELEGATECALL || value == nil || value.Sign() <= 0 {
+		return
+	}
+
+	logIndex := 0
+	if stateDB, ok := t.stateDB.(interface{ Logs() []*ethtypes.Log }); ok {
+		logIndex = len(stateDB.Logs())
+	}
+
+	last := len(t.frames) - 1
+	t.frames[last] = append(t.frames[last], simTransfer{
+		logIndex: logIndex,
+		log: &ethtypes.Log{
+			Address: types.SimTransferAddress,
+			Topics: []common.Hash{
+				types.SimTransferTopic,
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+			},
+			Data: common.BigToHash(value).Bytes(),
+		},
+	})
+}
+
+func (t *simTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	t.callGasTracer.onExit(depth, output, gasUsed, err, reverted)
+
+	last := len(t.frames) - 1
+	frame := t.frames[last]
+	t.frames = t.frames[:last]
+
+	if depth == 0 {
+		if !reverted {
+			t.transfers = frame
+		}
+		return
+	}
+
+	if !reverted {
+		t.frames[last-1] = append(t.frames[last-1], frame...)
+	}
+}
+
+// logs returns the given logs of the call with the recorded transfers
+// inserted at the position they happened.
+func (t *simTracer) logs(logs []*ethtypes.Log) []*ethtypes.Log {
+	if len(t.transfers) == 0 {
+		return logs
+	}
+
+	merged := make([]*ethtypes.Log, 0, len(logs)+len(t.transfers))
+	i := 0
+	for _, transfer := range t.transfers {
+		for ; i < transfer.logIndex && i < len(logs); i++ {
+			merged = append(merged, logs[i])
+		}
+		merged = append(merged, transfer.log)
+	}
+	return append(merged, logs[i:]...)
+}
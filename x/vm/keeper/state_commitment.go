@@ -0,0 +1,300 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// stateChanges records the EVM accounts and storage slots written by replayed
+// transactions, with their values before the transactions, along with the net
+// bank balance changes of the transactions. They are used to commit to the
+// state modified by each transaction, as there is no state root per
+// transaction in Cosmos.
+type stateChanges struct {
+	accounts map[common.Address]*accountChanges
+	// balances are the net balance changes per account address and denom
+	balances map[string]map[string]sdkmath.Int
+}
+
+// accountChanges holds the values of an EVM account and of its written
+// storage slots before the changes.
+type accountChanges struct {
+	account statedb.Account
+	storage map[common.Hash]common.Hash
+}
+
+// modifiedAccount is an EVM account modified by the changes, along with its
+// modified storage slots.
+type modifiedAccount struct {
+	address common.Address
+	account statedb.Account
+	slots   []common.Hash
+}
+
+func newStateChanges() *stateChanges {
+	return &stateChanges{
+		accounts: make(map[common.Address]*accountChanges),
+		balances: make(map[string]map[string]sdkmath.Int),
+	}
+}
+
+// record records the current values of the accounts and storage slots
+// modified by the journal of the StateDB. It must be called before the StateDB
+// is committed. The whole storage of the self-destructed accounts is recorded,
+// as it's deleted on commit.
+func (c *stateChanges) record(k *Keeper, ctx sdk.Context, stateDB *statedb.StateDB) {
+	ctx = recordingContext(ctx)
+	for addr, keys := range stateDB.DirtyStorage() {
+		account := c.account(k, ctx, addr)
+		if stateDB.HasSelfDestructed(addr) {
+			k.ForEachStorage(ctx, addr, func(key, _ common.Hash) bool {
+				keys = append(keys, key)
+				return true
+			})
+		}
+		for _, key := range keys {
+			if _, found := account.storage[key]; !found {
+				account.storage[key] = k.GetState(ctx, addr, key)
+			}
+		}
+	}
+}
+
+// account returns the recorded account, recording its current values first
+// if it's the first change of the account.
+func (c *stateChanges) account(k *Keeper, ctx sdk.Context, addr common.Address) *accountChanges {
+	account, found := c.accounts[addr]
+	if !found {
+		account = &accountChanges{
+			account: k.GetAccountOrEmpty(ctx, addr),
+			storage: make(map[common.Hash]common.Hash),
+		}
+		c.accounts[addr] = account
+	}
+	return account
+}
+
+// addBankEvents adds the balance changes of the coin spent and coin received
+// events to the net balance changes.
+func (c *stateChanges) addBankEvents(events sdk.Events) {
+	for _, event := range events {
+		var addrKey string
+		sign := 1
+		switch event.Type {
+		case banktypes.EventTypeCoinSpent:
+			addrKey, sign = banktypes.AttributeKeySpender, -1
+		case banktypes.EventTypeCoinReceived:
+			addrKey = banktypes.AttributeKeyReceiver
+		default:
+			continue
+		}
+
+		var (
+			addr  sdk.AccAddress
+			coins sdk.Coins
+			err   error
+		)
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case addrKey:
+				addr, err = sdk.AccAddressFromBech32(attr.Value)
+			case sdk.AttributeKeyAmount:
+				coins, err = sdk.ParseCoinsNormalized(attr.Value)
+			}
+			if err != nil {
+				break
+			}
+		}
+		if err != nil || addr.Empty() {
+			continue
+		}
+
+		balances, found := c.balances[string(addr)]
+		if !found {
+			balances = make(map[string]sdkmath.Int)
+			c.balances[string(addr)] = balances
+		}
+		for _, coin := range coins {
+			amount := coin.Amount
+			if sign < 0 {
+				amount = amount.Neg()
+			}
+			if prev, found := balances[coin.Denom]; found {
+				amount = prev.Add(amount)
+			}
+			balances[coin.Denom] = amount
+		}
+	}
+}
+
+// merge merges the changes of a following transaction into the changes,
+// keeping the values recorded first.
+func (c *stateChanges) merge(other *stateChanges) {
+	for addr, changes := range other.accounts {
+		account, found := c.accounts[addr]
+		if !found {
+			c.accounts[addr] = changes
+			continue
+		}
+		for key, value := range changes.storage {
+			if _, found := account.storage[key]; !found {
+				account.storage[key] = value
+			}
+		}
+	}
+
+	for addr, changes := range other.balances {
+		balances, found := c.balances[addr]
+		if !found {
+			balances = make(map[string]sdkmath.Int)
+			c.balances[addr] = balances
+		}
+		for denom, amount := range changes {
+			if prev, found := balances[denom]; found {
+				amount = prev.Add(amount)
+			}
+			balances[denom] = amount
+		}
+	}
+}
+
+// modifiedAccounts returns the EVM accounts whose nonce, balance, code hash or
+// storage differ from the recorded values, sorted by address.
+func (c *stateChanges) modifiedAccounts(k *Keeper, ctx sdk.Context) []modifiedAccount {
+	ctx = recordingContext(ctx)
+
+	addrs := make([]common.Address, 0, len(c.accounts))
+	for addr := range c.accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0 })
+
+	modified := make([]modifiedAccount, 0, len(addrs))
+	for _, addr := range addrs {
+		prev := c.accounts[addr]
+		account := k.GetAccountOrEmpty(ctx, addr)
+
+		var slots []common.Hash
+		for key, value := range prev.storage {
+			if k.GetState(ctx, addr, key) != value {
+				slots = append(slots, key)
+			}
+		}
+		sort.Slice(slots, func(i, j int) bool { return bytes.Compare(slots[i].Bytes(), slots[j].Bytes()) < 0 })
+
+		if account.Nonce == prev.account.Nonce &&
+			account.Balance.Eq(prev.account.Balance) &&
+			bytes.Equal(account.CodeHash, prev.account.CodeHash) &&
+			len(slots) == 0 {
+			continue
+		}
+		modified = append(modified, modifiedAccount{address: addr, account: account, slots: slots})
+	}
+	return modified
+}
+
+// modifiedBalances returns the denoms of the non-zero net balance changes per
+// account address, with the addresses and denoms sorted.
+func (c *stateChanges) modifiedBalances() ([]sdk.AccAddress, map[string][]string) {
+	var addrs []sdk.AccAddress
+	denoms := make(map[string][]string)
+	for addr, balances := range c.balances {
+		for denom, amount := range balances {
+			if !amount.IsZero() {
+				denoms[addr] = append(denoms[addr], denom)
+			}
+		}
+		if len(denoms[addr]) > 0 {
+			addrs = append(addrs, sdk.AccAddress(addr))
+			sort.Strings(denoms[addr])
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i], addrs[j]) < 0 })
+	return addrs, denoms
+}
+
+// commit returns the commitment to the state modified by the changes, chained
+// to the parent commitment so that a divergence persists in the following
+// commitments, along with the addresses of the modified accounts.
+//
+// The commitment hashes, in order, the nonce, balance, code hash and modified
+// storage slots of the modified EVM accounts, and the balances of the bank
+// accounts with a net balance change in the modified denoms.
+func (c *stateChanges) commit(k *Keeper, ctx sdk.Context, parent common.Hash) (common.Hash, []common.Address) {
+	hasher := crypto.NewKeccakState()
+	write := func(bz []byte) {
+		_, _ = hasher.Write(bz)
+	}
+	writeUint := func(n uint64) {
+		write(binary.BigEndian.AppendUint64(nil, n))
+	}
+	writeBytes := func(bz []byte) {
+		writeUint(uint64(len(bz)))
+		write(bz)
+	}
+
+	write(parent.Bytes())
+
+	modified := c.modifiedAccounts(k, ctx)
+	addrs := make(map[common.Address]struct{}, len(modified))
+	writeUint(uint64(len(modified)))
+	for _, account := range modified {
+		addrs[account.address] = struct{}{}
+		write(account.address.Bytes())
+		writeUint(account.account.Nonce)
+		write(account.account.Balance.PaddedBytes(32))
+		write(common.BytesToHash(account.account.CodeHash).Bytes())
+		writeUint(uint64(len(account.slots)))
+		for _, key := range account.slots {
+			write(key.Bytes())
+			write(k.GetState(recordingContext(ctx), account.address, key).Bytes())
+		}
+	}
+
+	bankAddrs, denoms := c.modifiedBalances()
+	writeUint(uint64(len(bankAddrs)))
+	for _, addr := range bankAddrs {
+		writeBytes(addr)
+		writeUint(uint64(len(denoms[string(addr)])))
+		for _, denom := range denoms[string(addr)] {
+			balance := k.bankWrapper.GetBalance(recordingContext(ctx), addr, denom)
+			writeBytes([]byte(denom))
+			writeBytes([]byte(balance.Amount.String()))
+		}
+		if len(addr) == common.AddressLength {
+			addrs[common.BytesToAddress(addr)] = struct{}{}
+		}
+	}
+
+	var root common.Hash
+	_, _ = hasher.Read(root[:])
+	return root, sortedAddresses(addrs)
+}
+
+// sortedAddresses returns the addresses of the set, sorted.
+func sortedAddresses(set map[common.Address]struct{}) []common.Address {
+	addrs := make([]common.Address, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0 })
+	return addrs
+}
+
+// recordingContext returns the context of the reads recording the state
+// changes, which must not consume the gas of the replayed transactions.
+func recordingContext(ctx sdk.Context) sdk.Context {
+	return ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+}
@@ -54,6 +54,10 @@ func (k *Keeper) NewEVM(
 		BaseFee:     cfg.BaseFee,
 		Random:      &common.MaxHash, // need to be different than nil to signal it is after the merge and pick up the right opcodes
 	}
+	cfg.BlockOverrides.Apply(&blockCtx)
+	if cfg.GetHash != nil {
+		blockCtx.GetHash = cfg.GetHash
+	}
 
 	ethCfg := types.GetEthChainConfig()
 	txCtx := core.NewEVMTxContext(&msg)
@@ -192,8 +196,8 @@ func (k *Keeper) ApplyTransaction(ctx sdk.Context, tx *ethtypes.Transaction) (*t
 	// thus restricted to be used only inside `ApplyMessage`.
 	tmpCtx, commitFn := ctx.CacheContext()
 
-	// pass true to commit the StateDB
-	res, err := k.ApplyMessageWithConfig(tmpCtx, *msg, nil, true, cfg, txConfig, false)
+	// pass true to commit the StateDB, the live tracer is used if the node runs one
+	res, err := k.ApplyMessageWithConfig(tmpCtx, *msg, k.liveTracing(ctx), true, cfg, txConfig, false)
 	if err != nil {
 		// when a transaction contains multiple msg, as long as one of the msg fails
 		// all gas will be deducted. so is not msg.Gas()
@@ -273,6 +277,11 @@ func (k *Keeper) ApplyTransaction(ctx sdk.Context, tx *ethtypes.Transaction) (*t
 		k.SetLogSizeTransient(ctx, uint64(txConfig.LogIndex)+uint64(len(ethLogs)))
 	}
 
+	// store the transaction and its receipt to derive the block roots
+	if err = k.SetTransientReceipt(ctx, uint64(txConfig.TxIndex), tx, res); err != nil {
+		return nil, errorsmod.Wrap(err, "failed to store transient receipt")
+	}
+
 	k.SetTxIndexTransient(ctx, uint64(txConfig.TxIndex)+1)
 
 	totalGasUsed, err := k.AddTransientGasUsed(ctx, res.GasUsed)
@@ -335,24 +344,31 @@ func (k *Keeper) ApplyMessage(ctx sdk.Context, msg core.Message, tracer *tracing
 //
 // If commit is true, the `StateDB` will be committed, otherwise discarded.
 func (k *Keeper) ApplyMessageWithConfig(ctx sdk.Context, msg core.Message, tracer *tracing.Hooks, commit bool, cfg *statedb.EVMConfig, txConfig statedb.TxConfig, internal bool) (*types.MsgEthereumTxResponse, error) {
+	return k.applyMessageWithStateDB(ctx, statedb.New(ctx, k, txConfig), msg, tracer, commit, cfg, txConfig, internal)
+}
+
+// applyMessageWithStateDB applies the message like ApplyMessageWithConfig, on
+// the given StateDB created with the context and the TxConfig.
+func (k *Keeper) applyMessageWithStateDB(ctx sdk.Context, stateDB *statedb.StateDB, msg core.Message, tracer *tracing.Hooks, commit bool, cfg *statedb.EVMConfig, txConfig statedb.TxConfig, internal bool) (*types.MsgEthereumTxResponse, error) {
 	var (
 		ret   []byte // return bytes from evm execution
 		vmErr error  // vm errors do not effect consensus and are therefore not assigned to err
 	)
 
-	stateDB := statedb.New(ctx, k, txConfig)
 	evm := k.NewEVM(ctx, msg, cfg, tracer, stateDB)
 
 	leftoverGas := msg.GasLimit
 
 	// Allow the tracer captures the tx level events, mainly the gas consumption.
 	vmCfg := evm.Config
-	if vmCfg.Tracer != nil {
+	if vmCfg.Tracer != nil && vmCfg.Tracer.OnTxStart != nil {
 		vmCfg.Tracer.OnTxStart(
 			evm.GetVMContext(),
 			ethtypes.NewTx(&ethtypes.LegacyTx{To: msg.To, Data: msg.Data, Value: msg.Value, Gas: msg.GasLimit}),
 			msg.From,
 		)
+	}
+	if vmCfg.Tracer != nil && vmCfg.Tracer.OnTxEnd != nil {
 		defer func() {
 			vmCfg.Tracer.OnTxEnd(&ethtypes.Receipt{GasUsed: msg.GasLimit - leftoverGas}, vmErr)
 		}()
@@ -432,6 +448,9 @@ func (k *Keeper) ApplyMessageWithConfig(ctx sdk.Context, msg core.Message, trace
 		if err := stateDB.Commit(); err != nil {
 			return nil, errorsmod.Wrap(err, "failed to commit stateDB")
 		}
+		if cfg.EnablePreimageRecording {
+			k.storePreimages(ctx, stateDB.Preimages())
+		}
 	}
 
 	// calculate a minimum amount of gas to be charged to sender if GasLimit
@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetTraceBlockConcurrency sets the number of transactions traced concurrently
+// by the block traces. Values lower than two trace the transactions in order.
+func (k *Keeper) SetTraceBlockConcurrency(concurrency int) *Keeper {
+	k.traceBlockConcurrency = concurrency
+	return k
+}
+
+// txPreState is the state of the block before one of its transactions, along
+// with the transaction config to apply it with.
+type txPreState struct {
+	ctx      sdk.Context
+	tx       *ethtypes.Transaction
+	txConfig statedb.TxConfig
+}
+
+// traceBlockParallel traces the transactions of the queried block on a bounded
+// pool of workers, calling fn with the trace result of each transaction in
+// order.
+//
+// The block is first replayed once without tracing, each transaction being
+// applied on a branch of the state left by the previous one. The branch a
+// transaction is applied on thus holds its pre-state, and is never written to
+// again, so the transactions can then be traced concurrently on throwaway
+// branches of their pre-state.
+func (k Keeper) traceBlockParallel(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	signer ethtypes.Signer,
+	txConfig statedb.TxConfig,
+	req *types.QueryTraceBlockRequest,
+	fn func(txIndex int, txHash common.Hash, result *types.TxTraceResult) error,
+) error {
+	preStates := make([]txPreState, len(req.Txs))
+	for i, tx := range req.Txs {
+		ethTx := tx.AsTransaction()
+		txConfig.TxHash = ethTx.Hash()
+		txConfig.TxIndex = uint(i) //nolint:gosec // G115 // won't exceed uint64
+		preStates[i] = txPreState{ctx: ctx, tx: ethTx, txConfig: txConfig}
+		if i == len(req.Txs)-1 {
+			// no transaction depends on the state left by the last one
+			break
+		}
+
+		ctx, _ = ctx.CacheContext()
+		// like the traces, the failed transactions are skipped
+		if logIndex, err := k.replayTx(ctx, cfg, txConfig, signer, ethTx, nil); err == nil {
+			txConfig.LogIndex = logIndex
+		}
+	}
+
+	var (
+		results = make([]types.TxTraceResult, len(preStates))
+		done    = make([]chan struct{}, len(preStates))
+		jobs    = make(chan int, len(preStates))
+		stop    = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	for i := range preStates {
+		done[i] = make(chan struct{})
+		jobs <- i
+	}
+	close(jobs)
+
+	// stop the workers and wait for the running traces before returning, as
+	// they read from the state of the query
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	for range min(k.traceBlockConcurrency, len(preStates)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				pre := preStates[i]
+				traceCtx, _ := pre.ctx.CacheContext()
+				traceResult, _, err := k.traceTx(traceCtx, cfg, pre.txConfig, signer, pre.tx, req.TraceConfig, false)
+				if err != nil {
+					results[i].Error = err.Error()
+				} else {
+					results[i].Result = traceResult
+				}
+				close(done[i])
+			}
+		}()
+	}
+
+	for i := range preStates {
+		<-done[i]
+		if err := fn(i, preStates[i].txConfig.TxHash, &results[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
 
 	"github.com/cosmos/evm/x/vm/types"
 )
@@ -44,4 +45,10 @@ type EVMConfig struct {
 	CoinBase                common.Address
 	BaseFee                 *big.Int
 	EnablePreimageRecording bool
+	// BlockOverrides, if set, overrides the header fields of the block context
+	// the message is executed in. Only used by queries such as `eth_call`.
+	BlockOverrides *types.BlockOverrides
+	// GetHash, if set, replaces the block hash lookup of the block context, so
+	// that the blocks simulated by `eth_simulateV1` are known to BLOCKHASH.
+	GetHash vm.GetHashFunc
 }
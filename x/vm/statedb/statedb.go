@@ -36,7 +36,10 @@ type revision struct {
 	journalIndex int
 }
 
-var _ vm.StateDB = &StateDB{}
+var (
+	_ vm.StateDB            = &StateDB{}
+	_ types.OverrideStateDB = &StateDB{}
+)
 
 // StateDB structs within the ethereum protocol are used to store anything
 // within the merkle trie. StateDBs take care of caching and storing
@@ -77,8 +80,19 @@ type StateDB struct {
 	// Per-transaction access list
 	accessList *accessList
 
+	// SHA3 preimages seen by the VM, only recorded if the
+	// EnablePreimageRecording flag is set on the vm.Config
+	preimages map[common.Hash][]byte
+
 	// The count of calls to precompiles
 	precompileCallsCounter uint8
+
+	// tracer is the tracer of the EVM the state is used in. It is set by the
+	// precompile calls to report the changes they make in the Cosmos SDK context.
+	tracer *tracing.Hooks
+	// Cosmos SDK messages executed and events emitted by the precompile calls,
+	// only recorded when tracing.
+	precompileTraces []*PrecompileTrace
 }
 
 func (s *StateDB) CreateContract(address common.Address) {
@@ -143,6 +157,7 @@ func New(ctx sdk.Context, keeper Keeper, txConfig TxConfig) *StateDB {
 		journal:          newJournal(),
 		accessList:       newAccessList(),
 		transientStorage: newTransientStorage(),
+		preimages:        make(map[common.Hash][]byte),
 		txConfig:         txConfig,
 	}
 }
@@ -157,6 +172,11 @@ func (s *StateDB) GetContext() sdk.Context {
 	return s.ctx
 }
 
+// TxConfig returns the config of the transaction being executed.
+func (s *StateDB) TxConfig() TxConfig {
+	return s.txConfig
+}
+
 // GetCacheContext returns the stateDB CacheContext.
 func (s *StateDB) GetCacheContext() (sdk.Context, error) {
 	if s.writeCache == nil {
@@ -321,11 +341,33 @@ func (s *StateDB) GetRefund() uint64 {
 	return s.refund
 }
 
-// AddPreimage records a SHA3 preimage seen by the VM.
-// AddPreimage performs a no-op since the EnablePreimageRecording flag is disabled
-// on the vm.Config during state transitions. No store trie preimages are written
-// to the database.
-func (s *StateDB) AddPreimage(_ common.Hash, _ []byte) {}
+// AddPreimage records a SHA3 preimage seen by the VM. It is only called if the
+// EnablePreimageRecording flag is set on the vm.Config. As in go-ethereum, the
+// preimages aren't journaled: they remain valid even if the call is reverted.
+func (s *StateDB) AddPreimage(hash common.Hash, preimage []byte) {
+	if _, ok := s.preimages[hash]; !ok {
+		s.preimages[hash] = slices.Clone(preimage)
+	}
+}
+
+// Preimages returns the SHA3 preimages recorded by the VM.
+func (s *StateDB) Preimages() map[common.Hash][]byte {
+	return s.preimages
+}
+
+// DirtyStorage returns the accounts modified by the journal, along with their
+// modified storage keys, which are written on commit.
+func (s *StateDB) DirtyStorage() map[common.Address][]common.Hash {
+	dirties := make(map[common.Address][]common.Hash, len(s.journal.dirties))
+	for addr := range s.journal.dirties {
+		var keys []common.Hash
+		if obj := s.stateObjects[addr]; obj != nil {
+			keys = obj.dirtyStorage.SortedKeys()
+		}
+		dirties[addr] = keys
+	}
+	return dirties
+}
 
 // getStateObject retrieves a state object given by the address, returning nil if
 // the object is not found.
@@ -452,6 +494,15 @@ func (s *StateDB) SubBalance(addr common.Address, amount *uint256.Int, reason tr
 	return stateObject.SubBalance(amount)
 }
 
+// SetBalance sets the balance of account, regardless of its current value.
+// It is used to apply state overrides on simulated calls.
+func (s *StateDB) SetBalance(addr common.Address, amount *uint256.Int) {
+	stateObject := s.getOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetBalance(amount)
+	}
+}
+
 // SetNonce sets the nonce of account.
 func (s *StateDB) SetNonce(addr common.Address, nonce uint64, reason tracing.NonceChangeReason) {
 	stateObject := s.getOrNewStateObject(addr)
@@ -479,6 +530,34 @@ func (s *StateDB) SetState(addr common.Address, key, value common.Hash) common.H
 	return common.Hash{}
 }
 
+// SetStorage replaces the entire storage of the account with the given one.
+// Every existing slot that is not part of the new storage is cleared.
+// It is used to apply state overrides on simulated calls.
+func (s *StateDB) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	stateObject := s.getOrNewStateObject(addr)
+	if stateObject == nil {
+		return
+	}
+
+	var keys []common.Hash
+	s.keeper.ForEachStorage(s.ctx, addr, func(key, _ common.Hash) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for key := range stateObject.dirtyStorage {
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		if _, ok := storage[key]; !ok {
+			stateObject.SetState(key, common.Hash{})
+		}
+	}
+	for key, value := range storage {
+		stateObject.SetState(key, value)
+	}
+}
+
 // SelfDestruct marks the given account as self-destructed.
 // This clears the account balance.
 //
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types"
 	"github.com/cosmos/evm/x/vm/types/mocks"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -219,6 +221,91 @@ func (suite *StateDBTestSuite) TestAccountOverride() {
 	suite.Require().Equal(uint64(0), db.GetNonce(address))
 }
 
+func (suite *StateDBTestSuite) TestStateOverride() {
+	key1 := common.BigToHash(big.NewInt(1))
+	value1 := common.BigToHash(big.NewInt(2))
+	key2 := common.BigToHash(big.NewInt(3))
+	value2 := common.BigToHash(big.NewInt(4))
+	nonce := hexutil.Uint64(5)
+	code := hexutil.Bytes("hello world")
+	balance := (*hexutil.Big)(big.NewInt(1000))
+
+	testCases := []struct {
+		name      string
+		overrides types.StateOverride
+		expPass   bool
+		expStates statedb.Storage
+	}{
+		{
+			"pass - override nonce, code and balance",
+			types.StateOverride{
+				address: {Nonce: &nonce, Code: &code, Balance: &balance},
+			},
+			true,
+			statedb.Storage{key1: value1},
+		},
+		{
+			"pass - state diff keeps existing storage",
+			types.StateOverride{
+				address: {StateDiff: &map[common.Hash]common.Hash{key2: value2}},
+			},
+			true,
+			statedb.Storage{key1: value1, key2: value2},
+		},
+		{
+			"pass - state replaces existing storage",
+			types.StateOverride{
+				address: {State: &map[common.Hash]common.Hash{key2: value2}},
+			},
+			true,
+			// cleared slots are committed with an empty value
+			statedb.Storage{key1: common.Hash{}, key2: value2},
+		},
+		{
+			"fail - state and state diff set at the same time",
+			types.StateOverride{
+				address: {
+					State:     &map[common.Hash]common.Hash{key2: value2},
+					StateDiff: &map[common.Hash]common.Hash{key2: value2},
+				},
+			},
+			false,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			keeper := mocks.NewEVMKeeper()
+			db := statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			db.SetState(address, key1, value1)
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			err := tc.overrides.Apply(db)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			override := tc.overrides[address]
+			if override.Nonce != nil {
+				suite.Require().Equal(uint64(nonce), db.GetNonce(address))
+			}
+			if override.Code != nil {
+				suite.Require().Equal([]byte(code), db.GetCode(address))
+			}
+			if override.Balance != nil {
+				suite.Require().Equal(uint256.MustFromBig(balance.ToInt()), db.GetBalance(address))
+			}
+			suite.Require().Equal(tc.expStates, CollectContractStorage(db))
+		})
+	}
+}
+
 func (suite *StateDBTestSuite) TestDBError() {
 	testCases := []struct {
 		name     string
@@ -655,6 +742,26 @@ func (suite *StateDBTestSuite) TestRefund() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestPreimages() {
+	db := statedb.New(sdk.Context{}, mocks.NewEVMKeeper(), emptyTxConfig)
+	suite.Require().Empty(db.Preimages())
+
+	preimage := []byte("preimage")
+	hash := crypto.Keccak256Hash(preimage)
+	db.AddPreimage(hash, preimage)
+
+	// the preimage is copied
+	preimage[0] = 'P'
+	suite.Require().Equal(map[common.Hash][]byte{hash: []byte("preimage")}, db.Preimages())
+
+	// the preimages aren't reverted
+	revision := db.Snapshot()
+	other := []byte("other")
+	db.AddPreimage(crypto.Keccak256Hash(other), other)
+	db.RevertToSnapshot(revision)
+	suite.Require().Len(db.Preimages(), 2)
+}
+
 func (suite *StateDBTestSuite) TestIterateStorage() {
 	ctx := sdk.Context{}
 
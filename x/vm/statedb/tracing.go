@@ -0,0 +1,70 @@
+package statedb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PrecompileTrace holds the Cosmos SDK messages executed and events emitted
+// by a precompile call. These are not visible to the EVM tracing hooks.
+type PrecompileTrace struct {
+	// Address is the address of the called precompile
+	Address common.Address
+	// MsgTypeURLs are the type URLs of the messages executed by the call
+	MsgTypeURLs []string
+
+	stateDB     *StateDB
+	eventsStart int
+}
+
+// Events returns the events emitted in the cache context since the start of
+// the precompile call.
+func (t *PrecompileTrace) Events() sdk.Events {
+	events := t.stateDB.cacheCtx.EventManager().Events()
+	if t.eventsStart > len(events) {
+		return nil
+	}
+	return events[t.eventsStart:]
+}
+
+// SetTracer sets the tracer of the EVM the StateDB is used in.
+func (s *StateDB) SetTracer(tracer *tracing.Hooks) {
+	s.tracer = tracer
+}
+
+// Tracer returns the tracer of the EVM the StateDB is used in, if any.
+func (s *StateDB) Tracer() *tracing.Hooks {
+	return s.tracer
+}
+
+// StartPrecompileTrace starts recording the messages and events of a call to
+// the given precompile. It must be called once the cache context is set up.
+// It is a no-op when the transaction is not traced.
+func (s *StateDB) StartPrecompileTrace(addr common.Address) {
+	if s.tracer == nil || s.writeCache == nil {
+		return
+	}
+	s.precompileTraces = append(s.precompileTraces, &PrecompileTrace{
+		Address:     addr,
+		stateDB:     s,
+		eventsStart: len(s.cacheCtx.EventManager().Events()),
+	})
+}
+
+// TraceMsg records the message executed by the current precompile call. It is
+// a no-op when the transaction is not traced.
+func (s *StateDB) TraceMsg(msg sdk.Msg) {
+	if len(s.precompileTraces) == 0 {
+		return
+	}
+	trace := s.precompileTraces[len(s.precompileTraces)-1]
+	trace.MsgTypeURLs = append(trace.MsgTypeURLs, sdk.MsgTypeURL(msg))
+}
+
+// PrecompileTraces returns the traces of the precompile calls of the
+// transaction, in call order.
+func (s *StateDB) PrecompileTraces() []*PrecompileTrace {
+	return s.precompileTraces
+}
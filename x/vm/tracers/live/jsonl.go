@@ -0,0 +1,285 @@
+package live
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+
+	"cosmossdk.io/log"
+)
+
+const (
+	// JSONLTracer is the name of the live tracer writing the traces of the
+	// executed transactions to a JSONL file.
+	JSONLTracer = "jsonl"
+
+	defaultTracer   = "callTracer"
+	defaultMaxSize  = 100 // megabytes
+	defaultMaxFiles = 10
+)
+
+// logger logs the errors of the live tracers, which aren't propagated to the
+// execution of the blocks.
+var logger = log.NewNopLogger()
+
+func init() {
+	tracers.LiveDirectory.Register(JSONLTracer, newJSONLTracer)
+}
+
+// SetLogger sets the logger of the errors of the live tracers created next.
+func SetLogger(l log.Logger) {
+	logger = l
+}
+
+// jsonlTracerConfig is the json configuration of the jsonl live tracer.
+type jsonlTracerConfig struct {
+	// Path is the path of the file the traces are written to
+	Path string `json:"path"`
+	// MaxSize is the size in megabytes after which the file is rotated
+	MaxSize int64 `json:"maxSize"`
+	// MaxFiles is the number of rotated files kept
+	MaxFiles int `json:"maxFiles"`
+	// Tracer is the native tracer run on each transaction, the callTracer by
+	// default
+	Tracer string `json:"tracer"`
+	// TracerConfig is the json configuration of the tracer
+	TracerConfig json.RawMessage `json:"tracerConfig"`
+}
+
+// txTrace is a line of the JSONL file, holding the trace of a transaction.
+type txTrace struct {
+	BlockNumber uint64          `json:"blockNumber"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	TxIndex     uint            `json:"txIndex"`
+	TxHash      common.Hash     `json:"txHash"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// txConfigReader is implemented by the StateDB, which holds the hash and index
+// of the transaction being executed.
+type txConfigReader interface {
+	TxConfig() statedb.TxConfig
+}
+
+// jsonlTracer runs the configured tracer on each transaction of the executed
+// blocks. The traces of a block are written to the file once the block ends,
+// so that a block is never split across rotated files.
+type jsonlTracer struct {
+	config      jsonlTracerConfig
+	chainConfig *params.ChainConfig
+	file        *rotatingFile
+	logger      log.Logger
+
+	block  bytes.Buffer
+	tracer *tracers.Tracer
+	trace  *txTrace
+}
+
+// newJSONLTracer returns a live tracer writing the traces of the executed
+// transactions to a rotating JSONL file.
+func newJSONLTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	config := jsonlTracerConfig{
+		MaxSize:  defaultMaxSize,
+		MaxFiles: defaultMaxFiles,
+		Tracer:   defaultTracer,
+	}
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("invalid jsonl tracer config: %w", err)
+	}
+	if config.Path == "" {
+		return nil, errors.New("jsonl tracer path is required")
+	}
+	if config.MaxSize <= 0 || config.MaxFiles < 0 {
+		return nil, fmt.Errorf("invalid jsonl tracer rotation: max size %d, max files %d", config.MaxSize, config.MaxFiles)
+	}
+	// the tracer runs inside the block execution with no time budget, so the
+	// JS tracers, which can loop forever, aren't allowed
+	if tracers.DefaultDirectory.IsJS(config.Tracer) {
+		return nil, fmt.Errorf("jsonl tracer %s is not a native tracer", config.Tracer)
+	}
+
+	file, err := openRotatingFile(config.Path, config.MaxSize*1024*1024, config.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &jsonlTracer{config: config, file: file, logger: logger.With("tracer", JSONLTracer)}
+	return &tracing.Hooks{
+		OnBlockchainInit: t.OnBlockchainInit,
+		OnBlockStart:     t.OnBlockStart,
+		OnBlockEnd:       t.OnBlockEnd,
+		OnClose:          t.OnClose,
+		OnTxStart:        t.OnTxStart,
+		OnTxEnd:          t.OnTxEnd,
+		OnEnter:          t.OnEnter,
+		OnExit:           t.OnExit,
+		OnOpcode:         t.OnOpcode,
+		OnFault:          t.OnFault,
+		OnGasChange:      t.OnGasChange,
+		OnBalanceChange:  t.OnBalanceChange,
+		OnNonceChange:    t.OnNonceChange,
+		OnCodeChange:     t.OnCodeChange,
+		OnStorageChange:  t.OnStorageChange,
+		OnLog:            t.OnLog,
+	}, nil
+}
+
+// OnBlockchainInit keeps the chain configuration used to create the tracers.
+func (t *jsonlTracer) OnBlockchainInit(chainConfig *params.ChainConfig) {
+	t.chainConfig = chainConfig
+}
+
+// OnBlockStart discards the traces of a previous block which didn't end.
+func (t *jsonlTracer) OnBlockStart(_ tracing.BlockEvent) {
+	t.block.Reset()
+	t.tracer, t.trace = nil, nil
+}
+
+// OnBlockEnd writes the traces of the block to the file.
+func (t *jsonlTracer) OnBlockEnd(err error) {
+	defer t.block.Reset()
+	if err != nil || t.block.Len() == 0 {
+		return
+	}
+	// errors are not propagated to the block execution, the traces of the
+	// block are dropped instead
+	if err := t.file.Write(t.block.Bytes()); err != nil {
+		t.logger.Error("failed to write the block traces", "path", t.config.Path, "error", err)
+	}
+}
+
+// OnClose closes the file.
+func (t *jsonlTracer) OnClose() {
+	if err := t.file.Close(); err != nil {
+		t.logger.Error("failed to close the traces file", "path", t.config.Path, "error", err)
+	}
+}
+
+// OnTxStart creates the tracer of the transaction.
+func (t *jsonlTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.trace = &txTrace{}
+	if env.BlockNumber != nil {
+		t.trace.BlockNumber = env.BlockNumber.Uint64()
+	}
+	if stateDB, ok := env.StateDB.(txConfigReader); ok {
+		txConfig := stateDB.TxConfig()
+		t.trace.BlockHash, t.trace.TxIndex, t.trace.TxHash = txConfig.BlockHash, txConfig.TxIndex, txConfig.TxHash
+	}
+
+	tracer, err := tracers.DefaultDirectory.New(t.config.Tracer, &tracers.Context{
+		BlockHash:   t.trace.BlockHash,
+		BlockNumber: env.BlockNumber,
+		TxIndex:     int(t.trace.TxIndex), //#nosec G115 -- int overflow is not a concern here
+		TxHash:      t.trace.TxHash,
+	}, t.config.TracerConfig, t.chainConfig)
+	if err != nil {
+		t.trace.Error = err.Error()
+		return
+	}
+
+	t.tracer = tracer
+	if t.tracer.OnTxStart != nil {
+		t.tracer.OnTxStart(env, tx, from)
+	}
+}
+
+// OnTxEnd adds the trace of the transaction to the traces of the block.
+func (t *jsonlTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if t.trace == nil {
+		return
+	}
+	trace := t.trace
+	t.trace = nil
+
+	if tracer := t.tracer; tracer != nil {
+		t.tracer = nil
+		if tracer.OnTxEnd != nil {
+			tracer.OnTxEnd(receipt, err)
+		}
+		result, err := tracer.GetResult()
+		if err != nil {
+			trace.Error = err.Error()
+		} else {
+			trace.Result = result
+		}
+	}
+
+	line, err := json.Marshal(trace)
+	if err != nil {
+		t.logger.Error("failed to encode the tx trace", "hash", trace.TxHash.Hex(), "error", err)
+		return
+	}
+	t.block.Write(line)
+	t.block.WriteByte('\n')
+}
+
+func (t *jsonlTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.tracer != nil && t.tracer.OnEnter != nil {
+		t.tracer.OnEnter(depth, typ, from, to, input, gas, value)
+	}
+}
+
+func (t *jsonlTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.tracer != nil && t.tracer.OnExit != nil {
+		t.tracer.OnExit(depth, output, gasUsed, err, reverted)
+	}
+}
+
+func (t *jsonlTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.tracer != nil && t.tracer.OnOpcode != nil {
+		t.tracer.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (t *jsonlTracer) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	if t.tracer != nil && t.tracer.OnFault != nil {
+		t.tracer.OnFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+func (t *jsonlTracer) OnGasChange(prev, current uint64, reason tracing.GasChangeReason) {
+	if t.tracer != nil && t.tracer.OnGasChange != nil {
+		t.tracer.OnGasChange(prev, current, reason)
+	}
+}
+
+func (t *jsonlTracer) OnBalanceChange(addr common.Address, prev, current *big.Int, reason tracing.BalanceChangeReason) {
+	if t.tracer != nil && t.tracer.OnBalanceChange != nil {
+		t.tracer.OnBalanceChange(addr, prev, current, reason)
+	}
+}
+
+func (t *jsonlTracer) OnNonceChange(addr common.Address, prev, current uint64) {
+	if t.tracer != nil && t.tracer.OnNonceChange != nil {
+		t.tracer.OnNonceChange(addr, prev, current)
+	}
+}
+
+func (t *jsonlTracer) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if t.tracer != nil && t.tracer.OnCodeChange != nil {
+		t.tracer.OnCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+	}
+}
+
+func (t *jsonlTracer) OnStorageChange(addr common.Address, slot common.Hash, prev, current common.Hash) {
+	if t.tracer != nil && t.tracer.OnStorageChange != nil {
+		t.tracer.OnStorageChange(addr, slot, prev, current)
+	}
+}
+
+func (t *jsonlTracer) OnLog(log *types.Log) {
+	if t.tracer != nil && t.tracer.OnLog != nil {
+		t.tracer.OnLog(log)
+	}
+}
@@ -0,0 +1,141 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native" // register the callTracer
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types/mocks"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdktestutil "github.com/cosmos/cosmos-sdk/testutil"
+)
+
+func TestJSONLTracer(t *testing.T) {
+	var (
+		sender    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		contract  = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		blockHash = common.HexToHash("0x01")
+		txHash    = common.HexToHash("0x02")
+	)
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	hooks, err := tracers.LiveDirectory.New(JSONLTracer, json.RawMessage(fmt.Sprintf(`{"path": %q}`, path)))
+	require.NoError(t, err)
+	hooks.OnBlockchainInit(params.TestChainConfig)
+
+	ctx := sdktestutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("test_t"))
+	stateDB := statedb.New(ctx, mocks.NewEVMKeeper(), statedb.NewTxConfig(blockHash, txHash, 1, 0))
+
+	hooks.OnBlockStart(tracing.BlockEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})})
+	tx := types.NewTx(&types.LegacyTx{Gas: 100000, To: &contract, Value: big.NewInt(0)})
+	hooks.OnTxStart(&tracing.VMContext{BlockNumber: big.NewInt(10), StateDB: stateDB}, tx, sender)
+	hooks.OnEnter(0, byte(vm.CALL), sender, contract, nil, 100000, big.NewInt(0))
+	hooks.OnExit(0, nil, 21000, nil, false)
+	hooks.OnTxEnd(&types.Receipt{GasUsed: 21000}, nil)
+
+	// the traces are only written once the block ends
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, bz)
+
+	hooks.OnBlockEnd(nil)
+	hooks.OnClose()
+
+	bz, err = os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(bz), "\n"), "\n")
+	require.Len(t, lines, 1)
+
+	var trace struct {
+		BlockNumber uint64      `json:"blockNumber"`
+		BlockHash   common.Hash `json:"blockHash"`
+		TxIndex     uint        `json:"txIndex"`
+		TxHash      common.Hash `json:"txHash"`
+		Result      struct {
+			Type string         `json:"type"`
+			From common.Address `json:"from"`
+			To   common.Address `json:"to"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &trace))
+	require.Equal(t, uint64(10), trace.BlockNumber)
+	require.Equal(t, blockHash, trace.BlockHash)
+	require.Equal(t, uint(1), trace.TxIndex)
+	require.Equal(t, txHash, trace.TxHash)
+	require.Equal(t, "CALL", trace.Result.Type)
+	require.Equal(t, sender, trace.Result.From)
+	require.Equal(t, contract, trace.Result.To)
+}
+
+func TestJSONLTracerConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		expErr bool
+	}{
+		{"fail - missing path", `{}`, true},
+		{"fail - invalid json", `{`, true},
+		{"fail - invalid max size", `{"path": "%s", "maxSize": 0}`, true},
+		{"fail - invalid max files", `{"path": "%s", "maxFiles": -1}`, true},
+		{"fail - unknown tracer", `{"path": "%s", "tracer": "unknown"}`, true},
+		{"fail - js tracer", `{"path": "%s", "tracer": "{result: function() { return 1 }, fault: function() {}}"}`, true},
+		{"pass - native tracer", `{"path": "%s", "tracer": "prestateTracer"}`, false},
+		{"pass - default config", `{"path": "%s"}`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			if strings.Contains(config, "%s") {
+				config = fmt.Sprintf(config, filepath.Join(t.TempDir(), "traces.jsonl"))
+			}
+			hooks, err := tracers.LiveDirectory.New(JSONLTracer, json.RawMessage(config))
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			hooks.OnClose()
+		})
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	file, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, data := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggggggggggg\n"} {
+		require.NoError(t, file.Write([]byte(data)))
+	}
+	require.NoError(t, file.Close())
+	require.Error(t, file.Write([]byte("hhhh\n")))
+
+	for p, expContent := range map[string]string{
+		path:        "gggggggggggg\n",
+		path + ".1": "eeee\nffff\n",
+		path + ".2": "cccc\ndddd\n",
+	} {
+		bz, err := os.ReadFile(p)
+		require.NoError(t, err)
+		require.Equal(t, expContent, string(bz))
+	}
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
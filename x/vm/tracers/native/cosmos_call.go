@@ -0,0 +1,200 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native" // register the callTracer
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("cosmosCallTracer", newCosmosCallTracer, false)
+}
+
+// precompileTraceReader is implemented by the StateDB recording the Cosmos SDK
+// messages executed and events emitted by the precompile calls.
+type precompileTraceReader interface {
+	PrecompileTraces() []*statedb.PrecompileTrace
+}
+
+// cosmosEvent is the json representation of a Cosmos SDK event.
+type cosmosEvent struct {
+	Type       string            `json:"type"`
+	Attributes []cosmosAttribute `json:"attributes"`
+}
+
+type cosmosAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// cosmosFrame holds the Cosmos SDK messages and events of a call frame.
+type cosmosFrame struct {
+	msgs        []string
+	events      []cosmosEvent
+	tracesStart int
+	hasCalls    bool
+}
+
+// cosmosCallTracer is a callTracer which attaches to each precompile call
+// frame the type URLs of the Cosmos SDK messages it executed and the events it
+// emitted, as the `cosmosMsgs` and `cosmosEvents` fields. The balance moves of
+// the EVM coin denom are also reported by the precompiles with balance-change
+// hooks, the other ones are only found in the events.
+type cosmosCallTracer struct {
+	callTracer *tracers.Tracer
+	stateDB    precompileTraceReader
+	frames     []*cosmosFrame // all the call frames, in call order
+	stack      []*cosmosFrame
+}
+
+// newCosmosCallTracer returns a native go tracer which wraps the callTracer,
+// using the same configuration.
+func newCosmosCallTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	callTracer, err := tracers.DefaultDirectory.New("callTracer", ctx, cfg, chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &cosmosCallTracer{callTracer: callTracer}
+	hooks := *callTracer.Hooks
+	hooks.OnTxStart = t.OnTxStart
+	hooks.OnEnter = t.OnEnter
+	hooks.OnExit = t.OnExit
+	return &tracers.Tracer{
+		Hooks:     &hooks,
+		GetResult: t.GetResult,
+		Stop:      callTracer.Stop,
+	}, nil
+}
+
+// OnTxStart keeps the StateDB of the transaction to read the precompile traces.
+func (t *cosmosCallTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.callTracer.OnTxStart(env, tx, from)
+	t.stateDB, _ = env.StateDB.(precompileTraceReader)
+}
+
+// OnEnter records the precompile traces count at the start of the call frame.
+func (t *cosmosCallTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.callTracer.OnEnter(depth, typ, from, to, input, gas, value)
+
+	if len(t.stack) > 0 {
+		t.stack[len(t.stack)-1].hasCalls = true
+	}
+	frame := &cosmosFrame{tracesStart: len(t.precompileTraces())}
+	t.frames = append(t.frames, frame)
+	t.stack = append(t.stack, frame)
+}
+
+// OnExit attaches the messages and events of the precompile calls made during
+// the call frame. Only the frames with no sub calls can be precompile calls,
+// and the reverted ones have no effects.
+func (t *cosmosCallTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	t.callTracer.OnExit(depth, output, gasUsed, err, reverted)
+
+	if len(t.stack) == 0 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	if err != nil || frame.hasCalls {
+		return
+	}
+
+	traces := t.precompileTraces()
+	if frame.tracesStart > len(traces) {
+		return
+	}
+	for _, trace := range traces[frame.tracesStart:] {
+		frame.msgs = append(frame.msgs, trace.MsgTypeURLs...)
+		frame.events = append(frame.events, cosmosEvents(trace.Events())...)
+	}
+}
+
+// GetResult returns the callTracer result, with the Cosmos SDK messages and
+// events added to the precompile call frames.
+func (t *cosmosCallTracer) GetResult() (json.RawMessage, error) {
+	res, err := t.callTracer.GetResult()
+	if err != nil {
+		return res, err
+	}
+
+	index := 0
+	return t.attach(res, &index)
+}
+
+// attach adds the Cosmos SDK messages and events to the json-encoded call frame
+// and its sub calls, which are in call order.
+func (t *cosmosCallTracer) attach(raw json.RawMessage, index *int) (json.RawMessage, error) {
+	var frame map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return raw, nil
+	}
+
+	fields := map[string]interface{}{}
+	if *index < len(t.frames) {
+		cosmos := t.frames[*index]
+		if len(cosmos.msgs) > 0 {
+			fields["cosmosMsgs"] = cosmos.msgs
+		}
+		if len(cosmos.events) > 0 {
+			fields["cosmosEvents"] = cosmos.events
+		}
+	}
+	*index++
+
+	if calls, ok := frame["calls"]; ok {
+		var subcalls []json.RawMessage
+		if err := json.Unmarshal(calls, &subcalls); err != nil {
+			return nil, err
+		}
+		for i := range subcalls {
+			subcall, err := t.attach(subcalls[i], index)
+			if err != nil {
+				return nil, err
+			}
+			subcalls[i] = subcall
+		}
+		fields["calls"] = subcalls
+	}
+
+	for key, value := range fields {
+		bz, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		frame[key] = bz
+	}
+	return json.Marshal(frame)
+}
+
+func (t *cosmosCallTracer) precompileTraces() []*statedb.PrecompileTrace {
+	if t.stateDB == nil {
+		return nil
+	}
+	return t.stateDB.PrecompileTraces()
+}
+
+// cosmosEvents converts the Cosmos SDK events to their json representation.
+func cosmosEvents(events sdk.Events) []cosmosEvent {
+	result := make([]cosmosEvent, len(events))
+	for i, event := range events {
+		result[i] = cosmosEvent{Type: event.Type, Attributes: make([]cosmosAttribute, len(event.Attributes))}
+		for j, attr := range event.Attributes {
+			result[i].Attributes[j] = cosmosAttribute{Key: attr.Key, Value: attr.Value}
+		}
+	}
+	return result
+}
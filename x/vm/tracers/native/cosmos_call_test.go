@@ -0,0 +1,88 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types/mocks"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdktestutil "github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestCosmosCallTracer(t *testing.T) {
+	var (
+		sender     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		contract   = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		precompile = common.HexToAddress("0x0000000000000000000000000000000000000804")
+	)
+
+	ctx := sdktestutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("test_t"))
+	stateDB := statedb.New(ctx, mocks.NewEVMKeeper(), statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash())))
+
+	tracer, err := tracers.DefaultDirectory.New("cosmosCallTracer", &tracers.Context{}, nil, params.TestChainConfig)
+	require.NoError(t, err)
+
+	// precompileCall simulates a precompile call executing a bank send
+	precompileCall := func(callErr error) {
+		tracer.OnEnter(1, byte(vm.CALL), contract, precompile, nil, 50000, big.NewInt(0))
+		cacheCtx, err := stateDB.GetCacheContext()
+		require.NoError(t, err)
+		stateDB.SetTracer(tracer.Hooks)
+		stateDB.StartPrecompileTrace(precompile)
+		cacheCtx.EventManager().EmitEvent(sdk.NewEvent(banktypes.EventTypeTransfer, sdk.NewAttribute(sdk.AttributeKeyAmount, "1aatom")))
+		stateDB.TraceMsg(&banktypes.MsgSend{})
+		tracer.OnExit(1, nil, 1000, callErr, callErr != nil)
+	}
+
+	tx := types.NewTx(&types.LegacyTx{Gas: 100000, To: &contract, Value: big.NewInt(0)})
+	tracer.OnTxStart(&tracing.VMContext{StateDB: stateDB}, tx, sender)
+	tracer.OnEnter(0, byte(vm.CALL), sender, contract, nil, 100000, big.NewInt(0))
+	precompileCall(nil)
+	tracer.OnEnter(1, byte(vm.CALL), contract, sender, nil, 10000, big.NewInt(0))
+	tracer.OnExit(1, nil, 0, nil, false)
+	precompileCall(errors.New("execution reverted"))
+	tracer.OnExit(0, nil, 30000, nil, false)
+	tracer.OnTxEnd(&types.Receipt{GasUsed: 30000}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var result struct {
+		CosmosMsgs []string `json:"cosmosMsgs"`
+		Calls      []struct {
+			To           common.Address  `json:"to"`
+			CosmosMsgs   []string        `json:"cosmosMsgs"`
+			CosmosEvents json.RawMessage `json:"cosmosEvents"`
+		} `json:"calls"`
+	}
+	require.NoError(t, json.Unmarshal(res, &result))
+	require.Nil(t, result.CosmosMsgs)
+	require.Len(t, result.Calls, 3)
+
+	require.Equal(t, precompile, result.Calls[0].To)
+	require.Equal(t, []string{sdk.MsgTypeURL(&banktypes.MsgSend{})}, result.Calls[0].CosmosMsgs)
+	require.JSONEq(t, `[{"type": "transfer", "attributes": [{"key": "amount", "value": "1aatom"}]}]`, string(result.Calls[0].CosmosEvents))
+
+	// not a precompile call
+	require.Nil(t, result.Calls[1].CosmosMsgs)
+	require.Nil(t, result.Calls[1].CosmosEvents)
+
+	// reverted precompile call
+	require.Nil(t, result.Calls[2].CosmosMsgs)
+	require.Nil(t, result.Calls[2].CosmosEvents)
+}
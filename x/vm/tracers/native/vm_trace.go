@@ -0,0 +1,278 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("parityVmTracer", newParityVMTracer, false)
+}
+
+// vmTrace is the Parity/OpenEthereum representation of the execution of a
+// call frame: the executed code and each of its operations.
+type vmTrace struct {
+	Code hexutil.Bytes  `json:"code"`
+	Ops  []*vmOperation `json:"ops"`
+}
+
+// vmOperation is an operation of a vmTrace. The trace of the call frame it
+// creates, if any, is set as sub.
+type vmOperation struct {
+	Cost uint64      `json:"cost"`
+	Ex   *vmExecuted `json:"ex"`
+	PC   uint64      `json:"pc"`
+	Sub  *vmTrace    `json:"sub"`
+}
+
+// vmExecuted holds the effects of a successfully executed operation.
+type vmExecuted struct {
+	Mem   *vmMemoryDiff  `json:"mem"`
+	Push  []*hexutil.Big `json:"push"`
+	Store *vmStorageDiff `json:"store"`
+	Used  uint64         `json:"used"`
+}
+
+type vmMemoryDiff struct {
+	Off  uint64        `json:"off"`
+	Data hexutil.Bytes `json:"data"`
+}
+
+type vmStorageDiff struct {
+	Key *hexutil.Big `json:"key"`
+	Val *hexutil.Big `json:"val"`
+}
+
+// vmFrame is a call frame being traced along with its last operation, whose
+// effects are only known once the next operation of the frame starts or the
+// frame exits.
+type vmFrame struct {
+	trace   *vmTrace
+	gas     uint64
+	pending *vmPendingOp
+}
+
+type vmPendingOp struct {
+	op      *vmOperation
+	opcode  vm.OpCode
+	scope   tracing.OpContext
+	memOff  uint64
+	memSize uint64
+	store   *vmStorageDiff
+	failed  bool
+}
+
+// parityVMTracer is a native tracer producing the `vmTrace` output of the
+// Parity `trace_replayTransaction` and `trace_call` methods.
+type parityVMTracer struct {
+	frames    []*vmFrame
+	result    *vmTrace
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newParityVMTracer returns a native go tracer which records the operations
+// executed by each call frame in the Parity vmTrace format.
+func newParityVMTracer(_ *tracers.Context, _ json.RawMessage, _ *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &parityVMTracer{}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter:  t.OnEnter,
+			OnExit:   t.OnExit,
+			OnOpcode: t.OnOpcode,
+			OnFault:  t.OnFault,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnEnter starts the trace of a new call frame, as the sub trace of the
+// operation that created it.
+func (t *parityVMTracer) OnEnter(_ int, _ byte, _ common.Address, _ common.Address, _ []byte, gas uint64, _ *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+
+	trace := &vmTrace{Code: hexutil.Bytes{}, Ops: []*vmOperation{}}
+	if len(t.frames) > 0 {
+		if parent := t.frames[len(t.frames)-1]; parent.pending != nil {
+			parent.pending.op.Sub = trace
+		}
+	}
+	t.frames = append(t.frames, &vmFrame{trace: trace, gas: gas})
+}
+
+// OnExit completes the last operation of the call frame.
+func (t *parityVMTracer) OnExit(_ int, _ []byte, gasUsed uint64, _ error, _ bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	var gasLeft uint64
+	if frame.gas > gasUsed {
+		gasLeft = frame.gas - gasUsed
+	}
+	frame.complete(gasLeft)
+
+	if len(t.frames) == 0 {
+		t.result = frame.trace
+	}
+}
+
+// OnOpcode completes the previous operation of the call frame and records the
+// new one.
+func (t *parityVMTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, _ []byte, _ int, _ error) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+
+	frame := t.frames[len(t.frames)-1]
+	frame.complete(gas)
+	if len(frame.trace.Ops) == 0 {
+		frame.trace.Code = scope.ContractCode()
+	}
+
+	operation := &vmOperation{Cost: cost, PC: pc}
+	frame.trace.Ops = append(frame.trace.Ops, operation)
+	frame.pending = newVMPendingOp(operation, vm.OpCode(op), scope)
+}
+
+// OnFault marks the last operation of the call frame as failed.
+func (t *parityVMTracer) OnFault(_ uint64, _ byte, _, _ uint64, _ tracing.OpContext, _ int, _ error) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+
+	if pending := t.frames[len(t.frames)-1].pending; pending != nil {
+		pending.failed = true
+	}
+}
+
+// GetResult returns the json-encoded vmTrace of the top-level call frame.
+func (t *parityVMTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *parityVMTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// newVMPendingOp records the memory range and storage slot written by the
+// operation, read from the stack before its execution.
+func newVMPendingOp(operation *vmOperation, opcode vm.OpCode, scope tracing.OpContext) *vmPendingOp {
+	pending := &vmPendingOp{op: operation, opcode: opcode, scope: scope}
+
+	stack := scope.StackData()
+	peek := func(n int) *uint256.Int {
+		if n >= len(stack) {
+			return new(uint256.Int)
+		}
+		return &stack[len(stack)-1-n]
+	}
+	memRange := func(off, size *uint256.Int) {
+		if off.IsUint64() && size.IsUint64() {
+			pending.memOff, pending.memSize = off.Uint64(), size.Uint64()
+		}
+	}
+
+	switch opcode {
+	case vm.MSTORE:
+		memRange(peek(0), uint256.NewInt(32))
+	case vm.MSTORE8:
+		memRange(peek(0), uint256.NewInt(1))
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY, vm.MCOPY:
+		memRange(peek(0), peek(2))
+	case vm.EXTCODECOPY:
+		memRange(peek(1), peek(3))
+	case vm.CALL, vm.CALLCODE:
+		memRange(peek(5), peek(6))
+	case vm.DELEGATECALL, vm.STATICCALL:
+		memRange(peek(4), peek(5))
+	case vm.SSTORE:
+		pending.store = &vmStorageDiff{
+			Key: (*hexutil.Big)(peek(0).ToBig()),
+			Val: (*hexutil.Big)(peek(1).ToBig()),
+		}
+	}
+	return pending
+}
+
+// complete sets the effects of the pending operation of the frame, given the
+// gas left after its execution.
+func (f *vmFrame) complete(gasLeft uint64) {
+	pending := f.pending
+	if pending == nil {
+		return
+	}
+	f.pending = nil
+	if pending.failed {
+		return
+	}
+
+	ex := &vmExecuted{Used: gasLeft, Push: []*hexutil.Big{}, Store: pending.store}
+
+	stack := pending.scope.StackData()
+	pushed := stackPushes(pending.opcode)
+	if pushed > len(stack) {
+		pushed = len(stack)
+	}
+	for _, item := range stack[len(stack)-pushed:] {
+		ex.Push = append(ex.Push, (*hexutil.Big)(item.ToBig()))
+	}
+
+	if pending.memSize > 0 {
+		memory := pending.scope.MemoryData()
+		if end := pending.memOff + pending.memSize; end >= pending.memOff && end <= uint64(len(memory)) {
+			ex.Mem = &vmMemoryDiff{
+				Off:  pending.memOff,
+				Data: common.CopyBytes(memory[pending.memOff:end]),
+			}
+		}
+	}
+
+	pending.op.Ex = ex
+}
+
+// stackPushes returns the number of stack items reported as pushed by the
+// opcode. Following Parity, DUP and SWAP operations report all the items they
+// rearrange.
+func stackPushes(op vm.OpCode) int {
+	switch {
+	case op >= vm.PUSH0 && op <= vm.PUSH32:
+		return 1
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		return int(op-vm.DUP1) + 2
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		return int(op-vm.SWAP1) + 2
+	case op >= vm.LOG0 && op <= vm.LOG4:
+		return 0
+	}
+
+	switch op {
+	case vm.STOP, vm.POP, vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.TSTORE, vm.JUMP, vm.JUMPI,
+		vm.JUMPDEST, vm.RETURN, vm.REVERT, vm.SELFDESTRUCT, vm.INVALID, vm.CALLDATACOPY,
+		vm.CODECOPY, vm.RETURNDATACOPY, vm.EXTCODECOPY, vm.MCOPY:
+		return 0
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,137 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// opContext is a mutable tracing.OpContext, updated by the test as the EVM
+// would update the scope of a call frame.
+type opContext struct {
+	memory []byte
+	stack  []uint256.Int
+	code   []byte
+}
+
+func (c *opContext) MemoryData() []byte       { return c.memory }
+func (c *opContext) StackData() []uint256.Int { return c.stack }
+func (c *opContext) Caller() common.Address   { return common.Address{} }
+func (c *opContext) Address() common.Address  { return common.Address{} }
+func (c *opContext) CallValue() *uint256.Int  { return new(uint256.Int) }
+func (c *opContext) CallInput() []byte        { return nil }
+func (c *opContext) ContractCode() []byte     { return c.code }
+func (c *opContext) setStack(items ...uint64) {
+	c.stack = make([]uint256.Int, len(items))
+	for i, item := range items {
+		c.stack[i].SetUint64(item)
+	}
+}
+
+func TestParityVMTracer(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		contract = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		callee   = common.BigToAddress(big.NewInt(3))
+	)
+
+	tracer, err := tracers.DefaultDirectory.New("parityVmTracer", &tracers.Context{}, nil, params.TestChainConfig)
+	require.NoError(t, err)
+
+	scope := &opContext{code: []byte{byte(vm.PUSH1), 0x2a}}
+	subScope := &opContext{code: []byte{byte(vm.PUSH1), 0x05, byte(vm.STOP)}}
+
+	tracer.OnEnter(0, byte(vm.CALL), sender, contract, nil, 100000, big.NewInt(0))
+	tracer.OnOpcode(0, byte(vm.PUSH1), 100000, 3, scope, nil, 1, nil)
+	scope.setStack(0x2a)
+	tracer.OnOpcode(2, byte(vm.PUSH1), 99997, 3, scope, nil, 1, nil)
+	// MSTORE 0x2a at offset 0
+	scope.setStack(0x2a, 0)
+	tracer.OnOpcode(4, byte(vm.MSTORE), 99994, 6, scope, nil, 1, nil)
+	scope.setStack()
+	scope.memory = common.LeftPadBytes([]byte{0x2a}, 32)
+	// SSTORE 1 at slot 2
+	tracer.OnOpcode(5, byte(vm.PUSH1), 99988, 3, scope, nil, 1, nil)
+	scope.setStack(1)
+	tracer.OnOpcode(7, byte(vm.PUSH1), 99985, 3, scope, nil, 1, nil)
+	scope.setStack(1, 2)
+	tracer.OnOpcode(9, byte(vm.SSTORE), 99982, 20000, scope, nil, 1, nil)
+	scope.setStack()
+	// CALL the callee, writing its 32 bytes of return data at offset 32
+	tracer.OnOpcode(10, byte(vm.PUSH1), 79982, 3, scope, nil, 1, nil)
+	scope.setStack(32, 32, 0, 0, 0, 3, 1000)
+	tracer.OnOpcode(12, byte(vm.CALL), 79979, 100, scope, nil, 1, nil)
+	tracer.OnEnter(1, byte(vm.CALL), contract, callee, nil, 1000, big.NewInt(0))
+	tracer.OnOpcode(0, byte(vm.PUSH1), 1000, 3, subScope, nil, 2, nil)
+	subScope.setStack(5)
+	tracer.OnOpcode(2, byte(vm.STOP), 997, 0, subScope, nil, 2, nil)
+	tracer.OnExit(1, nil, 3, nil, false)
+	scope.setStack(1)
+	scope.memory = append(scope.memory, common.LeftPadBytes([]byte{0x05}, 32)...)
+	// the last operation runs out of gas
+	tracer.OnOpcode(13, byte(vm.INVALID), 79000, 0, scope, nil, 1, nil)
+	tracer.OnFault(13, byte(vm.INVALID), 79000, 0, scope, 1, vm.ErrOutOfGas)
+	tracer.OnExit(0, nil, 100000, vm.ErrOutOfGas, false)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var trace vmTrace
+	require.NoError(t, json.Unmarshal(res, &trace))
+	require.Equal(t, hexutil.Bytes(scope.code), trace.Code)
+	require.Len(t, trace.Ops, 9)
+
+	push := func(values ...int64) []*hexutil.Big {
+		result := make([]*hexutil.Big, len(values))
+		for i, value := range values {
+			result[i] = (*hexutil.Big)(big.NewInt(value))
+		}
+		return result
+	}
+
+	// PUSH1 0x2a
+	require.Equal(t, uint64(0), trace.Ops[0].PC)
+	require.Equal(t, uint64(3), trace.Ops[0].Cost)
+	require.Equal(t, &vmExecuted{Push: push(0x2a), Used: 99997}, trace.Ops[0].Ex)
+
+	// MSTORE
+	require.Equal(t, &vmExecuted{
+		Mem:  &vmMemoryDiff{Off: 0, Data: common.LeftPadBytes([]byte{0x2a}, 32)},
+		Push: push(),
+		Used: 99988,
+	}, trace.Ops[2].Ex)
+
+	// SSTORE
+	require.Equal(t, &vmExecuted{
+		Push:  push(),
+		Store: &vmStorageDiff{Key: (*hexutil.Big)(big.NewInt(2)), Val: (*hexutil.Big)(big.NewInt(1))},
+		Used:  79982,
+	}, trace.Ops[5].Ex)
+
+	// CALL, with the return data written to memory and the sub trace
+	call := trace.Ops[7]
+	require.Equal(t, uint64(12), call.PC)
+	require.Equal(t, &vmExecuted{
+		Mem:  &vmMemoryDiff{Off: 32, Data: common.LeftPadBytes([]byte{0x05}, 32)},
+		Push: push(1),
+		Used: 79000,
+	}, call.Ex)
+	require.NotNil(t, call.Sub)
+	require.Equal(t, hexutil.Bytes(subScope.code), call.Sub.Code)
+	require.Len(t, call.Sub.Ops, 2)
+	require.Equal(t, &vmExecuted{Push: push(5), Used: 997}, call.Sub.Ops[0].Ex)
+	require.Equal(t, &vmExecuted{Push: push(), Used: 997}, call.Sub.Ops[1].Ex)
+	require.Nil(t, call.Sub.Ops[1].Sub)
+
+	// the faulted operation has no effects
+	require.Equal(t, uint64(13), trace.Ops[8].PC)
+	require.Nil(t, trace.Ops[8].Ex)
+}
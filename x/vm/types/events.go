@@ -6,6 +6,7 @@ const (
 	EventTypeBlockBloom = "block_bloom"
 	EventTypeTxLog      = "tx_log"
 	EventTypeFeeMarket  = "evm_fee_market"
+	EventTypeBlockRoots = "block_roots"
 
 	AttributeKeyBaseFee         = "base_fee"
 	AttributeKeyContractAddress = "contract"
@@ -21,6 +22,8 @@ const (
 	AttributeKeyEthereumTxFailed = "ethereumTxFailed"
 	AttributeValueCategory       = ModuleName
 	AttributeKeyEthereumBloom    = "bloom"
+	AttributeKeyTxRoot           = "transactionsRoot"
+	AttributeKeyReceiptsRoot     = "receiptsRoot"
 
 	MetricKeyTransitionDB = "transition_db"
 	MetricKeyStaticCall   = "static_call"
@@ -29,6 +29,7 @@ type AccountKeeper interface {
 	GetAccount(ctx context.Context, addr sdk.AccAddress) sdk.AccountI
 	SetAccount(ctx context.Context, account sdk.AccountI)
 	RemoveAccount(ctx context.Context, account sdk.AccountI)
+	IterateAccounts(ctx context.Context, cb func(account sdk.AccountI) (stop bool))
 	GetParams(ctx context.Context) (params authtypes.Params)
 	GetSequence(ctx context.Context, account sdk.AccAddress) (uint64, error)
 	AddressCodec() address.Codec
@@ -2,6 +2,8 @@ package types
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
@@ -27,6 +29,7 @@ const (
 	prefixStorage
 	prefixParams
 	prefixCodeHash
+	prefixBlockRoots
 )
 
 // prefix bytes for the EVM transient store
@@ -35,22 +38,31 @@ const (
 	prefixTransientTxIndex
 	prefixTransientLogSize
 	prefixTransientGasUsed
+	prefixTransientTx
+	prefixTransientReceipt
 )
 
 // KVStore key prefixes
 var (
-	KeyPrefixCode     = []byte{prefixCode}
-	KeyPrefixStorage  = []byte{prefixStorage}
-	KeyPrefixParams   = []byte{prefixParams}
-	KeyPrefixCodeHash = []byte{prefixCodeHash}
+	KeyPrefixCode       = []byte{prefixCode}
+	KeyPrefixStorage    = []byte{prefixStorage}
+	KeyPrefixParams     = []byte{prefixParams}
+	KeyPrefixCodeHash   = []byte{prefixCodeHash}
+	KeyPrefixBlockRoots = []byte{prefixBlockRoots}
 )
 
+// BlockRootsHistory is the number of recent blocks whose transactions and
+// receipts roots are kept in the store.
+const BlockRootsHistory = 8192
+
 // Transient Store key prefixes
 var (
 	KeyPrefixTransientBloom   = []byte{prefixTransientBloom}
 	KeyPrefixTransientTxIndex = []byte{prefixTransientTxIndex}
 	KeyPrefixTransientLogSize = []byte{prefixTransientLogSize}
 	KeyPrefixTransientGasUsed = []byte{prefixTransientGasUsed}
+	KeyPrefixTransientTx      = []byte{prefixTransientTx}
+	KeyPrefixTransientReceipt = []byte{prefixTransientReceipt}
 )
 
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
@@ -62,3 +74,9 @@ func AddressStoragePrefix(address common.Address) []byte {
 func StateKey(address common.Address, key []byte) []byte {
 	return append(AddressStoragePrefix(address), key...)
 }
+
+// BlockRootsKey defines the full key under which the transactions and receipts
+// roots of the block at the given height are stored.
+func BlockRootsKey(height uint64) []byte {
+	return append(KeyPrefixBlockRoots, sdk.Uint64ToBigEndian(height)...)
+}
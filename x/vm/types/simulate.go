@@ -0,0 +1,80 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// MaxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single `eth_simulateV1` request.
+	MaxSimulateBlocks = 256
+	// SimulateTimestampIncrement is the default increment between the timestamps
+	// of consecutive simulated blocks.
+	SimulateTimestampIncrement = 12
+)
+
+// Error codes returned in the simulated call results. They follow the ones
+// used by go-ethereum.
+const (
+	SimErrCodeReverted = 3
+	SimErrCodeVMError  = -32015
+)
+
+var (
+	// SimTransferTopic is the topic of the synthetic logs emitted for native
+	// value transfers: keccak256("Transfer(address,address,uint256)").
+	SimTransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	// SimTransferAddress is the address of the synthetic logs emitted for native
+	// value transfers, as defined by ERC-7528.
+	SimTransferAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+)
+
+// SimOpts are the inputs of a multi-block simulation.
+type SimOpts struct {
+	BlockStateCalls        []SimBlock `json:"blockStateCalls"`
+	TraceTransfers         bool       `json:"traceTransfers"`
+	Validation             bool       `json:"validation"`
+	ReturnFullTransactions bool       `json:"returnFullTransactions"`
+}
+
+// SimBlock is a batch of calls to be simulated sequentially on top of the
+// given block and state overrides.
+type SimBlock struct {
+	BlockOverrides *BlockOverrides   `json:"blockOverrides,omitempty"`
+	StateOverrides *StateOverride    `json:"stateOverrides,omitempty"`
+	Calls          []TransactionArgs `json:"calls"`
+}
+
+// SimBlockResult is the result of a simulated block. The transactions are
+// returned encoded and unsigned, together with their sender, so that the
+// caller can format them as needed.
+type SimBlockResult struct {
+	Header       *ethtypes.Header `json:"header"`
+	Transactions []SimTransaction `json:"transactions"`
+	Calls        []SimCallResult  `json:"calls"`
+}
+
+// SimTransaction is an unsigned transaction built from the arguments of a
+// simulated call.
+type SimTransaction struct {
+	From common.Address `json:"from"`
+	Tx   hexutil.Bytes  `json:"tx"`
+}
+
+// SimCallResult is the result of a simulated call.
+type SimCallResult struct {
+	ReturnValue hexutil.Bytes   `json:"returnData"`
+	Logs        []*ethtypes.Log `json:"logs"`
+	GasUsed     hexutil.Uint64  `json:"gasUsed"`
+	Status      hexutil.Uint64  `json:"status"`
+	Error       *SimCallError   `json:"error,omitempty"`
+}
+
+// SimCallError is the error of a failed simulated call.
+type SimCallError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Data    string `json:"data,omitempty"`
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"io"
 	"math/big"
 	"os"
 
@@ -20,11 +21,29 @@ const (
 	TracerMarkdown   = "markdown"
 )
 
+// TracerConfig defines the logger configuration of the tracers created by
+// NewTracer.
+type TracerConfig struct {
+	EnableMemory     bool // enable memory capture
+	DisableStack     bool // disable stack capture
+	DisableStorage   bool // disable storage capture
+	EnableReturnData bool // enable return data capture
+	Limit            int  // maximum size of the output, zero for no limit
+	// Output is the writer of the json and markdown tracers, which default to
+	// stderr and stdout respectively.
+	Output io.Writer
+}
+
 // NewTracer creates a new Logger tracer to collect execution traces from an
 // EVM transaction.
-func NewTracer(tracer string, msg core.Message, cfg *params.ChainConfig, height int64, timestamp uint64) *tracing.Hooks {
-	// TODO: enable additional log configuration
-	logCfg := &logger.Config{}
+func NewTracer(tracer string, tracerCfg TracerConfig, msg core.Message, cfg *params.ChainConfig, height int64, timestamp uint64) *tracing.Hooks {
+	logCfg := &logger.Config{
+		EnableMemory:     tracerCfg.EnableMemory,
+		DisableStack:     tracerCfg.DisableStack,
+		DisableStorage:   tracerCfg.DisableStorage,
+		EnableReturnData: tracerCfg.EnableReturnData,
+		Limit:            tracerCfg.Limit,
+	}
 
 	switch tracer {
 	case TracerAccessList:
@@ -37,9 +56,9 @@ func NewTracer(tracer string, msg core.Message, cfg *params.ChainConfig, height
 		}
 		return logger.NewAccessListTracer(msg.AccessList, blockAddrs).Hooks()
 	case TracerJSON:
-		return logger.NewJSONLogger(logCfg, os.Stderr)
+		return logger.NewJSONLogger(logCfg, tracerOutput(tracerCfg, os.Stderr))
 	case TracerMarkdown:
-		return logger.NewMarkdownLogger(logCfg, os.Stdout).Hooks() // TODO: Stderr ?
+		return logger.NewMarkdownLogger(logCfg, tracerOutput(tracerCfg, os.Stdout)).Hooks()
 	case TracerStruct:
 		return logger.NewStructLogger(logCfg).Hooks()
 	default:
@@ -47,6 +66,15 @@ func NewTracer(tracer string, msg core.Message, cfg *params.ChainConfig, height
 	}
 }
 
+// tracerOutput returns the output of the tracer configuration, or the given
+// default writer if it isn't set.
+func tracerOutput(cfg TracerConfig, defaultOutput io.Writer) io.Writer {
+	if cfg.Output == nil {
+		return defaultOutput
+	}
+	return cfg.Output
+}
+
 // TxTraceResult is the result of a single transaction trace during a block trace.
 type TxTraceResult struct {
 	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
@@ -0,0 +1,79 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/evm/x/vm/statedb"
+	"github.com/cosmos/evm/x/vm/types"
+	"github.com/cosmos/evm/x/vm/types/mocks"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdktestutil "github.com/cosmos/cosmos-sdk/testutil"
+)
+
+// opContext is a static tracing.OpContext
+type opContext struct {
+	memory []byte
+	stack  []uint256.Int
+}
+
+func (c opContext) MemoryData() []byte       { return c.memory }
+func (c opContext) StackData() []uint256.Int { return c.stack }
+func (c opContext) Caller() common.Address   { return common.Address{} }
+func (c opContext) Address() common.Address  { return common.Address{} }
+func (c opContext) CallValue() *uint256.Int  { return uint256.NewInt(0) }
+func (c opContext) CallInput() []byte        { return nil }
+func (c opContext) ContractCode() []byte     { return nil }
+
+func TestNewTracerConfig(t *testing.T) {
+	to := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	msg := core.Message{From: common.HexToAddress("0x1000000000000000000000000000000000000001"), To: &to}
+	scope := opContext{memory: []byte{0x01}, stack: []uint256.Int{*uint256.NewInt(2)}}
+
+	testCases := []struct {
+		name      string
+		cfg       types.TracerConfig
+		expMemory bool
+		expStack  bool
+	}{
+		{"default config", types.TracerConfig{}, false, true},
+		{"memory enabled and stack disabled", types.TracerConfig{EnableMemory: true, DisableStack: true}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+			tc.cfg.Output = &output
+
+			hooks := types.NewTracer(types.TracerJSON, tc.cfg, msg, params.TestChainConfig, 1, 0)
+			require.NotNil(t, hooks)
+
+			ctx := sdktestutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("test_t"))
+			stateDB := statedb.New(ctx, mocks.NewEVMKeeper(), statedb.NewEmptyTxConfig(common.Hash{}))
+			tx := ethtypes.NewTx(&ethtypes.LegacyTx{Gas: 100000, To: &to, Value: big.NewInt(0)})
+			hooks.OnTxStart(&tracing.VMContext{StateDB: stateDB}, tx, msg.From)
+			hooks.OnOpcode(0, byte(vm.PUSH1), 100000, 3, scope, nil, 1, nil)
+
+			var log struct {
+				Memory *string   `json:"memory"`
+				Stack  *[]string `json:"stack"`
+			}
+			require.NoError(t, json.Unmarshal(output.Bytes(), &log))
+			require.Equal(t, tc.expMemory, log.Memory != nil)
+			require.Equal(t, tc.expStack, log.Stack != nil)
+		})
+	}
+}